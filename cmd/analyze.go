@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/keylog"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze <keylog>",
+	Short: "Vim 키 입력 기록을 분석해 사용 습관과 추천 명령어를 보여줍니다",
+	Long: `vim -w 로 기록한 키 입력 파일(scriptout)을 분석합니다.
+
+키 입력을 노멀 모드 문법([횟수]["레지스터]명령[이동])으로 나누어
+명령어별 사용 횟수를 세고, 다음과 같은 비효율적인 습관을 찾습니다:
+  jjjjjj   → 6j     같은 이동 반복
+  xxxx     → dw     글자 단위 삭제 반복
+  dddddd   → 3dd    줄 명령 반복
+  $a       → A      더 짧은 명령어
+  같은 변경 → .      변경 다시 입력
+  화살표 키 → hjkl
+
+찾은 습관에 맞는 카탈로그 명령어와 강의를 추천합니다.
+모든 분석은 로컬에서만 이루어집니다.
+
+키 입력 기록 방법:
+  vim -w ~/vim-keys.log 파일이름     (기록이 파일 끝에 추가됩니다)
+
+사용 예시:
+  vi-assistant analyze ~/vim-keys.log
+  vi-assistant analyze ~/vim-keys.log --top 20`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+		top, _ := cmd.Flags().GetInt("top")
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			data, err = ioutil.ReadFile(args[0])
+		}
+		if err != nil {
+			fmt.Printf("키 입력 기록을 읽을 수 없습니다: %v\n", err)
+			return
+		}
+
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 카탈로그 오류: %v\n", err)
+			return
+		}
+
+		report := keylog.NewAnalyzer(commands, lang).Analyze(args[0], data)
+		if viper.GetString("output.format") == "json" {
+			printJSON(report)
+			return
+		}
+		fmt.Print(keylog.FormatReport(report, top, lang))
+	},
+}
+
+func init() {
+	analyzeCmd.Flags().Int("top", 10, "보여줄 자주 쓴 명령어 개수 (0이면 전체)")
+	rootCmd.AddCommand(analyzeCmd)
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/vimhelp"
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "명령어 카탈로그와 사용자 명령어 팩을 관리합니다",
+	Long: `명령어 카탈로그(내장 commands.json과 사용자 명령어 팩)를 확인합니다.
+
+사용자 명령어 팩은 팩 디렉토리(기본값: $XDG_DATA_HOME/vi-assistant/packs)의
+*.json 파일로, commands.json과 같은 형식을 사용합니다. 파일 이름(또는 "name" 필드)이
+팩 이름이 되어 search/explain 결과의 출처로 표시됩니다.
+
+카탈로그 파일 형식 (schema_version 1):
+  {
+    "schema_version": 1,
+    "name": "fugitive",
+    "categories": ["git"],
+    "commands": [
+      {"keyword": "...", "command": "...", "description": "...", "example": "...", "category": "..."}
+    ]
+  }
+명령어 배열만 있는 파일도 버전 1로 읽습니다. "categories"는 기본 카테고리 외에
+팩에서 쓰는 카테고리를 선언합니다.
+
+명령어가 겹치면:
+  - 팩끼리는 파일 이름 순서로 나중 팩이 우선합니다
+  - 팩과 내장 명령어는 packs.precedence 설정을 따릅니다 (pack: 팩 우선, builtin: 내장 우선)
+
+하위 명령어:
+  packs - 불러온 팩과 겹치는 명령어 보기
+  lint           - 카탈로그 파일을 스키마에 맞게 검사
+  import-vimhelp - 로컬 Vim 도움말(doc 디렉토리)에서 명령어 팩 만들기
+
+사용 예시:
+  vi-assistant catalog packs
+  vi-assistant catalog lint
+  vi-assistant catalog lint ./my-pack.json
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91/doc --install`,
+}
+
+var catalogPacksCmd = &cobra.Command{
+	Use:   "packs",
+	Short: "불러온 명령어 팩과 겹치는 명령어를 보여줍니다",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		result, err := catalog.LoadAll()
+		if err != nil {
+			fmt.Printf("카탈로그 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Pack directory: %s\n", catalog.PackDir())
+			fmt.Printf("Precedence: %s\n\n", viper.GetString("packs.precedence"))
+		} else {
+			fmt.Printf("팩 디렉토리: %s\n", catalog.PackDir())
+			fmt.Printf("우선순위: %s\n\n", viper.GetString("packs.precedence"))
+		}
+
+		if len(result.Packs) == 0 {
+			if lang == "en" {
+				fmt.Println("No command packs loaded.")
+			} else {
+				fmt.Println("불러온 명령어 팩이 없습니다.")
+			}
+		}
+		for _, pack := range result.Packs {
+			if lang == "en" {
+				fmt.Printf("%s (%d command(s)) - %s\n", pack.Name, pack.Commands, pack.Path)
+			} else {
+				fmt.Printf("%s (명령어 %d개) - %s\n", pack.Name, pack.Commands, pack.Path)
+			}
+		}
+
+		if len(result.Collisions) > 0 {
+			if lang == "en" {
+				fmt.Println("\nOverridden commands:")
+			} else {
+				fmt.Println("\n겹치는 명령어:")
+			}
+			for _, c := range result.Collisions {
+				fmt.Printf("  %-12s %s > %s\n", c.Command, c.Winner, c.Loser)
+			}
+		}
+
+		for _, warning := range result.Warnings {
+			fmt.Printf("\n%s\n", warning)
+		}
+	},
+}
+
+var catalogLintCmd = &cobra.Command{
+	Use:   "lint [file...]",
+	Short: "카탈로그 파일을 스키마에 맞게 검사합니다",
+	Long: `카탈로그 파일을 검사하고 문제를 파일:줄 위치와 함께 보여줍니다.
+파일을 지정하지 않으면 내장 카탈로그와 팩 디렉토리의 모든 팩을 검사합니다.
+
+오류 (error):
+  - JSON 문법 오류, 지원하지 않는 schema_version
+  - 알 수 없는 필드, 비어 있는 필수 필드
+  - 같은 파일 안에서 중복된 command
+경고 (warning):
+  - 알 수 없는 카테고리 (팩의 "categories"로 선언 가능)
+  - 다른 명령어와 겹치는 명령어 (예: ":help"와 ":help command" - 인수는 {이름}으로 표기)
+  - 명령어가 나오지 않는 예제
+
+오류가 있으면 종료 코드 1로 끝납니다.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		var issues []catalog.Issue
+		if len(args) == 0 {
+			all, err := catalog.LintAll()
+			if err != nil {
+				fmt.Printf("카탈로그 오류: %v\n", err)
+				os.Exit(1)
+			}
+			issues = all
+		}
+		for _, path := range args {
+			fileIssues, err := catalog.LintFile(path)
+			if err != nil {
+				fmt.Printf("카탈로그 오류: %v\n", err)
+				os.Exit(1)
+			}
+			issues = append(issues, fileIssues...)
+		}
+
+		if viper.GetString("output.format") == "json" {
+			if issues == nil {
+				issues = []catalog.Issue{}
+			}
+			printJSON(issues)
+		} else {
+			errors := 0
+			for _, issue := range issues {
+				fmt.Println(issue.String())
+				if issue.Severity == catalog.SeverityError {
+					errors++
+				}
+			}
+			warnings := len(issues) - errors
+
+			if lang == "en" {
+				fmt.Printf("%d error(s), %d warning(s)\n", errors, warnings)
+			} else {
+				fmt.Printf("오류 %d개, 경고 %d개\n", errors, warnings)
+			}
+		}
+
+		if catalog.HasErrors(issues) {
+			os.Exit(1)
+		}
+	},
+}
+
+var catalogImportVimhelpCmd = &cobra.Command{
+	Use:   "import-vimhelp [doc-dir]",
+	Short: "로컬 Vim 도움말에서 공식 설명을 가져와 명령어 팩을 만듭니다",
+	Long: `Vim 도움말 디렉토리(tags 파일과 *.txt 도움말)를 읽어 명령어 팩을 만듭니다.
+네트워크 없이 로컬 디렉토리만 사용합니다 (예: /usr/share/vim/vim91/doc).
+
+- 카탈로그의 명령어와 맞는 도움말 태그가 있으면 Vim의 원문 설명과
+  :help 태그를 붙인 항목을 팩에 넣습니다. 팩을 설치하면 내장 항목을 보강합니다
+  (packs.precedence가 pack일 때).
+- --tag나 --file로 지정한 태그는 카탈로그에 없으면 새 항목으로 만듭니다.
+
+결과는 표준 출력이나 -o 파일로 쓰고, --install을 주면 팩 디렉토리에 저장합니다.
+
+사용 예시:
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91/doc -o vimhelp.json
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91 --tag gJ --tag CTRL-A --install
+  vi-assistant catalog import-vimhelp ~/.vim/doc --file change.txt --install`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		files, _ := cmd.Flags().GetStringSlice("file")
+		name, _ := cmd.Flags().GetString("name")
+		output, _ := cmd.Flags().GetString("output")
+		install, _ := cmd.Flags().GetBool("install")
+
+		ix, err := vimhelp.Open(args[0])
+		if err != nil {
+			fmt.Printf("도움말 오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("카탈로그 오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := vimhelp.Import(ix, commands, vimhelp.ImportOptions{Name: name, Tags: tags, Files: files})
+		if err != nil {
+			fmt.Printf("가져오기 오류: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := json.MarshalIndent(result.Document, "", "  ")
+		if err != nil {
+			fmt.Printf("JSON 변환 오류: %v\n", err)
+			os.Exit(1)
+		}
+		data = append(data, '\n')
+
+		if install {
+			if catalog.PackDir() == "" {
+				fmt.Println("팩 디렉토리가 설정되어 있지 않습니다 (packs.dir)")
+				os.Exit(1)
+			}
+			output = filepath.Join(catalog.PackDir(), result.Document.Name+".json")
+			if err := os.MkdirAll(catalog.PackDir(), 0755); err != nil {
+				fmt.Printf("팩 디렉토리를 만들 수 없습니다: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// 표준 출력으로 팩을 쓸 때는 요약을 표준 오류로 보냅니다
+		summary := os.Stdout
+		if output == "" || output == "-" {
+			os.Stdout.Write(data)
+			summary = os.Stderr
+		} else if err := ioutil.WriteFile(output, data, 0644); err != nil {
+			fmt.Printf("파일을 쓸 수 없습니다: %v\n", err)
+			os.Exit(1)
+		}
+
+		if lang == "en" {
+			fmt.Fprintf(summary, "Augmented %d command(s), created %d from %s\n", result.Augmented, result.Created, ix.Dir())
+			if output != "" && output != "-" {
+				fmt.Fprintf(summary, "Pack written to %s\n", output)
+			}
+			if len(result.Missing) > 0 {
+				fmt.Fprintf(summary, "Tags not found: %s\n", strings.Join(result.Missing, ", "))
+			}
+		} else {
+			fmt.Fprintf(summary, "%s에서 명령어 %d개를 보강하고 %d개를 새로 만들었습니다\n", ix.Dir(), result.Augmented, result.Created)
+			if output != "" && output != "-" {
+				fmt.Fprintf(summary, "팩 저장: %s\n", output)
+			}
+			if len(result.Missing) > 0 {
+				fmt.Fprintf(summary, "찾지 못한 태그: %s\n", strings.Join(result.Missing, ", "))
+			}
+		}
+	},
+}
+
+func init() {
+	catalogImportVimhelpCmd.Flags().StringSlice("tag", nil, "카탈로그에 없어도 가져올 도움말 태그 (여러 번 사용 가능)")
+	catalogImportVimhelpCmd.Flags().StringSlice("file", nil, "명령어 태그를 모두 가져올 도움말 파일 (예: change.txt)")
+	catalogImportVimhelpCmd.Flags().String("name", vimhelp.DefaultPackName, "팩 이름")
+	catalogImportVimhelpCmd.Flags().StringP("output", "o", "", "팩을 저장할 파일 (기본값: 표준 출력)")
+	catalogImportVimhelpCmd.Flags().Bool("install", false, "팩 디렉토리에 <name>.json으로 저장")
+
+	catalogCmd.AddCommand(catalogPacksCmd)
+	catalogCmd.AddCommand(catalogLintCmd)
+	catalogCmd.AddCommand(catalogImportVimhelpCmd)
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/cheatsheet"
+	"vi-assistant/internal/keyboard"
+	"vi-assistant/internal/learn"
+	"vi-assistant/internal/style"
+)
+
+// cheatsheetFormatSVG draws the normal-mode keyboard instead of a list
+const cheatsheetFormatSVG = "svg"
+
+// cheatsheet 명령어 플래그
+var (
+	cheatsheetFormat     string   // 출력 형식
+	cheatsheetOutput     string   // 출력 파일
+	cheatsheetLevel      string   // 이 단계까지 배우는 명령어만
+	cheatsheetCategories []string // 이 카테고리의 명령어만
+	cheatsheetFavorites  bool     // 즐겨찾기만
+	cheatsheetWidth      int      // 터미널 너비
+)
+
+var cheatsheetCmd = &cobra.Command{
+	Use:   "cheatsheet",
+	Short: "카탈로그로 인쇄용 치트시트를 만듭니다",
+	Long: `팩을 포함한 카탈로그에서 카테고리별로 묶은 치트시트를 만듭니다.
+
+형식:
+  terminal  터미널 너비에 맞춘 여러 단 배치 (기본값, 색상은 color 설정을 따름)
+  html      외부 파일 없이 열리는 인쇄용 HTML
+  svg       노멀 모드에서 각 키가 하는 일을 그린 키보드 배치도 (keymap.layout 설정의 자판)
+  markdown, text
+
+필터:
+  --level       그 단계까지의 강의에서 배우는 명령어만 (beginner/intermediate)
+  --category    지정한 카테고리만 (쉼표로 구분하거나 반복)
+  --favorites   즐겨찾기한 명령어만 (메모가 함께 표시됨)
+
+사용 예시:
+  vi-assistant cheatsheet
+  vi-assistant cheatsheet --level beginner --format html -o vi.html
+  vi-assistant cheatsheet --category navigation,search
+  vi-assistant cheatsheet --favorites --format svg -o keys.svg`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+
+		notes := make(map[string]string)
+		commands, err = filterCheatsheet(commands, notes)
+		if err != nil {
+			fmt.Printf("치트시트 오류: %v\n", err)
+			return
+		}
+		if len(commands) == 0 {
+			if lang == "en" {
+				fmt.Println("No commands match the filters.")
+			} else {
+				fmt.Println("조건에 맞는 명령어가 없습니다.")
+			}
+			return
+		}
+
+		var output string
+		switch cheatsheetFormat {
+		case cheatsheetFormatSVG:
+			var layout keyboard.Layout
+			if layout, err = keyboard.LayoutByName(viper.GetString("keymap.layout")); err == nil {
+				var b strings.Builder
+				err = keyboard.WriteSVG(&b, layout, keyboard.Bind(commands), lang)
+				output = b.String()
+			}
+		case cheatsheet.FormatTerminal, "":
+			output = cheatsheet.Columns(cheatsheetSheet(commands, notes, lang), columnsWidth(cheatsheetWidth), style.Enabled)
+		default:
+			output, err = cheatsheet.Render(cheatsheetSheet(commands, notes, lang), cheatsheetFormat)
+		}
+		if err != nil {
+			fmt.Printf("치트시트 출력 오류: %v\n", err)
+			return
+		}
+
+		if cheatsheetOutput == "" {
+			fmt.Print(output)
+			return
+		}
+		if err := ioutil.WriteFile(cheatsheetOutput, []byte(output), 0644); err != nil {
+			fmt.Printf("치트시트 저장 오류: %v\n", err)
+			return
+		}
+		if lang == "en" {
+			fmt.Printf("Wrote cheat sheet (%d commands) to %s.\n", len(commands), cheatsheetOutput)
+		} else {
+			fmt.Printf("치트시트(명령어 %d개)를 %s에 저장했습니다.\n", len(commands), cheatsheetOutput)
+		}
+	},
+}
+
+// filterCheatsheet keeps the commands matching the level, category and
+// favorites flags; the notes of favorites are put into notes
+func filterCheatsheet(commands []catalog.Command, notes map[string]string) ([]catalog.Command, error) {
+	maxLevel := -1
+	if cheatsheetLevel != "" {
+		for i, level := range learn.Levels {
+			if level == cheatsheetLevel {
+				maxLevel = i
+			}
+		}
+		if maxLevel < 0 {
+			return nil, fmt.Errorf("알 수 없는 단계입니다: %s (사용 가능: %s)", cheatsheetLevel, strings.Join(learn.Levels, ", "))
+		}
+	}
+
+	categories := make(map[string]bool)
+	for _, category := range cheatsheetCategories {
+		categories[strings.TrimSpace(category)] = true
+	}
+
+	var favorite map[string]bool
+	if cheatsheetFavorites {
+		fm, err := newFavoritesManager()
+		if err != nil {
+			return nil, err
+		}
+		list, err := fm.List()
+		if err != nil {
+			return nil, err
+		}
+		favorite = make(map[string]bool)
+		for _, f := range list {
+			favorite[f.Command] = true
+			notes[f.Command] = f.Note
+		}
+	}
+
+	var kept []catalog.Command
+	for _, cmd := range commands {
+		if len(categories) > 0 && !categories[cmd.Category] {
+			continue
+		}
+		if favorite != nil && !favorite[cmd.Command] {
+			continue
+		}
+		if maxLevel >= 0 {
+			// The Korean lessons are the complete set, so levels do not depend on lang
+			ref, ok := learn.FindLesson(cmd.Command, "ko")
+			if !ok || levelIndex(ref.Level) > maxLevel {
+				continue
+			}
+		}
+		kept = append(kept, cmd)
+	}
+	return kept, nil
+}
+
+// columnsWidth returns width, or the terminal width from $COLUMNS when width is not set
+func columnsWidth(width int) int {
+	if width <= 0 {
+		width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	if width <= 0 {
+		width = 80
+	}
+	return width
+}
+
+// levelIndex returns the position of level in learn.Levels
+func levelIndex(level string) int {
+	for i, l := range learn.Levels {
+		if l == level {
+			return i
+		}
+	}
+	return len(learn.Levels)
+}
+
+// cheatsheetSheet groups the commands into a titled sheet describing the filters
+func cheatsheetSheet(commands []catalog.Command, notes map[string]string, lang string) cheatsheet.Sheet {
+	entries := make([]cheatsheet.Entry, len(commands))
+	for i, cmd := range commands {
+		entries[i] = cheatsheet.Entry{Command: cmd.Command, Description: cmd.Description, Category: cmd.Category, Note: notes[cmd.Command]}
+	}
+
+	en := lang == "en"
+	var filters []string
+	if cheatsheetLevel != "" {
+		filters = append(filters, pick(en, cheatsheetLevel+" 단계까지", "up to "+cheatsheetLevel))
+	}
+	if len(cheatsheetCategories) > 0 {
+		filters = append(filters, strings.Join(cheatsheetCategories, ", "))
+	}
+	if cheatsheetFavorites {
+		filters = append(filters, pick(en, "즐겨찾기", "favorites"))
+	}
+	filters = append(filters, pick(en, fmt.Sprintf("명령어 %d개", len(commands)), fmt.Sprintf("%d commands", len(commands))))
+
+	sheet := cheatsheet.Group(pick(en, "vi 치트시트", "vi cheat sheet"), entries, lang)
+	sheet.Description = strings.Join(filters, " · ")
+	return sheet
+}
+
+func init() {
+	cheatsheetCmd.Flags().StringVar(&cheatsheetFormat, "format", cheatsheet.FormatTerminal,
+		"출력 형식 ("+strings.Join(append(append([]string{}, cheatsheet.Formats...), cheatsheetFormatSVG), "/")+")")
+	cheatsheetCmd.Flags().StringVarP(&cheatsheetOutput, "output", "o", "", "출력 파일 (생략하면 표준 출력)")
+	cheatsheetCmd.Flags().StringVar(&cheatsheetLevel, "level", "", "이 단계까지 배우는 명령어만 ("+strings.Join(learn.Levels, "/")+")")
+	cheatsheetCmd.Flags().StringSliceVar(&cheatsheetCategories, "category", nil, "이 카테고리의 명령어만 (쉼표로 구분하거나 반복)")
+	cheatsheetCmd.Flags().BoolVar(&cheatsheetFavorites, "favorites", false, "즐겨찾기한 명령어만")
+	cheatsheetCmd.Flags().IntVar(&cheatsheetWidth, "width", 0, "terminal 형식의 너비 (기본값: $COLUMNS 또는 80)")
+	rootCmd.AddCommand(cheatsheetCmd)
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/cheatsheet"
+)
+
+var collectionCmd = &cobra.Command{
+	Use:     "collection",
+	Aliases: []string{"col"},
+	Short:   "즐겨찾기 컬렉션(치트시트)을 관리합니다",
+	Long: `즐겨찾기를 "git-commit-editing", "refactoring" 같은 이름의 컬렉션으로 묶고
+카테고리별 치트시트로 출력합니다.
+
+하위 명령어:
+  create - 컬렉션 생성
+  delete - 컬렉션 삭제 (즐겨찾기는 유지)
+  add    - 컬렉션에 즐겨찾기 추가
+  remove - 컬렉션에서 즐겨찾기 제거
+  list   - 컬렉션 목록 보기
+  show   - 컬렉션을 치트시트로 출력 (terminal/markdown/html/text)
+
+사용 예시:
+  vi-assistant fav collection create refactoring --description "리팩터링할 때 쓰는 명령어"
+  vi-assistant fav collection add refactoring :%s/old/new/g dd p
+  vi-assistant fav collection show refactoring --format html -o refactoring.html`,
+}
+
+// 컬렉션 하위 명령어 플래그
+var (
+	collectionDescription string // collection create 설명
+	collectionFormat      string // collection show 출력 형식
+	collectionOutput      string // collection show 출력 파일
+)
+
+var collectionCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "새 컬렉션을 만듭니다",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.CreateCollection(args[0], collectionDescription); err != nil {
+			fmt.Printf("컬렉션 생성 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Created collection '%s'.\n", args[0])
+		} else {
+			fmt.Printf("'%s' 컬렉션을 만들었습니다.\n", args[0])
+		}
+	},
+}
+
+var collectionDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "컬렉션을 삭제합니다 (즐겨찾기는 유지됩니다)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.DeleteCollection(args[0]); err != nil {
+			fmt.Printf("컬렉션 삭제 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Deleted collection '%s'.\n", args[0])
+		} else {
+			fmt.Printf("'%s' 컬렉션을 삭제했습니다.\n", args[0])
+		}
+	},
+}
+
+var collectionAddCmd = &cobra.Command{
+	Use:   "add [name] [command...]",
+	Short: "컬렉션에 즐겨찾기 명령어를 추가합니다",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.AddToCollection(args[0], args[1:]...); err != nil {
+			fmt.Printf("컬렉션 추가 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Added %s to collection '%s'.\n", strings.Join(args[1:], ", "), args[0])
+		} else {
+			fmt.Printf("'%s' 컬렉션에 추가했습니다: %s\n", args[0], strings.Join(args[1:], ", "))
+		}
+	},
+}
+
+var collectionRemoveCmd = &cobra.Command{
+	Use:   "remove [name] [command...]",
+	Short: "컬렉션에서 명령어를 제거합니다",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.RemoveFromCollection(args[0], args[1:]...); err != nil {
+			fmt.Printf("컬렉션 제거 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Removed %s from collection '%s'.\n", strings.Join(args[1:], ", "), args[0])
+		} else {
+			fmt.Printf("'%s' 컬렉션에서 제거했습니다: %s\n", args[0], strings.Join(args[1:], ", "))
+		}
+	},
+}
+
+var collectionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "컬렉션 목록을 표시합니다",
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		collections, err := fm.Collections()
+		if err != nil {
+			fmt.Printf("컬렉션 목록 오류: %v\n", err)
+			return
+		}
+
+		if len(collections) == 0 {
+			if lang == "en" {
+				fmt.Println("No collections found.")
+			} else {
+				fmt.Println("컬렉션이 없습니다.")
+			}
+			return
+		}
+
+		for _, c := range collections {
+			if lang == "en" {
+				fmt.Printf("%s (%d command(s))\n", c.Name, len(c.Commands))
+			} else {
+				fmt.Printf("%s (명령어 %d개)\n", c.Name, len(c.Commands))
+			}
+			if c.Description != "" {
+				fmt.Printf("   %s\n", c.Description)
+			}
+		}
+	},
+}
+
+var collectionShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "컬렉션을 치트시트로 출력합니다",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		sheet, err := fm.CollectionSheet(args[0], lang)
+		if err != nil {
+			fmt.Printf("컬렉션 오류: %v\n", err)
+			return
+		}
+
+		output, err := cheatsheet.Render(sheet, collectionFormat)
+		if err != nil {
+			fmt.Printf("치트시트 출력 오류: %v\n", err)
+			return
+		}
+
+		if collectionOutput == "" {
+			fmt.Print(output)
+			return
+		}
+
+		if err := ioutil.WriteFile(collectionOutput, []byte(output), 0644); err != nil {
+			fmt.Printf("치트시트 저장 오류: %v\n", err)
+			return
+		}
+		if lang == "en" {
+			fmt.Printf("Wrote cheat sheet to %s.\n", collectionOutput)
+		} else {
+			fmt.Printf("치트시트를 %s에 저장했습니다.\n", collectionOutput)
+		}
+	},
+}
+
+func init() {
+	collectionCreateCmd.Flags().StringVar(&collectionDescription, "description", "", "컬렉션 설명")
+
+	collectionShowCmd.Flags().StringVar(&collectionFormat, "format", cheatsheet.FormatTerminal,
+		"출력 형식 ("+strings.Join(cheatsheet.Formats, "/")+")")
+	collectionShowCmd.Flags().StringVarP(&collectionOutput, "output", "o", "", "출력 파일 (생략하면 표준 출력)")
+
+	collectionCmd.AddCommand(collectionCreateCmd)
+	collectionCmd.AddCommand(collectionDeleteCmd)
+	collectionCmd.AddCommand(collectionAddCmd)
+	collectionCmd.AddCommand(collectionRemoveCmd)
+	collectionCmd.AddCommand(collectionListCmd)
+	collectionCmd.AddCommand(collectionShowCmd)
+
+	favoritesCmd.AddCommand(collectionCmd)
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/editors"
+	"vi-assistant/internal/learn"
+	"vi-assistant/internal/search"
+)
+
+// Dynamic completions for cobra's completion command (bash, zsh, fish, powershell).
+// Candidates are "value\tdescription"; shells without descriptions drop the part after the tab.
+
+// completeCommands offers catalog commands for the first argument
+func completeCommands(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	commands, err := catalog.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates []string
+	for _, c := range commands {
+		if strings.HasPrefix(c.Command, toComplete) {
+			candidates = append(candidates, completion(c.Command, c.Description))
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFavorites offers the saved favorites for the first argument
+func completeFavorites(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	fm, err := newFavoritesManager()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	list, err := fm.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates []string
+	for _, f := range list {
+		if !strings.HasPrefix(f.ID(), toComplete) {
+			continue
+		}
+		description := f.Description
+		if len(f.Tags) > 0 {
+			description += " [" + strings.Join(f.Tags, ", ") + "]"
+		}
+		candidates = append(candidates, completion(f.ID(), description))
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLevels offers the lesson levels with their lesson counts
+func completeLevels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	lang := viper.GetString("lang")
+	var candidates []string
+	for _, level := range learn.Levels {
+		if !strings.HasPrefix(level, toComplete) {
+			continue
+		}
+		count := len(learn.GetLessons(level, lang))
+		description := fmt.Sprintf("강의 %d개", count)
+		if lang == "en" {
+			description = fmt.Sprintf("%d lessons", count)
+			if count == 1 {
+				description = "1 lesson"
+			}
+		}
+		candidates = append(candidates, completion(level, description))
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEditors offers the editors of the translate data files
+func completeEditors(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	db, err := editors.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates []string
+	for _, e := range db.Editors {
+		if strings.HasPrefix(e.ID, toComplete) {
+			candidates = append(candidates, completion(e.ID, e.Name))
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// filterDescriptions describes the search filters in Korean and English
+var filterDescriptions = map[string][2]string{
+	"category": {"카테고리", "category"},
+	"mode":     {"모드 (normal, insert, visual, command-line)", "mode (normal, insert, visual, command-line)"},
+	"source":   {"출처 (built-in 또는 팩 이름)", "source (built-in or pack name)"},
+	"posix":    {"POSIX vi에 있는지 여부", "in POSIX vi"},
+	"vim":      {"Vim 버전 (yes, no도 가능)", "Vim version (or yes, no)"},
+	"nvim":     {"Neovim 버전 (yes, no도 가능)", "Neovim version (or yes, no)"},
+}
+
+// completeSearch offers filter names and their aliases, then the values of the filter being typed
+func completeSearch(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	en := viper.GetString("lang") == "en"
+
+	key, value, found := strings.Cut(toComplete, ":")
+	if !found {
+		var candidates []string
+		for _, key := range search.FilterKeys {
+			if strings.HasPrefix(key, toComplete) {
+				text := filterDescriptions[key]
+				candidates = append(candidates, completion(key+":", pick(en, text[0], text[1])))
+			}
+		}
+		for _, alias := range filterAliases() {
+			if strings.HasPrefix(alias, toComplete) {
+				text := filterDescriptions[search.FilterKey(alias)]
+				candidates = append(candidates, completion(alias+":", pick(en, text[0], text[1])))
+			}
+		}
+		// The filter name is followed by its value, not a space
+		return candidates, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+
+	values, err := filterValues(search.FilterKey(strings.ToLower(key)), en)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates []string
+	for _, v := range values {
+		name, _, _ := strings.Cut(v, "\t")
+		if strings.HasPrefix(name, value) {
+			candidates = append(candidates, key+":"+v)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// filterAliases returns the other names of the search filters in a stable order
+func filterAliases() []string {
+	aliases := make([]string, 0, len(search.FilterAliases))
+	for alias := range search.FilterAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// filterValues returns the completion values of a search filter
+func filterValues(key string, en bool) ([]string, error) {
+	switch key {
+	case "category", "mode", "source":
+	case "posix", "vim", "nvim":
+		return []string{"yes", "no"}, nil
+	default:
+		return nil, nil
+	}
+
+	commands, err := catalog.Load()
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, c := range commands {
+		switch key {
+		case "category":
+			counts[c.Category]++
+		case "source":
+			counts[c.Source]++
+		case "mode":
+			for _, m := range c.Modes {
+				counts[m]++
+			}
+		}
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if key == "mode" {
+		names = append(names[:0], catalog.Modes...) // in the order modes are documented
+	} else {
+		sort.Strings(names)
+	}
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = completion(name, pick(en, fmt.Sprintf("명령어 %d개", counts[name]), fmt.Sprintf("%d commands", counts[name])))
+	}
+	return values, nil
+}
+
+// completion joins a candidate and its description
+func completion(value, description string) string {
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// pick returns the Korean or English text
+func pick(en bool, ko, english string) string {
+	if en {
+		return english
+	}
+	return ko
+}
+
+func init() {
+	explainCmd.ValidArgsFunction = completeCommands
+	relatedCmd.ValidArgsFunction = completeCommands
+	favAddCmd.ValidArgsFunction = completeCommands
+	translateCmd.ValidArgsFunction = completeCommands
+	searchCmd.ValidArgsFunction = completeSearch
+	learnStartCmd.ValidArgsFunction = completeLevels
+	learnListCmd.ValidArgsFunction = completeLevels
+	cheatsheetCmd.RegisterFlagCompletionFunc("level", completeLevels)
+	for _, c := range []*cobra.Command{favRemoveCmd, favTagCmd, favUntagCmd, favNoteCmd} {
+		c.ValidArgsFunction = completeFavorites
+	}
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "설정을 확인하고 변경합니다",
+	Long: `설정 파일을 만들고, 설정 값을 확인하거나 변경합니다.
+
+하위 명령어:
+  init - 기본값과 설명이 담긴 설정 파일 생성
+  get  - 설정 값 확인
+  set  - 설정 값 변경 (스키마 검사)
+  list - 모든 설정 키와 현재 값 보기
+  path - 설정 파일 경로 보기
+
+사용 예시:
+  vi-assistant config init
+  vi-assistant config set lang en
+  vi-assistant config set search.limit 10
+  vi-assistant config set data.sources data/commands.json,~/team-commands.json
+  vi-assistant config get color`,
+}
+
+// config init 플래그
+var configForce bool
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "기본 설정 파일을 만듭니다",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+		path := configFilePath()
+
+		if _, err := os.Stat(path); err == nil && !configForce {
+			if lang == "en" {
+				fmt.Printf("Config file already exists: %s (use --force to overwrite)\n", path)
+			} else {
+				fmt.Printf("설정 파일이 이미 있습니다: %s (덮어쓰려면 --force 사용)\n", path)
+			}
+			return
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fmt.Printf("설정 디렉토리 생성 오류: %v\n", err)
+			return
+		}
+		if err := ioutil.WriteFile(path, []byte(config.Template(lang)), 0644); err != nil {
+			fmt.Printf("설정 파일 쓰기 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Created config file: %s\n", path)
+		} else {
+			fmt.Printf("설정 파일을 만들었습니다: %s\n", path)
+		}
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "설정 값을 확인합니다",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, ok := config.Lookup(args[0])
+		if !ok {
+			fmt.Printf("설정 오류: %v\n", &config.UnknownKeyError{Name: args[0], Suggestions: config.Suggest(args[0])})
+			return
+		}
+
+		fmt.Println(config.FormatValue(viper.Get(key.Name)))
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "설정 값을 변경합니다 (목록은 쉼표로 구분)",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		value, err := config.Parse(args[0], args[1])
+		if err != nil {
+			fmt.Printf("설정 오류: %v\n", err)
+			return
+		}
+		key, _ := config.Lookup(args[0])
+
+		// 플래그와 환경 변수가 섞이지 않도록 설정 파일만 따로 읽어서 수정합니다
+		path := configFilePath()
+		fileConfig := viper.New()
+		fileConfig.SetConfigFile(path)
+		fileConfig.SetConfigType("yaml")
+		if err := fileConfig.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("설정 파일 읽기 오류: %v\n", err)
+			return
+		}
+
+		fileConfig.Set(key.Name, value)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fmt.Printf("설정 디렉토리 생성 오류: %v\n", err)
+			return
+		}
+		if err := fileConfig.WriteConfigAs(path); err != nil {
+			fmt.Printf("설정 파일 쓰기 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("%s = %s (%s)\n", key.Name, config.FormatValue(value), path)
+		} else {
+			fmt.Printf("%s = %s 로 설정했습니다 (%s)\n", key.Name, config.FormatValue(value), path)
+		}
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "모든 설정 키와 현재 값을 보여줍니다",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		for _, key := range config.Keys {
+			value := config.FormatValue(viper.Get(key.Name))
+
+			// 기본값이 아닌 값은 어디서 왔는지 표시합니다
+			source := ""
+			if viper.InConfig(key.Name) {
+				source = " *"
+			}
+
+			fmt.Printf("%-16s %s%s\n", key.Name, value, source)
+			description := key.Description(lang)
+			if len(key.Allowed) > 0 {
+				description += " (" + strings.Join(key.Allowed, "/") + ")"
+			}
+			fmt.Printf("%-16s # %s\n", "", description)
+		}
+
+		if lang == "en" {
+			fmt.Println("\n* set in the config file")
+		} else {
+			fmt.Println("\n* 설정 파일에서 지정한 값")
+		}
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "설정 파일 경로를 보여줍니다",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(configFilePath())
+	},
+}
+
+// configFilePath 함수는 --config 플래그나 XDG 설정 디렉토리의 설정 파일 경로를 반환합니다
+func configFilePath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	return appPaths.ConfigFile()
+}
+
+func init() {
+	configInitCmd.Flags().BoolVar(&configForce, "force", false, "기존 설정 파일 덮어쓰기")
+
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configPathCmd)
+}
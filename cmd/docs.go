@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/site"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "man 페이지와 정적 HTML 카탈로그 문서를 만듭니다",
+	Long: `CLI 명령어의 man 페이지와 카탈로그 전체의 정적 HTML 레퍼런스를 만듭니다.
+
+--man DIR   명령어마다 man 페이지(vi-assistant-search.1 등)를 만듭니다.
+            --lang en이면 영어 도움말로 만듭니다.
+--html DIR  팩을 포함한 카탈로그를 언어별 페이지(DIR/ko, DIR/en)로 만듭니다.
+            카테고리별로 묶이고, 검색 상자가 있으며, 네트워크 없이 열립니다.
+
+사용 예시:
+  vi-assistant docs --man ./man
+  vi-assistant docs --man ./man/en --lang en
+  vi-assistant docs --html ./site
+  man -l ./man/vi-assistant-search.1`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+		manDir, _ := cmd.Flags().GetString("man")
+		htmlDir, _ := cmd.Flags().GetString("html")
+		if manDir == "" && htmlDir == "" {
+			cmd.Help()
+			return
+		}
+
+		if manDir != "" {
+			if err := writeManPages(manDir, lang); err != nil {
+				fmt.Printf("man 페이지 생성 오류: %v\n", err)
+				return
+			}
+			if lang == "en" {
+				fmt.Printf("Wrote man pages to %s.\n", manDir)
+			} else {
+				fmt.Printf("man 페이지를 %s에 만들었습니다.\n", manDir)
+			}
+		}
+
+		if htmlDir != "" {
+			commands, err := catalog.Load()
+			if err != nil {
+				fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+				return
+			}
+			if err := site.Write(htmlDir, commands); err != nil {
+				fmt.Printf("HTML 문서 생성 오류: %v\n", err)
+				return
+			}
+			if lang == "en" {
+				fmt.Printf("Wrote the catalog reference (%d commands) to %s/index.html.\n", len(commands), htmlDir)
+			} else {
+				fmt.Printf("카탈로그 레퍼런스(명령어 %d개)를 %s/index.html에 만들었습니다.\n", len(commands), htmlDir)
+			}
+		}
+	},
+}
+
+// writeManPages writes a man page for every command in lang
+func writeManPages(dir, lang string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	restore := localizeHelp(rootCmd, lang)
+	defer restore()
+
+	// Leave out the generation date line so the pages only change with the help text
+	rootCmd.DisableAutoGenTag = true
+	defer func() { rootCmd.DisableAutoGenTag = false }()
+
+	header := &doc.GenManHeader{Title: "VI-ASSISTANT", Section: "1", Source: "vi-assistant", Manual: "vi-assistant 설명서"}
+	if lang == "en" {
+		header.Manual = "vi-assistant Manual"
+	}
+	return doc.GenManTree(rootCmd, header, dir)
+}
+
+func init() {
+	docsCmd.Flags().String("man", "", "man 페이지를 만들 디렉토리")
+	docsCmd.Flags().String("html", "", "정적 HTML 카탈로그 레퍼런스를 만들 디렉토리")
+	rootCmd.AddCommand(docsCmd)
+}
@@ -36,8 +36,20 @@ var explainCmd = &cobra.Command{
 			return
 		}
 
-		// 설명 결과를 포맷팅하여 출력합니다
-		output := explain.FormatExplanation(result, lang)
-		fmt.Print(output)
+		// output.format이 json이면 JSON으로, 아니면 포맷팅하여 출력합니다
+		if viper.GetString("output.format") == "json" {
+			printJSON(result)
+		} else {
+			output := explain.FormatExplanation(result, lang)
+			fmt.Print(output)
+		}
+
+		// 즐겨찾기에 있는 명령어라면 사용 횟수를 기록합니다
+		// 기록 실패는 설명 출력에 영향을 주지 않도록 무시합니다
+		if result.Found {
+			if fm, err := newFavoritesManager(); err == nil {
+				fm.RecordUse(result.Command.Command)
+			}
+		}
 	},
 } 
\ No newline at end of file
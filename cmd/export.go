@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/vimhelp"
+)
+
+// Catalog export formats
+const (
+	exportFormatVimhelp = "vimhelp"
+	exportFormatJSON    = "json"
+)
+
+// export 명령어 플래그
+var (
+	exportFormat      string // 내보낼 형식
+	exportOutput      string // 출력 파일 또는 디렉토리
+	exportNoFavorites bool   // 즐겨찾기를 넣지 않음
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "카탈로그를 Vim 도움말 파일 등으로 내보냅니다",
+	Long: `팩을 포함한 전체 카탈로그와 즐겨찾기를 내보냅니다.
+
+형식:
+  vimhelp  Vim 도움말 파일 (vi-assistant.txt). 명령어마다 *vi-assistant-dd* 같은
+           태그가 붙고, 카테고리별 절, 관련 명령어와 Vim 도움말 링크가 들어갑니다.
+  json     팩을 합친 카탈로그 (commands.json 형식)
+
+--output에 디렉토리를 주면 그 안에 vi-assistant.txt(또는 commands.json)를 만듭니다.
+
+사용 예시:
+  vi-assistant export --format vimhelp -o ~/.vim/doc
+  vim -c 'helptags ~/.vim/doc' -c 'help vi-assistant-dd'
+  vi-assistant export --format json -o catalog.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		result, err := catalog.LoadAll()
+		if err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+
+		var buf bytes.Buffer
+		fileName := vimhelp.HelpFileName
+		switch exportFormat {
+		case exportFormatVimhelp:
+			opts := vimhelp.HelpOptions{Lang: lang, Packs: result.Packs, Date: time.Now()}
+			if !exportNoFavorites {
+				opts.Favorites = helpFavorites()
+			}
+			err = vimhelp.WriteHelpFile(&buf, result.Commands, opts)
+		case exportFormatJSON:
+			fileName = "commands.json"
+			var data []byte
+			data, err = json.MarshalIndent(catalog.Document{SchemaVersion: catalog.SchemaVersion, Commands: result.Commands}, "", "  ")
+			buf.Write(append(data, '\n'))
+		default:
+			err = fmt.Errorf("지원하지 않는 형식입니다: %s (사용 가능: %s, %s)", exportFormat, exportFormatVimhelp, exportFormatJSON)
+		}
+		if err != nil {
+			fmt.Printf("내보내기 오류: %v\n", err)
+			return
+		}
+
+		if exportOutput == "" {
+			fmt.Print(buf.String())
+			return
+		}
+
+		path := exportOutput
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, fileName)
+		}
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			fmt.Printf("내보내기 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Exported %d command(s) to %s.\n", len(result.Commands), path)
+		} else {
+			fmt.Printf("명령어 %d개를 %s(으)로 내보냈습니다.\n", len(result.Commands), path)
+		}
+		if exportFormat == exportFormatVimhelp {
+			if lang == "en" {
+				fmt.Printf("Run :helptags %s in Vim, then :help vi-assistant.txt\n", filepath.Dir(path))
+			} else {
+				fmt.Printf("Vim에서 :helptags %s 를 실행한 뒤 :help vi-assistant.txt 로 보세요.\n", filepath.Dir(path))
+			}
+		}
+	},
+}
+
+// helpFavorites returns the favorites to list in the help file.
+// A missing or unreadable favorites file just leaves the section out.
+func helpFavorites() []vimhelp.Favorite {
+	fm, err := newFavoritesManager()
+	if err != nil {
+		return nil
+	}
+	list, err := fm.List()
+	if err != nil {
+		return nil
+	}
+	favs := make([]vimhelp.Favorite, len(list))
+	for i, f := range list {
+		favs[i] = vimhelp.Favorite{Command: f.Command, Description: f.Description, Tags: f.Tags, Note: f.Note}
+	}
+	return favs
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", exportFormatVimhelp, "내보낼 형식 (vimhelp/json)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "출력 파일 또는 디렉토리 (생략하면 표준 출력)")
+	exportCmd.Flags().BoolVar(&exportNoFavorites, "no-favorites", false, "즐겨찾기 절을 넣지 않음")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,13 +23,36 @@ var favoritesCmd = &cobra.Command{
   list   - 즐겨찾기 목록 보기
   remove - 즐겨찾기에서 제거
   clear  - 모든 즐겨찾기 삭제
+  tag    - 즐겨찾기에 태그 추가
+  untag  - 즐겨찾기에서 태그 제거
+  note   - 즐겨찾기 메모 설정
+  export - 즐겨찾기 내보내기 (json/csv/markdown, 팀 세트)
+  import - 즐겨찾기 가져오기 (팀 세트 포함)
 
 사용 예시:
-  vi-assistant fav add :wq
-  vi-assistant fav list
+  vi-assistant fav add :wq --tag git --note "커밋 메시지 저장"
+  vi-assistant fav list --tag git --sort usage
+  vi-assistant fav export favorites.csv
+  vi-assistant fav export team.json --team-name backend
+  vi-assistant fav import ~/dotfiles/vi-team.json --strategy overwrite
   vi-assistant fav remove :wq`,
 }
 
+// 즐겨찾기 하위 명령어 플래그
+var (
+	favTags    []string // fav add에서 지정한 태그
+	favNote    string   // fav add에서 지정한 메모
+	favListTag string   // fav list 태그 필터
+	favSortBy  string   // fav list 정렬 기준
+
+	favFormat          string // fav export/import 파일 형식
+	favExportTag       string // fav export 태그 필터
+	favTeamName        string // fav export를 팀 세트로 내보낼 때의 이름
+	favTeamDescription string // 팀 세트 설명
+	favStrategy        string // fav import 병합 방식
+	favConflictTag     string // fav import 중복 시 가져온 항목에 붙일 태그
+)
+
 var favAddCmd = &cobra.Command{
 	Use:   "add [command]",
 	Short: "명령어를 즐겨찾기에 추가합니다",
@@ -51,14 +78,20 @@ var favAddCmd = &cobra.Command{
 		}
 
 		// 즐겨찾기 매니저 생성
-		fm, err := favorites.NewFavoritesManager()
+		fm, err := newFavoritesManager()
 		if err != nil {
 			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
 			return
 		}
 
 		// 즐겨찾기에 추가
-		err = fm.Add(command, result.Command.Description)
+		err = fm.Add(favorites.Favorite{
+			Command:     result.Command.Command,
+			Description: result.Command.Description,
+			Category:    result.Command.Category,
+			Tags:        favTags,
+			Note:        favNote,
+		})
 		if err != nil {
 			fmt.Printf("즐겨찾기 추가 오류: %v\n", err)
 			return
@@ -79,7 +112,7 @@ var favListCmd = &cobra.Command{
 		lang := viper.GetString("lang")
 
 		// 즐겨찾기 매니저 생성
-		fm, err := favorites.NewFavoritesManager()
+		fm, err := newFavoritesManager()
 		if err != nil {
 			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
 			return
@@ -92,6 +125,13 @@ var favListCmd = &cobra.Command{
 			return
 		}
 
+		// 태그 필터와 정렬 적용
+		favList = favorites.FilterByTag(favList, favListTag)
+		if err := favorites.SortFavorites(favList, favSortBy); err != nil {
+			fmt.Printf("즐겨찾기 정렬 오류: %v\n", err)
+			return
+		}
+
 		// 결과 출력
 		output := favorites.FormatFavorites(favList, lang)
 		fmt.Print(output)
@@ -107,7 +147,7 @@ var favRemoveCmd = &cobra.Command{
 		lang := viper.GetString("lang")
 
 		// 즐겨찾기 매니저 생성
-		fm, err := favorites.NewFavoritesManager()
+		fm, err := newFavoritesManager()
 		if err != nil {
 			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
 			return
@@ -135,7 +175,7 @@ var favClearCmd = &cobra.Command{
 		lang := viper.GetString("lang")
 
 		// 즐겨찾기 매니저 생성
-		fm, err := favorites.NewFavoritesManager()
+		fm, err := newFavoritesManager()
 		if err != nil {
 			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
 			return
@@ -156,9 +196,243 @@ var favClearCmd = &cobra.Command{
 	},
 }
 
+var favTagCmd = &cobra.Command{
+	Use:   "tag [command] [tag...]",
+	Short: "즐겨찾기에 태그를 추가합니다",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		command := args[0]
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.AddTags(command, args[1:]...); err != nil {
+			fmt.Printf("태그 추가 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Tagged '%s' with %s.\n", command, strings.Join(args[1:], ", "))
+		} else {
+			fmt.Printf("'%s'에 태그를 추가했습니다: %s\n", command, strings.Join(args[1:], ", "))
+		}
+	},
+}
+
+var favUntagCmd = &cobra.Command{
+	Use:   "untag [command] [tag...]",
+	Short: "즐겨찾기에서 태그를 제거합니다",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		command := args[0]
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.RemoveTags(command, args[1:]...); err != nil {
+			fmt.Printf("태그 제거 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Removed tags from '%s': %s\n", command, strings.Join(args[1:], ", "))
+		} else {
+			fmt.Printf("'%s'에서 태그를 제거했습니다: %s\n", command, strings.Join(args[1:], ", "))
+		}
+	},
+}
+
+var favNoteCmd = &cobra.Command{
+	Use:   "note [command] [text]",
+	Short: "즐겨찾기 메모를 설정합니다 (빈 문자열이면 삭제)",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		command := args[0]
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		if err := fm.SetNote(command, args[1]); err != nil {
+			fmt.Printf("메모 설정 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Updated note for '%s'.\n", command)
+		} else {
+			fmt.Printf("'%s'의 메모를 수정했습니다.\n", command)
+		}
+	},
+}
+
+var favExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "즐겨찾기를 파일로 내보냅니다 (파일을 생략하면 표준 출력)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		favList, err := fm.List()
+		if err != nil {
+			fmt.Printf("즐겨찾기 목록 오류: %v\n", err)
+			return
+		}
+		favList = favorites.FilterByTag(favList, favExportTag)
+
+		// 형식을 지정하지 않으면 파일 확장자로 추정합니다
+		format := favFormat
+		if format == "" && len(args) == 1 {
+			format = favorites.FormatFromPath(args[0])
+		} else if format == "" {
+			format = favorites.FormatJSON
+		}
+		// 팀 세트는 JSON 파일만 가져올 수 있습니다
+		if favTeamName != "" && !strings.EqualFold(format, favorites.FormatJSON) {
+			fmt.Printf("즐겨찾기 내보내기 오류: 팀 세트는 JSON으로만 내보낼 수 있습니다 (형식: %s)\n", format)
+			return
+		}
+
+		var buf bytes.Buffer
+		if favTeamName != "" {
+			err = favorites.ExportTeamSet(&buf, favTeamName, favTeamDescription, favList)
+		} else {
+			err = favorites.Export(&buf, favList, format)
+		}
+		if err != nil {
+			fmt.Printf("즐겨찾기 내보내기 오류: %v\n", err)
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Print(buf.String())
+			return
+		}
+
+		if err := ioutil.WriteFile(args[0], buf.Bytes(), 0644); err != nil {
+			fmt.Printf("즐겨찾기 내보내기 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			fmt.Printf("Exported %d favorite(s) to %s.\n", len(favList), args[0])
+		} else {
+			fmt.Printf("즐겨찾기 %d개를 %s(으)로 내보냈습니다.\n", len(favList), args[0])
+		}
+	},
+}
+
+var favImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "파일에서 즐겨찾기를 가져옵니다 (팀 세트 지원)",
+	Long: `JSON, CSV, Markdown 파일에서 즐겨찾기를 가져옵니다.
+
+JSON 파일이 {"name": ..., "favorites": [...]} 형태의 팀 세트이면
+각 항목에 "team:<이름>" 태그가 붙습니다. 팀 세트 파일은 읽기만 하므로
+dotfiles 저장소에 그대로 두고 공유할 수 있습니다.
+
+병합 방식 (이미 있는 명령어를 가져올 때):
+  skip      - 기존 즐겨찾기를 유지 (기본값)
+  overwrite - 가져온 항목으로 덮어쓰기
+  both      - 둘 다 유지하고 가져온 항목에 태그(--tag)를 붙임
+              가져온 항목은 "명령어@태그" 이름으로 저장됩니다 (예: fav remove dd@imported)`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			fmt.Printf("즐겨찾기 가져오기 오류: %v\n", err)
+			return
+		}
+		defer file.Close()
+
+		format := favFormat
+		if format == "" {
+			format = favorites.FormatFromPath(args[0])
+		}
+
+		incoming, teamSet, err := favorites.Parse(file, format)
+		if err != nil {
+			fmt.Printf("즐겨찾기 가져오기 오류: %v\n", err)
+			return
+		}
+
+		// 즐겨찾기 매니저 생성
+		fm, err := newFavoritesManager()
+		if err != nil {
+			fmt.Printf("즐겨찾기 매니저 오류: %v\n", err)
+			return
+		}
+
+		result, err := fm.Import(incoming, favStrategy, favConflictTag)
+		if err != nil {
+			fmt.Printf("즐겨찾기 가져오기 오류: %v\n", err)
+			return
+		}
+
+		if lang == "en" {
+			if teamSet != nil {
+				fmt.Printf("Team set: %s\n", teamSet.Name)
+			}
+			fmt.Printf("Imported: %d added, %d skipped, %d overwritten, %d kept both.\n",
+				result.Added, result.Skipped, result.Overwritten, result.Duplicated)
+		} else {
+			if teamSet != nil {
+				fmt.Printf("팀 세트: %s\n", teamSet.Name)
+			}
+			fmt.Printf("가져오기 완료: 추가 %d개, 건너뜀 %d개, 덮어씀 %d개, 둘 다 유지 %d개\n",
+				result.Added, result.Skipped, result.Overwritten, result.Duplicated)
+		}
+	},
+}
+
 func init() {
+	favAddCmd.Flags().StringSliceVar(&favTags, "tag", nil, "추가할 태그 (쉼표로 구분하거나 여러 번 지정)")
+	favAddCmd.Flags().StringVar(&favNote, "note", "", "즐겨찾기 메모")
+
+	favListCmd.Flags().StringVar(&favListTag, "tag", "", "지정한 태그가 있는 즐겨찾기만 표시")
+	favListCmd.Flags().StringVar(&favSortBy, "sort", favorites.SortByAdded,
+		"정렬 기준 ("+strings.Join(favorites.SortKeys, "/")+")")
+
+	favExportCmd.Flags().StringVar(&favFormat, "format", "", "출력 형식 ("+strings.Join(favorites.Formats, "/")+", 기본값: 확장자로 추정)")
+	favExportCmd.Flags().StringVar(&favExportTag, "tag", "", "지정한 태그가 있는 즐겨찾기만 내보내기")
+	favExportCmd.Flags().StringVar(&favTeamName, "team-name", "", "지정하면 이 이름의 팀 세트(JSON)로 내보내기")
+	favExportCmd.Flags().StringVar(&favTeamDescription, "team-description", "", "팀 세트 설명")
+
+	favImportCmd.Flags().StringVar(&favFormat, "format", "", "입력 형식 ("+strings.Join(favorites.Formats, "/")+", 기본값: 확장자로 추정)")
+	favImportCmd.Flags().StringVar(&favStrategy, "strategy", favorites.MergeSkip,
+		"이미 있는 명령어의 병합 방식 ("+strings.Join(favorites.MergeStrategies, "/")+")")
+	favImportCmd.Flags().StringVar(&favConflictTag, "tag", favorites.DefaultConflictTag, "both 방식에서 가져온 항목에 붙일 태그")
+
 	favoritesCmd.AddCommand(favAddCmd)
 	favoritesCmd.AddCommand(favListCmd)
 	favoritesCmd.AddCommand(favRemoveCmd)
 	favoritesCmd.AddCommand(favClearCmd)
+	favoritesCmd.AddCommand(favTagCmd)
+	favoritesCmd.AddCommand(favUntagCmd)
+	favoritesCmd.AddCommand(favNoteCmd)
+	favoritesCmd.AddCommand(favExportCmd)
+	favoritesCmd.AddCommand(favImportCmd)
 } 
\ No newline at end of file
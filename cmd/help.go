@@ -6,7 +6,9 @@ import (
 
 	"github.com/spf13/cobra"  // CLI 명령어 프레임워크
 	"github.com/spf13/viper"  // 설정 관리 라이브러리
-	"vi-assistant/internal/explain"  // 설명 기능을 위한 내부 패키지
+	"vi-assistant/internal/catalog"  // 명령어 카탈로그
+	"vi-assistant/internal/cheatsheet"  // 치트시트 구성과 출력
+	"vi-assistant/internal/style"  // 터미널 강조 표시
 )
 
 // helpCmd는 vi 명령어 빠른 참조를 위한 Cobra 명령어입니다
@@ -18,6 +20,7 @@ var helpCmd = &cobra.Command{
 
 카테고리별로 정리된 명령어 목록과 간단한 설명을 보여줍니다.
 초보자가 가장 먼저 알아야 할 명령어들로 구성되어 있습니다.
+전체 목록은 vi-assistant cheatsheet로 볼 수 있습니다.
 
 사용 예시:
   vi-assistant help
@@ -26,9 +29,14 @@ var helpCmd = &cobra.Command{
 		// 명령어 실행 시 호출되는 함수
 		lang := viper.GetString("lang")  // 현재 언어 설정을 가져옴
 
-		// 빠른 참조 내용을 출력합니다
-		quickRef := explain.GetQuickReference(lang)
-		fmt.Print(quickRef)
+		// 카탈로그를 읽어 빠른 참조를 구성합니다
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+		sheet := quickReference(commands, lang)
+		fmt.Print(cheatsheet.Columns(sheet, columnsWidth(0), style.Enabled))  // 터미널 너비에 맞춰 여러 열로 출력
 
 		// 추가 도움말 팁을 출력합니다
 		if lang == "en" {  // 영어 버전
@@ -36,11 +44,46 @@ var helpCmd = &cobra.Command{
 			fmt.Println("  - Use 'vi-assistant search <keyword>' to find specific commands")
 			fmt.Println("  - Use 'vi-assistant explain <command>' for detailed explanations")
 			fmt.Println("  - Use 'vi-assistant --learn beginner' for interactive tutorial")
+			fmt.Println("  - Use 'vi-assistant cheatsheet' for a full, printable cheat sheet")
 		} else {  // 한국어 버전
 			fmt.Println("\n💡 팁:")
 			fmt.Println("  - 'vi-assistant search <키워드>'로 특정 명령어 검색")
 			fmt.Println("  - 'vi-assistant explain <명령어>'로 상세 설명 보기")
 			fmt.Println("  - 'vi-assistant --learn beginner'로 대화형 튜토리얼 시작")
+			fmt.Println("  - 'vi-assistant cheatsheet'로 전체 치트시트 보기 (인쇄용 HTML, 키보드 SVG)")
 		}
 	},
-} 
\ No newline at end of file
+} 
+
+// quickReferenceCommands는 빠른 참조에 보여줄 카탈로그 명령어입니다
+// 설명과 카테고리는 카탈로그에서 가져오므로 이름만 적습니다
+var quickReferenceCommands = []string{
+	":w", ":q", ":wq", ":q!",  // 파일
+	"i", "Esc",  // 모드 전환
+	"yy", "dd", "p", "u",  // 편집
+	"h", "j", "k", "l",  // 이동
+	"/pattern", ":s/old/new",  // 검색과 바꾸기
+}
+
+// quickReference는 quickReferenceCommands를 카탈로그에서 찾아 카테고리별 치트시트로 묶습니다
+// 카탈로그에 없는 이름은 건너뜁니다
+func quickReference(commands []catalog.Command, lang string) cheatsheet.Sheet {
+	byName := make(map[string]catalog.Command)
+	for _, cmd := range commands {
+		if _, exists := byName[cmd.Command]; !exists {
+			byName[cmd.Command] = cmd
+		}
+	}
+
+	var entries []cheatsheet.Entry
+	for _, name := range quickReferenceCommands {
+		if cmd, ok := byName[name]; ok {
+			entries = append(entries, cheatsheet.Entry{Command: cmd.Command, Description: cmd.Description, Category: cmd.Category})
+		}
+	}
+
+	en := lang == "en"
+	sheet := cheatsheet.Group(pick(en, "빠른 참조 - 자주 사용하는 vi 명령어", "Quick Reference - Common vi Commands"), entries, lang)
+	sheet.Description = pick(en, fmt.Sprintf("명령어 %d개", len(entries)), fmt.Sprintf("%d commands", len(entries)))
+	return sheet
+}
@@ -0,0 +1,610 @@
+package cmd
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// commandHelpEN is the English short help of each command, keyed by its path
+// below the root command ("" is the root itself, "fav add" a subcommand).
+// The Korean help is written on the commands themselves.
+var commandHelpEN = map[string]string{
+	"":                       "A CLI assistant for vi/vim commands",
+	"analyze":                "Analyze a Vim keystroke log for habits and recommended commands",
+	"catalog":                "Manage the command catalog and user command packs",
+	"catalog import-vimhelp": "Build a command pack from the official text of the local Vim help",
+	"catalog lint":           "Check catalog files against the schema",
+	"catalog packs":          "Show loaded command packs and overridden commands",
+	"cheatsheet":             "Build a printable cheat sheet from the catalog",
+	"config":                 "Show and change settings",
+	"config get":             "Show a setting",
+	"config init":            "Create the default config file",
+	"config list":            "Show every setting key and its current value",
+	"config path":            "Show the config file path",
+	"config set":             "Change a setting (lists are comma-separated)",
+	"docs":                   "Generate man pages and a static HTML catalog reference",
+	"explain":                "Explain a vi command in detail",
+	"export":                 "Export the catalog as a Vim help file or JSON",
+	"fav":                    "Manage favorite commands",
+	"fav add":                "Add a command to the favorites",
+	"fav clear":              "Remove every favorite",
+	"fav collection":         "Manage favorite collections (cheat sheets)",
+	"fav collection add":     "Add favorite commands to a collection",
+	"fav collection create":  "Create a collection",
+	"fav collection delete":  "Delete a collection (favorites are kept)",
+	"fav collection list":    "List collections",
+	"fav collection remove":  "Remove commands from a collection",
+	"fav collection show":    "Print a collection as a cheat sheet",
+	"fav export":             "Export favorites to a file (standard output without a file)",
+	"fav import":             "Import favorites from a file (team sets supported)",
+	"fav list":               "List favorites",
+	"fav note":               "Set the note of a favorite (an empty string removes it)",
+	"fav remove":             "Remove a command from the favorites",
+	"fav tag":                "Add tags to a favorite",
+	"fav untag":              "Remove tags from a favorite",
+	"help":                   "Quick reference of frequently used vi commands",
+	"keymap":                 "Show what each key does in normal mode on a keyboard",
+	"learn":                  "Start the step-by-step learning mode",
+	"learn list":             "List lessons",
+	"learn start":            "Start a tutorial",
+	"option":                 "Show what a Vim option (:set) means and its default",
+	"paths":                  "Show where config, data and state files are kept",
+	"related":                "Follow the relation graph to related commands",
+	"rpc":                    "Serve JSON-RPC over standard input/output for editor plugins",
+	"search":                 "Search vi commands by keyword",
+	"serve":                  "Serve the catalog as a local HTTP/JSON API",
+	"shell":                  "Open an interactive shell for consecutive commands",
+	"translate":              "Translate between vi commands and keys in other editors (Emacs, VS Code, nano)",
+	"ui":                     "Browse commands and manage favorites in a full-screen view",
+	"vimrc":                  "Explain a vimrc file line by line",
+}
+
+// commandLongEN is the English long help of the commands that have one,
+// keyed like commandHelpEN. It follows the Korean long help, examples included.
+var commandLongEN = map[string]string{
+	"": `Vi Assistant is a CLI tool for quickly looking up and learning vi/vim commands.
+
+Features:
+- 🔍 Search vi commands by keyword
+- 📖 Detailed explanations and examples
+- 🎓 Step-by-step learning mode
+- ⭐ Favorites
+- 🌍 Korean and English
+
+Examples:
+  vi-assistant search copy
+  vi-assistant explain :wq
+  vi-assistant --learn beginner
+  vi-assistant help`,
+
+	"analyze": `Analyze a keystroke file (scriptout) recorded with vim -w.
+
+The keys are split by the normal mode grammar ([count]["register]command[motion])
+to count how often each command is used, and these inefficient habits are found:
+  jjjjjj       → 6j     repeated motions
+  xxxx         → dw     deleting character by character
+  dddddd       → 3dd    repeated line commands
+  $a           → A      a shorter command exists
+  same change  → .      retyping a change
+  arrow keys   → hjkl
+
+Catalog commands and lessons matching the habits found are recommended.
+All analysis happens locally.
+
+Recording keystrokes:
+  vim -w ~/vim-keys.log filename     (keys are appended to the file)
+
+Examples:
+  vi-assistant analyze ~/vim-keys.log
+  vi-assistant analyze ~/vim-keys.log --top 20`,
+
+	"catalog": `Inspect the command catalog (the built-in commands.json and user command packs).
+
+User command packs are *.json files in the pack directory (default:
+$XDG_DATA_HOME/vi-assistant/packs) in the same format as commands.json. The file
+name (or the "name" field) is the pack name, shown as the source in search/explain results.
+
+Catalog file format (schema_version 1):
+  {
+    "schema_version": 1,
+    "name": "fugitive",
+    "categories": ["git"],
+    "commands": [
+      {"keyword": "...", "command": "...", "description": "...", "example": "...", "category": "..."}
+    ]
+  }
+A file holding only an array of commands is read as version 1. "categories" declares
+the categories a pack uses besides the built-in ones.
+
+When commands collide:
+  - between packs, the later pack in file name order wins
+  - between a pack and the built-in catalog, the packs.precedence setting decides
+    (pack: the pack wins, builtin: the built-in command wins)
+
+Subcommands:
+  packs          - show loaded packs and colliding commands
+  lint           - check catalog files against the schema
+  import-vimhelp - build a command pack from the local Vim help (doc directory)
+
+Examples:
+  vi-assistant catalog packs
+  vi-assistant catalog lint
+  vi-assistant catalog lint ./my-pack.json
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91/doc --install`,
+
+	"catalog lint": `Check catalog files and report problems with their file:line position.
+Without files, the built-in catalog and every pack in the pack directory are checked.
+
+Errors:
+  - JSON syntax errors, unsupported schema_version
+  - unknown fields, empty required fields
+  - the same command twice in one file
+Warnings:
+  - unknown categories (a pack can declare them in "categories")
+  - commands overlapping other commands (e.g. ":help" and ":help command" -
+    write arguments as {name})
+  - examples that do not mention the command
+
+The exit code is 1 when there are errors.`,
+
+	"catalog import-vimhelp": `Build a command pack from a Vim help directory (the tags file and the *.txt help).
+Only the local directory is used, without the network (e.g. /usr/share/vim/vim91/doc).
+
+- When a help tag matches a catalog command, the pack gets that entry with Vim's
+  own description and :help tag. Installing the pack enriches the built-in entries
+  (when packs.precedence is pack).
+- Tags given with --tag or --file become new entries when they are not in the catalog.
+
+The result is written to standard output or the -o file; --install saves it in the pack directory.
+
+Examples:
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91/doc -o vimhelp.json
+  vi-assistant catalog import-vimhelp /usr/share/vim/vim91 --tag gJ --tag CTRL-A --install
+  vi-assistant catalog import-vimhelp ~/.vim/doc --file change.txt --install`,
+
+	"cheatsheet": `Build a cheat sheet grouped by category from the catalog, packs included.
+
+Formats:
+  terminal  several columns fitted to the terminal width (default, colors follow the color setting)
+  html      print-friendly HTML that opens without other files
+  svg       keyboard drawing of what each key does in normal mode (layout from keymap.layout)
+  markdown, text
+
+Filters:
+  --level       only commands taught in lessons up to that level (beginner/intermediate)
+  --category    only these categories (comma-separated or repeated)
+  --favorites   only favorite commands (their notes are shown)
+
+Examples:
+  vi-assistant cheatsheet
+  vi-assistant cheatsheet --level beginner --format html -o vi.html
+  vi-assistant cheatsheet --category navigation,search
+  vi-assistant cheatsheet --favorites --format svg -o keys.svg`,
+
+	"config": `Create the config file and show or change settings.
+
+Subcommands:
+  init - create a config file with the defaults and their descriptions
+  get  - show a setting
+  set  - change a setting (checked against the schema)
+  list - show every setting key and its current value
+  path - show the config file path
+
+Examples:
+  vi-assistant config init
+  vi-assistant config set lang en
+  vi-assistant config set search.limit 10
+  vi-assistant config set data.sources data/commands.json,~/team-commands.json
+  vi-assistant config get color`,
+
+	"docs": `Generate man pages for the CLI commands and a static HTML reference of the whole catalog.
+
+--man DIR   writes a man page per command (vi-assistant-search.1 and so on).
+            With --lang en the pages use the English help.
+--html DIR  writes the catalog, packs included, as one page per language (DIR/ko, DIR/en).
+            Commands are grouped by category, with a search box, and open without the network.
+
+Examples:
+  vi-assistant docs --man ./man
+  vi-assistant docs --man ./man/en --lang en
+  vi-assistant docs --html ./site
+  man -l ./man/vi-assistant-search.1`,
+
+	"explain": `Show a detailed explanation and examples of a vi/vim command.
+
+An exact command gets its explanation;
+otherwise similar commands are suggested.
+
+Examples:
+  vi-assistant explain :wq
+  vi-assistant explain yy
+  vi-assistant explain /pattern`,
+
+	"export": `Export the whole catalog, packs included, and the favorites.
+
+Formats:
+  vimhelp  a Vim help file (vi-assistant.txt). Every command gets a tag such as
+           *vi-assistant-dd*, with a section per category, related commands and
+           links to the Vim help.
+  json     the catalog merged with the packs (commands.json format)
+
+When --output is a directory, vi-assistant.txt (or commands.json) is created in it.
+
+Examples:
+  vi-assistant export --format vimhelp -o ~/.vim/doc
+  vim -c 'helptags ~/.vim/doc' -c 'help vi-assistant-dd'
+  vi-assistant export --format json -o catalog.json`,
+
+	"fav": `Add and manage frequently used vi commands as favorites.
+
+Subcommands:
+  add    - add a command to the favorites
+  list   - list favorites
+  remove - remove a favorite
+  clear  - remove every favorite
+  tag    - add tags to a favorite
+  untag  - remove tags from a favorite
+  note   - set the note of a favorite
+  export - export favorites (json/csv/markdown, team sets)
+  import - import favorites (team sets included)
+
+Examples:
+  vi-assistant fav add :wq --tag git --note "save the commit message"
+  vi-assistant fav list --tag git --sort usage
+  vi-assistant fav export favorites.csv
+  vi-assistant fav export team.json --team-name backend
+  vi-assistant fav import ~/dotfiles/vi-team.json --strategy overwrite
+  vi-assistant fav remove :wq`,
+
+	"fav collection": `Group favorites into named collections such as "git-commit-editing" or
+"refactoring" and print them as cheat sheets grouped by category.
+
+Subcommands:
+  create - create a collection
+  delete - delete a collection (favorites are kept)
+  add    - add favorites to a collection
+  remove - remove favorites from a collection
+  list   - list collections
+  show   - print a collection as a cheat sheet (terminal/markdown/html/text)
+
+Examples:
+  vi-assistant fav collection create refactoring --description "commands for refactoring"
+  vi-assistant fav collection add refactoring :%s/old/new/g dd p
+  vi-assistant fav collection show refactoring --format html -o refactoring.html`,
+
+	"fav import": `Import favorites from a JSON, CSV or Markdown file.
+
+When the JSON file is a team set of the form {"name": ..., "favorites": [...]},
+every entry is tagged "team:<name>". Team set files are only read, so they can
+stay in a dotfiles repository and be shared.
+
+Merge strategies (when an imported command is already a favorite):
+  skip      - keep the existing favorite (default)
+  overwrite - replace it with the imported entry
+  both      - keep both and tag the imported entry (--tag)
+              the imported entry is stored as "command@tag" (e.g. fav remove dd@imported)`,
+
+	"help": `Quick reference of frequently used vi/vim commands.
+
+Shows the commands grouped by category with a short description.
+It covers the commands beginners should learn first.
+Use vi-assistant cheatsheet for the full list.
+
+Examples:
+  vi-assistant help
+  vi-assistant help --lang en`,
+
+	"keymap": `Write the catalog command each key runs in normal mode on a keyboard layout,
+colored by category. It shows at a glance that hjkl move and d, y are operators.
+
+Layers:
+  lower  keys pressed without Shift
+  shift  keys pressed with Shift
+  ctrl   keys pressed with Ctrl
+  all    all three layers (default)
+
+Choose the keyboard layout with --layout or the keymap.layout setting (qwerty/dvorak/colemak/korean).
+korean (Hangul 2-set) also shows the jamo each key types in Hangul input mode.
+--format svg draws the Shift and lower layers as one SVG picture.
+
+Examples:
+  vi-assistant keymap
+  vi-assistant keymap --layer shift
+  vi-assistant keymap --layout dvorak
+  vi-assistant config set keymap.layout korean
+  vi-assistant keymap --format svg -o keymap.svg`,
+
+	"learn": `Show the step-by-step vi tutorials.
+
+Subcommands:
+  start - start a tutorial (without a level, the learn.level setting is used)
+  list  - list the lessons of a level
+
+Examples:
+  vi-assistant learn start
+  vi-assistant learn start intermediate
+  vi-assistant learn list beginner`,
+
+	"option": `Show the description, type, default, scope and examples of a Vim option.
+
+Full names, abbreviations and :set arguments are all accepted:
+  expandtab, et       option names and abbreviations
+  noet, invhls, et!   off/toggle prefixes (boolean options only)
+  ts=4, sw+=2         assign, add (+=), subtract (-=), prepend (^=)
+  ":set noet"         a leading :set is fine too
+
+Without a name, every option is listed.
+
+Examples:
+  vi-assistant option expandtab
+  vi-assistant option noic
+  vi-assistant option "path+=**"`,
+
+	"paths": `Show the directories and files vi-assistant uses.
+
+They follow the XDG base directory specification:
+  config - $XDG_CONFIG_HOME/vi-assistant (default: ~/.config/vi-assistant)
+  data   - $XDG_DATA_HOME/vi-assistant (default: ~/.local/share/vi-assistant)
+  state  - $XDG_STATE_HOME/vi-assistant (default: ~/.local/state/vi-assistant)
+
+The paths.data and paths.state settings in the config file move the data and state directories.`,
+
+	"related": `Follow the relations between commands to related commands.
+
+Relations:
+  inverse         u ↔ Ctrl+r
+  counterpart     p ↔ P, o ↔ O
+  general form    dd → d{motion}
+  specific forms  d{motion} → dd, dw, D
+  see also        see_also in the catalog
+  same category   shown at the first level only
+
+Without --depth, the related.depth setting (default 2) is used.
+
+Examples:
+  vi-assistant related dd
+  vi-assistant related u --depth 1`,
+
+	"rpc": `Read one JSON-RPC 2.0 request per line from standard input and write one response
+per line to standard output. A Neovim/Vim plugin can start the process once and keep
+sending requests. The catalog is read once, and the server exits at the end of input.
+
+Methods (protocol version 1):
+  version     protocol version and method list
+  explain     {"command": "dd"}
+  search      {"query": "delete mode:visual", "limit": 5}
+  parse-keys  {"keys": "3dw<Esc>"} (Vim key notation; with "raw": true, the typed bytes)
+  options     {"name": "sw+=2"}, {"search": "case"}, every option without parameters
+  favorites   {"action": "list|add|remove", "command": "dd", "tags": [], "note": "", "tag": "", "sort": ""}
+
+Examples:
+  echo '{"jsonrpc":"2.0","id":1,"method":"explain","params":{"command":"dd"}}' | vi-assistant rpc`,
+
+	"search": `Search vi/vim commands by keyword.
+
+The search looks at:
+- command keywords
+- the commands themselves
+- descriptions
+- categories
+- aliases (e.g. :quit)
+
+Filters of the form "field:value" narrow the results:
+  category:value  commands in the category
+  mode:value      normal, insert, visual, command-line (or n, i, v, c)
+  source:value    commands from the source (built-in or a pack name)
+  posix:yes|no    whether the command is in POSIX vi
+  vim:version     commands available in that Vim version (or yes, no)
+  nvim:version    commands available in that Neovim version (or yes, no)
+
+Examples:
+  vi-assistant search copy
+  vi-assistant search save
+  vi-assistant search navigation
+  vi-assistant search mode:visual
+  vi-assistant search move posix:no
+  vi-assistant search category:search vim:7.0`,
+
+	"serve": `Serve command search, explanations, categories, favorites and lessons as a JSON API.
+It uses the same catalog, packs and favorites file as the CLI.
+
+Endpoints (all under /api/v1):
+  GET    /health                     health check
+  GET    /search?q=query&limit=N     search (the search command's filters work too)
+  GET    /explain?command=dd         explain a command (404 with suggestions when missing)
+  GET    /categories                 list categories
+  GET    /favorites?tag=&sort=       list favorites
+  POST   /favorites                  add a favorite {"command": "dd", "tags": [], "note": ""}
+  DELETE /favorites?command=dd       remove a favorite
+  GET    /lessons?level=&lang=       list lessons
+
+On Ctrl+C (SIGINT) or SIGTERM, requests in progress are finished before exiting.
+Requests are logged to standard error (--quiet turns this off).
+
+Examples:
+  vi-assistant serve
+  vi-assistant serve --addr 127.0.0.1:9000 --cors-origin http://localhost:3000
+  curl 'http://127.0.0.1:8420/api/v1/search?q=delete'`,
+
+	"shell": `Open a prompt to type search, explain, related, fav and learn commands one after
+another without starting the program again. The catalog is read once.
+
+Editing keys:
+  Tab             complete commands, catalog commands, categories (category:) and levels
+  ↑/↓, Ctrl+p/n   previous input (history is saved in shell_history in the state directory)
+  Ctrl+a/e        start / end of the line
+  Ctrl+w, Ctrl+u  delete a word / the line
+  Ctrl+c          cancel the input
+  Ctrl+d, exit    quit
+
+Examples:
+  vi-assistant shell
+  vi-assistant> search category:copy
+  vi-assistant> explain dd
+  vi-assistant> fav add dd --tag edit`,
+
+	"translate": `Show which keys a vi command is in other editors, and which vi command the keys
+of another editor are. Where VSCodeVim and IdeaVim behave differently from Vim, it says so.
+
+Every editor has one file under data/editors, and keys are accepted in any of the
+editors' notations (^K, C-k, Ctrl+K).
+
+Examples:
+  vi-assistant translate dd                  dd in other editors?
+  vi-assistant translate :wq --to vscode     only VS Code
+  vi-assistant translate --from nano ^K      nano's ^K in vi?
+  vi-assistant translate --from emacs "C-x C-s" --to nano
+  vi-assistant translate --from emacs        the whole Emacs table
+  vi-assistant translate                     list editors`,
+
+	"ui": `Open a full-screen browser with a category list, a command list and an explanation pane.
+It moves with the keyboard like vi and searches as you type.
+
+Keys:
+  j/k, ↓/↑       move
+  h/l, Tab       switch between the category, command and explanation panes
+  gg, G          first / last
+  Ctrl+d/Ctrl+u  half a page
+  /              search (the search command's filters work too, Esc cancels)
+  f              add/remove a favorite
+  ?              help
+  q              quit
+
+Examples:
+  vi-assistant ui
+  vi-assistant ui "delete mode:visual"`,
+
+	"vimrc": `Read a vimrc file and explain what each line does.
+
+Explained:
+  set/setlocal   option meaning and value from the option database (no/inv prefixes, +=, -= included)
+  map family     mode (nnoremap, inoremap, ...), recursion, arguments such as <silent>, <leader>
+  autocmd        event, file pattern and command
+  let            mapleader, &option, g: variables
+  command        user-defined commands
+
+Mappings that override built-in commands (e.g. nnoremap x ...) and unknown options are warned about.
+
+Without a path, ~/.vimrc, ~/.vim/vimrc and ~/.config/nvim/init.vim are tried in order.
+A path of - reads standard input.
+
+Examples:
+  vi-assistant vimrc
+  vi-assistant vimrc ~/dotfiles/vimrc
+  cat vimrc | vi-assistant vimrc -`,
+}
+
+// flagHelpEN is the English usage of flags, keyed by "path --flag" or,
+// for flags that mean the same everywhere, by "--flag"
+var flagHelpEN = map[string]string{
+	"--config":        "config file (default: $XDG_CONFIG_HOME/vi-assistant/config.yaml)",
+	"--lang":          "output language (ko/en)",
+	"--learn":         "start the learning mode (beginner/intermediate)",
+	"--output-format": "search/explain output format (text/json)",
+	"--color":         "use colors (auto/always/never)",
+	"--toggle":        "toggle help",
+	"--output":        "output file (standard output when omitted)",
+	"--read-only":     "reject adding and removing favorites",
+
+	"analyze --top": "number of most used commands to show (0 shows all)",
+
+	"catalog import-vimhelp --tag":     "help tags to import even when not in the catalog (repeatable)",
+	"catalog import-vimhelp --file":    "help files whose command tags are all imported (e.g. change.txt)",
+	"catalog import-vimhelp --name":    "pack name",
+	"catalog import-vimhelp --install": "save as <name>.json in the pack directory",
+
+	"cheatsheet --format":    "output format (terminal/markdown/html/text/svg)",
+	"cheatsheet --level":     "only commands taught up to this level (beginner/intermediate)",
+	"cheatsheet --category":  "only commands in these categories (comma-separated or repeated)",
+	"cheatsheet --favorites": "only favorite commands",
+	"cheatsheet --width":     "width of the terminal format (default: $COLUMNS or 80)",
+
+	"config init --force": "overwrite an existing config file",
+
+	"docs --man":  "directory for the CLI man pages",
+	"docs --html": "directory for the static HTML catalog reference",
+
+	"export --format":       "export format (vimhelp/json)",
+	"export --output":       "output file or directory (standard output when omitted)",
+	"export --no-favorites": "leave out the favorites section",
+
+	"fav add --tag":                       "tags to add (comma-separated or repeated)",
+	"fav add --note":                      "note for the favorite",
+	"fav list --tag":                      "only show favorites with this tag",
+	"fav list --sort":                     "sort order (added/name/category/usage)",
+	"fav export --format":                 "output format (json/csv/markdown, default: from the file extension)",
+	"fav export --tag":                    "only export favorites with this tag",
+	"fav export --team-name":              "export as a team set (JSON) with this name",
+	"fav export --team-description":       "team set description",
+	"fav import --format":                 "input format (json/csv/markdown, default: from the file extension)",
+	"fav import --strategy":               "how to merge duplicates (skip/overwrite/both)",
+	"fav import --tag":                    "tag added to imported entries with the both strategy",
+	"fav collection create --description": "collection description",
+	"fav collection show --format":        "output format (terminal/markdown/html/text)",
+
+	"keymap --layout": "keyboard layout (qwerty/dvorak/colemak/korean, default: the keymap.layout setting)",
+	"keymap --layer":  "layer to draw (lower/shift/ctrl/all)",
+	"keymap --format": "output format (text/svg)",
+
+	"related --depth": "relation depth to follow (default: the related.depth setting)",
+
+	"serve --addr":        "address to listen on (default: the serve.addr setting)",
+	"serve --cors-origin": "browser origins allowed to call the API (repeatable, * allows any)",
+	"serve --quiet":       "do not log requests",
+
+	"translate --from": "translate keys of this editor into vi commands (emacs/vscode/nano/...)",
+	"translate --to":   "only show equivalents in these editors (comma-separated or repeated)",
+}
+
+// localizeHelp switches the help of cmd and its subcommands to lang for
+// generated documentation and returns a function putting the original back.
+// Commands without an English long help show the English short help instead.
+func localizeHelp(cmd *cobra.Command, lang string) func() {
+	if lang != "en" {
+		return func() {}
+	}
+
+	var restores []func()
+	var visit func(c *cobra.Command)
+	visit = func(c *cobra.Command) {
+		path := strings.TrimPrefix(strings.TrimPrefix(c.CommandPath(), cmd.Root().Name()), " ")
+		short, long, example := c.Short, c.Long, c.Example
+		restores = append(restores, func() { c.Short, c.Long, c.Example = short, long, example })
+
+		// cobra's own commands (completion, help) are already in English
+		if text, ok := commandHelpEN[path]; ok && strings.IndexFunc(short, isHangul) >= 0 {
+			c.Short = text
+			c.Long = commandLongEN[path] // without a long help cobra shows the short one
+		}
+
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			text, ok := flagHelpEN[path+" --"+f.Name]
+			if !ok {
+				text, ok = flagHelpEN["--"+f.Name]
+			}
+			if ok && strings.IndexFunc(f.Usage, isHangul) >= 0 {
+				usage := f.Usage
+				f.Usage = text
+				restores = append(restores, func() { f.Usage = usage })
+			}
+		})
+
+		for _, sub := range c.Commands() {
+			visit(sub)
+		}
+	}
+	visit(cmd)
+
+	return func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+}
+
+func isHangul(r rune) bool {
+	return unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/keyboard"
+	"vi-assistant/internal/style"
+)
+
+// keymap 명령어 플래그
+var (
+	keymapLayout string // 자판 배열 (기본값: keymap.layout 설정)
+	keymapLayer  string // 그릴 층
+	keymapFormat string // 출력 형식
+	keymapOutput string // 출력 파일
+)
+
+var keymapCmd = &cobra.Command{
+	Use:   "keymap",
+	Short: "노멀 모드에서 각 키가 하는 일을 키보드 모양으로 보여줍니다",
+	Long: `키보드 배치 위에 노멀 모드에서 각 키가 실행하는 카탈로그 명령어를 적고,
+카테고리별 색으로 보여줍니다. hjkl이 이동이고 d, y가 연산자라는 것을 한눈에 볼 수 있습니다.
+
+층:
+  lower  Shift 없이 누른 키
+  shift  Shift와 함께 누른 키
+  ctrl   Ctrl과 함께 누른 키
+  all    세 층 모두 (기본값)
+
+자판 배열은 --layout이나 keymap.layout 설정으로 고릅니다 (qwerty/dvorak/colemak/korean).
+korean(한글 2벌식)은 각 키에 한글 입력 상태의 자모를 함께 보여줍니다.
+--format svg는 Shift와 기본 층을 한 장의 SVG 그림으로 그립니다.
+
+사용 예시:
+  vi-assistant keymap
+  vi-assistant keymap --layer shift
+  vi-assistant keymap --layout dvorak
+  vi-assistant config set keymap.layout korean
+  vi-assistant keymap --format svg -o keymap.svg`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		name := viper.GetString("keymap.layout")
+		if cmd.Flags().Changed("layout") {
+			name = keymapLayout
+		}
+		layout, err := keyboard.LayoutByName(name)
+		if err != nil {
+			fmt.Printf("키맵 오류: %v\n", err)
+			return
+		}
+
+		layers := keyboard.Layers
+		if keymapLayer != "all" {
+			layers = []string{keymapLayer}
+			if !containsWord(keyboard.Layers, keymapLayer) {
+				fmt.Printf("키맵 오류: 알 수 없는 층입니다: %s (사용 가능: %s, all)\n", keymapLayer, strings.Join(keyboard.Layers, ", "))
+				return
+			}
+		}
+
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+		bindings := keyboard.Bind(commands)
+
+		var b strings.Builder
+		switch keymapFormat {
+		case "text":
+			for i, layer := range layers {
+				if i > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString(keyboard.Text(layout, bindings, layer, lang, style.Enabled && keymapOutput == ""))
+			}
+		case "svg":
+			err = keyboard.WriteSVG(&b, layout, bindings, lang)
+		default:
+			err = fmt.Errorf("지원하지 않는 형식입니다: %s (사용 가능: text, svg)", keymapFormat)
+		}
+		if err != nil {
+			fmt.Printf("키맵 오류: %v\n", err)
+			return
+		}
+
+		if keymapOutput == "" {
+			fmt.Print(b.String())
+			return
+		}
+		if err := ioutil.WriteFile(keymapOutput, []byte(b.String()), 0644); err != nil {
+			fmt.Printf("키맵 저장 오류: %v\n", err)
+			return
+		}
+		if lang == "en" {
+			fmt.Printf("Wrote the %s keymap to %s.\n", layout.Name, keymapOutput)
+		} else {
+			fmt.Printf("%s 키맵을 %s에 저장했습니다.\n", layout.Name, keymapOutput)
+		}
+	},
+}
+
+func init() {
+	keymapCmd.Flags().StringVar(&keymapLayout, "layout", "qwerty", "자판 배열 ("+strings.Join(keyboard.LayoutNames(), "/")+", 기본값: keymap.layout 설정)")
+	keymapCmd.Flags().StringVar(&keymapLayer, "layer", "all", "그릴 층 ("+strings.Join(keyboard.Layers, "/")+"/all)")
+	keymapCmd.Flags().StringVar(&keymapFormat, "format", "text", "출력 형식 (text/svg)")
+	keymapCmd.Flags().StringVarP(&keymapOutput, "output", "o", "", "출력 파일 (생략하면 표준 출력)")
+	keymapCmd.RegisterFlagCompletionFunc("layout", cobra.FixedCompletions(keyboard.LayoutNames(), cobra.ShellCompDirectiveNoFileComp))
+	keymapCmd.RegisterFlagCompletionFunc("layer", cobra.FixedCompletions(append(append([]string{}, keyboard.Layers...), "all"), cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.AddCommand(keymapCmd)
+}
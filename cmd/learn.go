@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/learn"
+)
+
+var learnCmd = &cobra.Command{
+	Use:   "learn",
+	Short: "단계별 학습 모드를 시작합니다",
+	Long: `단계별 vi 튜토리얼을 보여줍니다.
+
+하위 명령어:
+  start - 튜토리얼 시작 (레벨을 생략하면 learn.level 설정 사용)
+  list  - 레벨별 강의 목록 보기
+
+사용 예시:
+  vi-assistant learn start
+  vi-assistant learn start intermediate
+  vi-assistant learn list beginner`,
+}
+
+var learnStartCmd = &cobra.Command{
+	Use:   "start [level]",
+	Short: "튜토리얼을 시작합니다",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		level := viper.GetString("learn.level")
+		if len(args) == 1 {
+			level = args[0]
+		}
+		handleLearnMode(level)
+	},
+}
+
+var learnListCmd = &cobra.Command{
+	Use:   "list [level]",
+	Short: "강의 목록을 보여줍니다",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		levels := learn.Levels
+		if len(args) == 1 {
+			levels = args
+		}
+
+		for _, level := range levels {
+			switch level {
+			case "beginner":
+				fmt.Print(learn.FormatLessonList(learn.GetBeginnerLessons(lang), level, lang))
+			case "intermediate":
+				fmt.Print(learn.FormatLessonList(learn.GetIntermediateLessons(lang), level, lang))
+			default:
+				if lang == "en" {
+					fmt.Printf("Unknown level: %s. Use 'beginner' or 'intermediate'\n", level)
+				} else {
+					fmt.Printf("알 수 없는 레벨입니다: %s. 'beginner' 또는 'intermediate'를 사용하세요\n", level)
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	learnCmd.AddCommand(learnStartCmd)
+	learnCmd.AddCommand(learnListCmd)
+}
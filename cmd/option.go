@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/options"
+)
+
+var optionCmd = &cobra.Command{
+	Use:   "option [name]",
+	Short: "Vim 옵션(:set)의 뜻과 기본값을 보여줍니다",
+	Long: `Vim 옵션의 설명, 종류, 기본값, 범위와 사용 예시를 보여줍니다.
+
+전체 이름, 줄임말, :set 인자 형태를 모두 받습니다:
+  expandtab, et       옵션 이름과 줄임말
+  noet, invhls, et!   끄기/전환 접두사 (켬/끔 옵션만)
+  ts=4, sw+=2         값 지정, 더하기(+=), 빼기(-=), 앞에 추가(^=)
+  ":set noet"         :set 을 붙여도 됩니다
+
+이름을 생략하면 전체 옵션 목록을 보여줍니다.
+
+사용 예시:
+  vi-assistant option expandtab
+  vi-assistant option noic
+  vi-assistant option "path+=**"`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		db, err := options.Load()
+		if err != nil {
+			fmt.Printf("옵션 데이터베이스 오류: %v\n", err)
+			return
+		}
+
+		if len(args) == 0 {
+			if viper.GetString("output.format") == "json" {
+				printJSON(db.Options)
+				return
+			}
+			for _, o := range db.Options {
+				name := o.Name
+				if o.Abbreviation != "" {
+					name = fmt.Sprintf("%s (%s)", o.Name, o.Abbreviation)
+				}
+				fmt.Printf("%-26s %s\n", name, o.Describe(lang))
+			}
+			return
+		}
+
+		result := db.Explain(strings.Join(args, " "))
+		if viper.GetString("output.format") == "json" {
+			printJSON(result)
+			return
+		}
+		fmt.Print(options.FormatResult(result, lang))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(optionCmd)
+}
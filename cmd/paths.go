@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/paths"
+	"vi-assistant/internal/ui"
+)
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "설정, 데이터, 상태 파일의 위치를 보여줍니다",
+	Long: `vi-assistant가 사용하는 디렉토리와 파일 위치를 보여줍니다.
+
+XDG 기본 디렉토리 규칙을 따릅니다:
+  설정 - $XDG_CONFIG_HOME/vi-assistant (기본값: ~/.config/vi-assistant)
+  데이터 - $XDG_DATA_HOME/vi-assistant (기본값: ~/.local/share/vi-assistant)
+  상태 - $XDG_STATE_HOME/vi-assistant (기본값: ~/.local/state/vi-assistant)
+
+설정 파일의 paths.data, paths.state 값으로 데이터/상태 위치를 바꿀 수 있습니다.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		configFile := viper.ConfigFileUsed()
+		if configFile == "" {
+			configFile = appPaths.ConfigFile()
+		}
+
+		entries := []struct {
+			labelEN, labelKO, path string
+		}{
+			{"Config file", "설정 파일", configFile},
+			{"Config dir", "설정 디렉토리", appPaths.ConfigDir},
+			{"Data dir", "데이터 디렉토리", appPaths.DataDir},
+			{"State dir", "상태 디렉토리", appPaths.StateDir},
+			{"Favorites", "즐겨찾기", favoritesFilePath()},
+			{"Collections", "컬렉션", filepath.Join(filepath.Dir(favoritesFilePath()), "collections.json")},
+			{"Command packs", "명령어 팩", packDirPath()},
+		}
+
+		for _, e := range entries {
+			label := e.labelKO
+			if lang == "en" {
+				label = e.labelEN
+			}
+
+			// 파일이 아직 없으면 표시합니다
+			status := ""
+			if _, err := os.Stat(e.path); os.IsNotExist(err) {
+				if lang == "en" {
+					status = " (not created yet)"
+				} else {
+					status = " (아직 없음)"
+				}
+			}
+			// 한글 라벨은 두 칸씩 차지하므로 바이트가 아닌 화면 너비로 맞춥니다
+			label += ":"
+			fmt.Printf("%s%s %s%s\n", label, strings.Repeat(" ", max(16-ui.TextWidth(label), 0)), e.path, status)
+		}
+	},
+}
+
+// newFavoritesManager 함수는 initConfig에서 계산한 경로로 즐겨찾기 매니저를 만듭니다
+// favorites.path 설정이 있으면 그 파일을 사용합니다
+func newFavoritesManager() (*favorites.FavoritesManager, error) {
+	return favorites.NewFavoritesManagerAt(favoritesFilePath(), appPaths.StateDir)
+}
+
+// packDirPath 함수는 사용자 명령어 팩 디렉토리를 반환합니다
+// packs.dir 설정이 없으면 데이터 디렉토리의 packs를 사용합니다
+func packDirPath() string {
+	if dir := viper.GetString("packs.dir"); dir != "" {
+		home, _ := os.UserHomeDir()
+		return paths.ExpandHome(dir, home)
+	}
+	return filepath.Join(appPaths.DataDir, "packs")
+}
+
+// favoritesFilePath 함수는 실제로 사용할 즐겨찾기 파일 경로를 반환합니다
+func favoritesFilePath() string {
+	if path := viper.GetString("favorites.path"); path != "" {
+		home, _ := os.UserHomeDir()
+		return paths.ExpandHome(path, home)
+	}
+	return appPaths.FavoritesFile()
+}
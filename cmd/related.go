@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/explain"
+)
+
+var relatedCmd = &cobra.Command{
+	Use:   "related [command]",
+	Short: "관련 명령어를 관계 그래프로 따라가며 보여줍니다",
+	Long: `명령어 사이의 관계를 따라가며 관련 명령어를 보여줍니다.
+
+관계 종류:
+  반대 동작      u ↔ Ctrl+r
+  짝 명령어      p ↔ P, o ↔ O
+  일반형         dd → d{motion}
+  구체적인 형태  d{motion} → dd, dw, D
+  관련 명령어    카탈로그의 see_also
+  같은 카테고리  첫 단계에서만 표시
+
+--depth를 생략하면 related.depth 설정(기본값 2)을 사용합니다.
+
+사용 예시:
+  vi-assistant related dd
+  vi-assistant related u --depth 1`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		depth := viper.GetInt("related.depth")
+		if cmd.Flags().Changed("depth") {
+			depth, _ = cmd.Flags().GetInt("depth")
+		}
+		if depth < 1 {
+			fmt.Println("깊이는 1 이상이어야 합니다")
+			return
+		}
+
+		result, found, err := explain.Related(args[0], depth)
+		if err != nil {
+			fmt.Printf("관련 명령어 오류: %v\n", err)
+			return
+		}
+
+		// 명령어를 찾지 못하면 explain과 같이 제안 목록을 보여줍니다
+		if result == nil {
+			if viper.GetString("output.format") == "json" {
+				printJSON(found)
+			} else {
+				fmt.Print(explain.FormatExplanation(found, lang))
+			}
+			return
+		}
+
+		if viper.GetString("output.format") == "json" {
+			printJSON(result)
+			return
+		}
+		fmt.Print(explain.FormatRelated(result, lang))
+	},
+}
+
+func init() {
+	relatedCmd.Flags().Int("depth", 2, "따라갈 관계의 깊이 (기본값: related.depth 설정)")
+	rootCmd.AddCommand(relatedCmd)
+}
@@ -3,12 +3,19 @@
 package cmd
 
 import (
+	"encoding/json"  // JSON 출력을 위한 패키지
 	"fmt"  // 표준 출력/입력 포맷팅을 위한 패키지
 	"os"   // 운영체제 인터페이스를 위한 패키지
+	"sort"  // 설정 경고를 키 순서로 출력하기 위한 패키지
+	"strings"  // 환경 변수 이름 변환을 위한 패키지
 
 	"github.com/spf13/cobra"  // CLI 명령어 프레임워크
 	"github.com/spf13/viper"  // 설정 관리 라이브러리
+	"vi-assistant/internal/catalog"  // 명령어 카탈로그 설정을 위한 내부 패키지
+	"vi-assistant/internal/config"  // 설정 스키마를 위한 내부 패키지
 	"vi-assistant/internal/learn"  // 학습 모드 기능을 위한 내부 패키지
+	"vi-assistant/internal/paths"  // XDG 경로 계산을 위한 내부 패키지
+	"vi-assistant/internal/style"  // 색상 출력 설정을 위한 내부 패키지
 )
 
 // 전역 변수들 - CLI 플래그와 설정을 저장합니다
@@ -16,6 +23,7 @@ var (
 	cfgFile string  // 설정 파일 경로를 저장하는 변수
 	lang    string  // 출력 언어 설정 (ko/en)을 저장하는 변수
 	learnLevel string  // 학습 모드 레벨 (beginner/intermediate)을 저장하는 변수
+	appPaths paths.Paths  // initConfig에서 계산한 설정/데이터/상태 디렉토리
 )
 
 // rootCmd는 하위 명령어 없이 호출될 때의 기본 명령어를 나타냅니다
@@ -38,6 +46,11 @@ var rootCmd = &cobra.Command{
   vi-assistant --learn beginner
   vi-assistant help`,  // 긴 설명 (도움말에 표시됨)
 	Version: "1.0.0",  // 애플리케이션 버전
+	// 하위 명령어 없이 실행하면 도움말을 보여줍니다
+	// Run이 있어야 --learn 플래그를 처리하는 PersistentPreRun이 실행됩니다
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
 }
 
 // Execute 함수는 모든 하위 명령어를 루트 명령어에 추가하고 플래그를 적절히 설정합니다
@@ -53,22 +66,34 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	// 전역 플래그 설정 - 모든 하위 명령어에서 사용 가능한 플래그들
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "설정 파일 (기본값: $HOME/.vi-assistant.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "설정 파일 (기본값: $XDG_CONFIG_HOME/vi-assistant/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&lang, "lang", "ko", "출력 언어 (ko/en)")
 	rootCmd.PersistentFlags().StringVar(&learnLevel, "learn", "", "학습 모드 시작 (beginner/intermediate)")
+	rootCmd.PersistentFlags().String("output-format", "text", "search/explain 출력 형식 (text/json)")
+	rootCmd.PersistentFlags().String("color", "auto", "색상 사용 (auto/always/never)")
 
 	// 로컬 플래그 설정 - 루트 명령어에서만 사용 가능한 플래그
 	rootCmd.Flags().BoolP("toggle", "t", false, "도움말 토글")
 
+	// 설정 스키마의 기본값을 등록합니다
+	for _, key := range config.Keys {
+		viper.SetDefault(key.Name, key.Default)
+	}
+
 	// Viper 설정 바인딩 - 플래그 값을 설정으로 연결
 	viper.BindPFlag("lang", rootCmd.PersistentFlags().Lookup("lang"))
-	viper.BindPFlag("learn", rootCmd.PersistentFlags().Lookup("learn"))
+	viper.BindPFlag("output.format", rootCmd.PersistentFlags().Lookup("output-format"))
+	viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
 
 	// 하위 명령어들을 루트 명령어에 추가
 	rootCmd.AddCommand(searchCmd)    // 검색 명령어
 	rootCmd.AddCommand(explainCmd)   // 설명 명령어
 	rootCmd.AddCommand(helpCmd)      // 도움말 명령어
 	rootCmd.AddCommand(favoritesCmd) // 즐겨찾기 명령어
+	rootCmd.AddCommand(pathsCmd)     // 경로 확인 명령어
+	rootCmd.AddCommand(configCmd)    // 설정 관리 명령어
+	rootCmd.AddCommand(learnCmd)     // 학습 모드 명령어
+	rootCmd.AddCommand(catalogCmd)   // 카탈로그 관리 명령어
 
 	// 학습 모드 플래그 처리 - 명령어 실행 전에 학습 모드가 설정되었는지 확인
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -80,28 +105,149 @@ func init() {
 
 // initConfig 함수는 설정 파일과 환경 변수를 읽어들입니다
 // 애플리케이션 시작 시 자동으로 호출되어 설정을 초기화합니다
+// 설정/데이터/상태 디렉토리는 XDG 기본 디렉토리 규칙을 따릅니다
 func initConfig() {
+	// XDG 기본 경로를 먼저 계산합니다 (설정 파일 위치를 알아야 하므로)
+	defaults, err := paths.Resolve(paths.Paths{})
+	cobra.CheckErr(err)  // 오류가 발생하면 프로그램 종료
+
+	// 이전 버전 파일은 한 번만 옮깁니다 (상태 디렉토리의 .migrated 파일로 확인)
+	// --config로 다른 설정 파일을 쓰거나 셸 자동 완성 중일 때는 옮기지 않습니다
+	migrate := cfgFile == "" && !isCompletionRequest() && !paths.Migrated(defaults)
+	migrated := true
+
 	if cfgFile != "" {
 		// 플래그로 지정된 설정 파일을 사용
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// 홈 디렉토리를 찾습니다
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)  // 오류가 발생하면 프로그램 종료
+		// 이전 버전의 ~/.vi-assistant.yaml이 있으면 XDG 설정 디렉토리로 옮깁니다
+		if migrate {
+			moves, err := paths.MigrateConfig(defaults)
+			reportMigration(moves, err)
+			migrated = migrated && err == nil
+		}
 
-		// 홈 디렉토리에서 ".vi-assistant" 이름의 설정 파일을 찾습니다 (확장자 없음)
-		viper.AddConfigPath(home)      // 설정 파일 경로 추가
+		// $XDG_CONFIG_HOME/vi-assistant/config.yaml을 설정 파일로 사용합니다
+		viper.AddConfigPath(defaults.ConfigDir)  // 설정 파일 경로 추가
 		viper.SetConfigType("yaml")    // 설정 파일 타입을 YAML로 설정
-		viper.SetConfigName(".vi-assistant")  // 설정 파일 이름 설정
+		viper.SetConfigName("config")  // 설정 파일 이름 설정
 	}
 
-	// 환경 변수를 자동으로 읽어들입니다 (viper_로 시작하는 변수들)
+	// 환경 변수를 자동으로 읽어들입니다 (VI_ASSISTANT_로 시작하는 변수들)
+	// output.format은 VI_ASSISTANT_OUTPUT_FORMAT처럼 점을 밑줄로 바꿉니다
+	// 접두사가 없으면 $LANG 같은 시스템 변수가 설정을 덮어씁니다
+	viper.SetEnvPrefix("VI_ASSISTANT")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	// 설정 파일이 발견되면 읽어들입니다
+	configLoaded := false
 	if err := viper.ReadInConfig(); err == nil {
+		configLoaded = true
 		fmt.Fprintln(os.Stderr, "설정 파일 사용:", viper.ConfigFileUsed())
 	}
+
+	// 설정 파일의 paths.data / paths.state 값으로 기본 경로를 덮어씁니다
+	appPaths, err = paths.Resolve(paths.Paths{
+		ConfigDir: defaults.ConfigDir,
+		DataDir:   viper.GetString("paths.data"),
+		StateDir:  viper.GetString("paths.state"),
+	})
+	cobra.CheckErr(err)
+
+	// 이전 버전의 ~/.vi-assistant 디렉토리에 있던 데이터를 옮깁니다
+	// favorites.path를 지정했으면 즐겨찾기와 컬렉션은 그 위치를 따르므로 그대로 두고,
+	// 나중에 설정을 지웠을 때 옮길 수 있도록 완료 표시도 남기지 않습니다
+	if migrate && viper.GetString("favorites.path") == "" {
+		moves, err := paths.MigrateData(appPaths)
+		reportMigration(moves, err)
+		if migrated && err == nil {
+			if err := paths.MarkMigrated(defaults); err != nil {
+				fmt.Fprintf(os.Stderr, "파일 이전 오류: %v\n", err)
+			}
+		}
+	}
+
+	// 설정 파일의 잘못된 키나 값을 알려줍니다 (실행은 계속합니다)
+	if configLoaded {
+		validateConfigFile(viper.ConfigFileUsed())
+	}
+
+	// 설정 값을 각 패키지에 적용합니다
+	catalog.SetSources(viper.GetStringSlice("data.sources"))
+	catalog.SetPackDir(packDirPath())
+	if err := catalog.SetPrecedence(viper.GetString("packs.precedence")); err != nil {
+		fmt.Fprintf(os.Stderr, "설정 경고: %v\n", err)
+	}
+	style.Enabled = colorEnabled()
+}
+
+// validateConfigFile 함수는 설정 파일에 적힌 값만 검사해서 경고를 출력합니다
+// 플래그나 환경 변수로 덮어쓴 값이 파일의 잘못된 값을 가리지 않도록 파일을 따로 읽습니다
+func validateConfigFile(path string) {
+	fileConfig := viper.New()
+	fileConfig.SetConfigFile(path)
+	if err := fileConfig.ReadInConfig(); err != nil {
+		return
+	}
+	keys := fileConfig.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := config.Validate(key, fileConfig.Get(key)); err != nil {
+			fmt.Fprintf(os.Stderr, "설정 경고: %v\n", err)
+		}
+	}
+}
+
+// colorEnabled 함수는 color 설정에 따라 색상 출력 여부를 결정합니다
+// auto는 표준 출력이 터미널이고 NO_COLOR 환경 변수가 없을 때만 색상을 사용합니다
+func colorEnabled() bool {
+	switch viper.GetString("color") {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// reportMigration 함수는 첫 실행 시 옮긴 파일 목록이나 오류를 stderr에 알립니다
+func reportMigration(moves []paths.Move, err error) {
+	for _, m := range moves {
+		fmt.Fprintf(os.Stderr, "파일을 새 위치로 옮겼습니다: %s -> %s\n", m.From, m.To)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "파일 이전 오류: %v\n", err)
+	}
+}
+
+// isCompletionRequest 함수는 셸이 자동 완성 후보를 요청한 실행인지 확인합니다
+// 자동 완성 중에는 파일을 옮기거나 stderr에 안내를 출력하지 않아야 합니다
+func isCompletionRequest() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+	switch os.Args[1] {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+		return true
+	}
+	return false
+}
+
+// printJSON 함수는 값을 들여쓰기된 JSON으로 표준 출력에 씁니다
+// output.format 설정이 json일 때 사용됩니다
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Printf("JSON 변환 오류: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
 }
 
 // getMessage 함수는 현재 언어 설정에 따라 지역화된 메시지를 반환합니다
@@ -171,7 +317,7 @@ func handleLearnMode(level string) {
 		// 각 강의를 순차적으로 표시합니다
 		for i, lesson := range lessons {
 			fmt.Print(learn.FormatLesson(lesson, i+1, lang))  // 강의 내용 출력
-			if i < len(lessons)-1 {  // 마지막 강의가 아닌 경우
+			if i < len(lessons)-1 && viper.GetBool("learn.pause") {  // 마지막 강의가 아니고 learn.pause 설정이 켜진 경우
 				if lang == "en" {
 					fmt.Println("\nPress Enter to continue to next lesson...")
 				} else {
@@ -202,7 +348,7 @@ func handleLearnMode(level string) {
 		// 각 강의를 순차적으로 표시합니다
 		for i, lesson := range lessons {
 			fmt.Print(learn.FormatLesson(lesson, i+1, lang))  // 강의 내용 출력
-			if i < len(lessons)-1 {  // 마지막 강의가 아닌 경우
+			if i < len(lessons)-1 && viper.GetBool("learn.pause") {  // 마지막 강의가 아니고 learn.pause 설정이 켜진 경우
 				if lang == "en" {
 					fmt.Println("\nPress Enter to continue to next lesson...")
 				} else {
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/rpc"
+)
+
+var rpcCmd = &cobra.Command{
+	Use:   "rpc",
+	Short: "에디터 플러그인용 JSON-RPC를 표준 입출력으로 제공합니다",
+	Long: `표준 입력에서 한 줄에 하나씩 JSON-RPC 2.0 요청을 읽고, 응답을 한 줄씩 표준 출력에 씁니다.
+Neovim/Vim 플러그인이 프로세스를 한 번만 띄우고 계속 요청할 수 있습니다.
+카탈로그는 한 번만 읽으며, 입력이 끝나면 종료합니다.
+
+메서드 (프로토콜 버전 1):
+  version     프로토콜 버전과 메서드 목록
+  explain     {"command": "dd"}
+  search      {"query": "delete mode:visual", "limit": 5}
+  parse-keys  {"keys": "3dw<Esc>"} (Vim 키 표기, "raw": true면 입력한 바이트 그대로)
+  options     {"name": "sw+=2"}, {"search": "case"}, 매개변수 없으면 전체 목록
+  favorites   {"action": "list|add|remove", "command": "dd", "tags": [], "note": "", "tag": "", "sort": ""}
+
+사용 예시:
+  echo '{"jsonrpc":"2.0","id":1,"method":"explain","params":{"command":"dd"}}' | vi-assistant rpc`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		readOnly, _ := cmd.Flags().GetBool("read-only")
+
+		// Every request shares the catalog read here
+		catalog.KeepLoaded(true)
+		defer catalog.KeepLoaded(false)
+
+		server := rpc.New(rpc.Options{
+			SearchLimit: viper.GetInt("search.limit"),
+			ReadOnly:    readOnly,
+			Favorites: func() (*favorites.FavoritesManager, error) {
+				return newFavoritesManager()
+			},
+		})
+		if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s%v\n", getMessage("error"), err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rpcCmd.Flags().Bool("read-only", false, "즐겨찾기 추가/제거를 막습니다")
+	rootCmd.AddCommand(rpcCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"fmt"  // 표준 출력/입력 포맷팅을 위한 패키지
+	"strings"  // 인수를 검색어로 합치기 위한 패키지
 
 	"github.com/spf13/cobra"  // CLI 명령어 프레임워크
 	"github.com/spf13/viper"  // 설정 관리 라이브러리
@@ -12,7 +13,7 @@ import (
 // searchCmd는 vi 명령어 검색을 위한 Cobra 명령어입니다
 // 사용자가 키워드를 입력하면 관련된 vi 명령어들을 검색하여 표시합니다
 var searchCmd = &cobra.Command{
-	Use:   "search [keyword]",  // 명령어 사용법 - keyword는 필수 인수
+	Use:   "search [keyword] [filter:value...]",  // 명령어 사용법 - 키워드나 필터가 하나 이상 필요
 	Short: "키워드로 vi 명령어를 검색합니다",  // 짧은 설명
 	Long: `키워드를 사용하여 vi/vim 명령어를 검색합니다.
 
@@ -21,15 +22,27 @@ var searchCmd = &cobra.Command{
 - 실제 명령어
 - 설명
 - 카테고리
+- 별칭 (예: :quit)
+
+"필드:값" 형태의 필터로 결과를 좁힐 수 있습니다:
+  category:값   카테고리가 일치하는 명령어
+  mode:값       normal, insert, visual, command-line (n, i, v, c로 줄여 쓸 수 있음)
+  source:값     출처가 일치하는 명령어 (built-in 또는 팩 이름)
+  posix:yes|no  POSIX vi에 있는지 여부
+  vim:버전      해당 Vim 버전에서 쓸 수 있는 명령어 (yes, no도 가능)
+  nvim:버전     해당 Neovim 버전에서 쓸 수 있는 명령어 (yes, no도 가능)
 
 사용 예시:
   vi-assistant search copy
   vi-assistant search save
-  vi-assistant search navigation`,  // 긴 설명 (도움말에 표시됨)
-	Args: cobra.ExactArgs(1),  // 정확히 1개의 인수가 필요함을 지정
+  vi-assistant search navigation
+  vi-assistant search mode:visual
+  vi-assistant search move posix:no
+  vi-assistant search category:search vim:7.0`,  // 긴 설명 (도움말에 표시됨)
+	Args: cobra.MinimumNArgs(1),  // 키워드나 필터가 1개 이상 필요함을 지정
 	Run: func(cmd *cobra.Command, args []string) {
 		// 명령어 실행 시 호출되는 함수
-		keyword := args[0]  // 첫 번째 인수를 키워드로 사용
+		keyword := strings.Join(args, " ")  // 모든 인수를 하나의 검색어로 사용
 		lang := viper.GetString("lang")  // 현재 언어 설정을 가져옴
 
 		// 검색 기능을 실행합니다
@@ -39,6 +52,15 @@ var searchCmd = &cobra.Command{
 			return
 		}
 
+		// search.limit 설정만큼만 표시합니다 (0이면 제한 없음)
+		results.Limit(viper.GetInt("search.limit"))
+
+		// output.format이 json이면 JSON으로 출력합니다
+		if viper.GetString("output.format") == "json" {
+			printJSON(results)
+			return
+		}
+
 		// 검색 결과를 포맷팅하여 출력합니다
 		output := search.FormatSearchResults(results, lang)
 		fmt.Print(output)
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/server"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "카탈로그를 로컬 HTTP/JSON API로 제공합니다",
+	Long: `명령어 검색, 설명, 카테고리, 즐겨찾기, 강의를 JSON API로 제공합니다.
+CLI와 같은 카탈로그, 팩, 즐겨찾기 파일을 사용합니다.
+
+엔드포인트 (모두 /api/v1 아래):
+  GET    /health                     상태 확인
+  GET    /search?q=검색어&limit=N     검색 (search 명령과 같은 필터 사용 가능)
+  GET    /explain?command=dd         명령어 설명 (없으면 404와 제안 목록)
+  GET    /categories                 카테고리 목록
+  GET    /favorites?tag=&sort=       즐겨찾기 목록
+  POST   /favorites                  즐겨찾기 추가 {"command": "dd", "tags": [], "note": ""}
+  DELETE /favorites?command=dd       즐겨찾기 제거
+  GET    /lessons?level=&lang=       강의 목록
+
+Ctrl+C(SIGINT)나 SIGTERM을 받으면 진행 중인 요청을 마친 뒤 종료합니다.
+요청 기록은 표준 에러로 출력합니다 (--quiet로 끄기).
+
+사용 예시:
+  vi-assistant serve
+  vi-assistant serve --addr 127.0.0.1:9000 --cors-origin http://localhost:3000
+  curl 'http://127.0.0.1:8420/api/v1/search?q=delete'`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := viper.GetString("serve.addr")
+		if cmd.Flags().Changed("addr") {
+			addr, _ = cmd.Flags().GetString("addr")
+		}
+		origins := viper.GetStringSlice("serve.cors_origins")
+		if cmd.Flags().Changed("cors-origin") {
+			origins, _ = cmd.Flags().GetStringSlice("cors-origin")
+		}
+		readOnly, _ := cmd.Flags().GetBool("read-only")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		opts := server.Options{
+			Addr:         addr,
+			AllowOrigins: origins,
+			Lang:         viper.GetString("lang"),
+			SearchLimit:  viper.GetInt("search.limit"),
+			ReadOnly:     readOnly,
+			Favorites: func() (*favorites.FavoritesManager, error) {
+				return newFavoritesManager()
+			},
+		}
+		if !quiet {
+			opts.Logger = log.New(os.Stderr, "", log.LstdFlags)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "vi-assistant API: http://%s/api/%s/ (Ctrl+C로 종료)\n", addr, server.APIVersion)
+		if err := server.New(opts).ListenAndServe(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "서버 오류: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "서버를 종료했습니다")
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "127.0.0.1:8420", "기다릴 주소 (기본값: serve.addr 설정)")
+	serveCmd.Flags().StringSlice("cors-origin", nil, "API 호출을 허용할 브라우저 출처 (여러 번 지정 가능, *는 모두 허용)")
+	serveCmd.Flags().Bool("read-only", false, "즐겨찾기 추가/제거를 막습니다")
+	serveCmd.Flags().Bool("quiet", false, "요청 기록을 출력하지 않습니다")
+	rootCmd.AddCommand(serveCmd)
+}
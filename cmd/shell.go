@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/hangul"
+	"vi-assistant/internal/learn"
+	"vi-assistant/internal/search"
+	"vi-assistant/internal/shell"
+	"vi-assistant/internal/ui"
+)
+
+// shellCommands are the CLI commands available inside the shell
+var shellCommands = []string{"search", "explain", "related", "fav", "learn"}
+
+// shellBuiltins are handled by the shell itself
+var shellBuiltins = []string{"help", "history", "exit", "quit"}
+
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "명령어를 이어서 입력하는 대화형 셸을 엽니다",
+	Long: `search, explain, related, fav, learn 명령어를 프로그램을 다시 실행하지 않고
+이어서 입력할 수 있는 프롬프트를 엽니다. 카탈로그는 한 번만 읽습니다.
+
+편집 키:
+  Tab             명령어, 카탈로그 명령어, 카테고리(category:), 레벨 자동 완성
+  ↑/↓, Ctrl+p/n   이전 입력 (히스토리는 상태 디렉토리의 shell_history에 저장)
+  Ctrl+a/e        줄 처음 / 끝
+  Ctrl+w, Ctrl+u  단어 / 줄 지우기
+  Ctrl+c          입력 취소
+  Ctrl+d, exit    종료
+
+사용 예시:
+  vi-assistant shell
+  vi-assistant> search category:copy
+  vi-assistant> explain dd
+  vi-assistant> fav add dd --tag edit`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		// Every command in the session shares the catalog read here
+		catalog.KeepLoaded(true)
+		defer catalog.KeepLoaded(false)
+		if _, err := catalog.Load(); err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+
+		history, err := shell.LoadHistory(filepath.Join(appPaths.StateDir, "shell_history"), shell.DefaultHistorySize)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			history, _ = shell.LoadHistory("", shell.DefaultHistorySize)
+		}
+		editor := shell.NewEditor(os.Stdin, os.Stdout, history, shellCompletion())
+
+		// Without a terminal (piped input or output) lines are read as they are, without prompts
+		var tty *ui.TTY
+		if ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stdout) {
+			if tty, err = ui.OpenTTY(); err == nil {
+				defer tty.Restore()
+				tty.Restore()
+			}
+		}
+		interactive := tty != nil
+		editor.Plain = !interactive
+
+		// The terminal is raw only while a line is edited, so command output prints normally
+		readLine := func(prompt string) (string, error) {
+			if !interactive {
+				return editor.ReadLine("")
+			}
+			if err := tty.Raw(); err != nil {
+				return "", err
+			}
+			defer tty.Restore()
+			if width, _, err := tty.Size(); err == nil && width > 0 {
+				editor.Width = width
+			}
+			return editor.ReadLine(prompt)
+		}
+
+		if interactive {
+			fmt.Println(getMessage("welcome"))
+			if lang == "en" {
+				fmt.Println("Type help for commands, Tab to complete, exit or Ctrl+D to quit.")
+			} else {
+				fmt.Println("help로 명령어 목록, Tab으로 자동 완성, exit 또는 Ctrl+D로 종료합니다.")
+			}
+		}
+
+		// search and explain without arguments ask for them with these prompts
+		prompts := map[string]string{
+			"search":  getMessage("search_prompt"),
+			"explain": getMessage("explain_prompt"),
+		}
+
+		for {
+			line, err := readLine("vi-assistant> ")
+			if err == shell.ErrInterrupt {
+				continue
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s%v\n", getMessage("error"), err)
+				return
+			}
+			if interactive {
+				if err := history.Add(line); err != nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+				}
+			}
+
+			words, err := shell.Split(line)
+			if err != nil {
+				fmt.Printf("%s%v\n", getMessage("error"), err)
+				continue
+			}
+			if len(words) == 0 {
+				continue
+			}
+
+			// search and explain without arguments ask for them
+			if prompt, ok := prompts[words[0]]; ok && len(words) == 1 {
+				root := editor.Complete
+				editor.Complete = root.Words[words[0]]
+				answer, err := readLine(prompt)
+				editor.Complete = root
+				if err != nil {
+					continue
+				}
+				more, err := shell.Split(answer)
+				if err != nil || len(more) == 0 {
+					continue
+				}
+				words = append(words, more...)
+			}
+
+			if runShellLine(words, history) {
+				return
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
+
+// runShellLine runs one shell line and reports whether the shell should exit
+func runShellLine(words []string, history *shell.History) bool {
+	lang := viper.GetString("lang")
+
+	switch words[0] {
+	case "exit", "quit":
+		return true
+	case "history":
+		for i, entry := range history.Entries() {
+			fmt.Printf("%4d  %s\n", i+1, entry)
+		}
+		return false
+	case "help":
+		if len(words) > 1 && containsWord(shellCommands, words[1]) {
+			if target, _, err := rootCmd.Find(words[1:]); err == nil {
+				target.Help()
+				return false
+			}
+		}
+		printShellHelp(lang)
+		return false
+	}
+
+	if !containsWord(shellCommands, words[0]) {
+		// A command typed in Hangul input mode is not run, since the rest of the line is likely mistyped too
+		if g := hangulShellCommand(words[0]); g != nil {
+			fmt.Print(g.Warning(lang))
+			return false
+		}
+		if lang == "en" {
+			fmt.Printf("Unknown command: %s (type help for the list)\n", words[0])
+		} else {
+			fmt.Printf("알 수 없는 명령어입니다: %s (help로 목록 보기)\n", words[0])
+		}
+		return false
+	}
+
+	target, rest, err := rootCmd.Find(words)
+	if err == nil {
+		err = runInShell(target, rest)
+	}
+	if err != nil {
+		fmt.Printf("%s%v\n", getMessage("error"), err)
+	}
+	return false
+}
+
+// hangulShellCommand reads word back as the keys typed in Hangul input mode
+// and returns the guess when they are a shell command or builtin
+func hangulShellCommand(word string) *hangul.Guess {
+	for _, g := range hangul.Guesses(word) {
+		if containsWord(shellCommands, g.Keys) || containsWord(shellBuiltins, g.Keys) {
+			return &g
+		}
+	}
+	return nil
+}
+
+// runInShell parses flags and runs a command the way Execute would, then puts
+// the flags back so options given on one line do not leak into the next
+func runInShell(target *cobra.Command, args []string) error {
+	target.InitDefaultHelpFlag()
+	restore := saveFlags(target)
+	defer restore()
+
+	if err := target.ParseFlags(args); err != nil {
+		return err
+	}
+	if help, _ := target.Flags().GetBool("help"); help {
+		return target.Help()
+	}
+	if !target.Runnable() {
+		return target.Help()
+	}
+
+	positional := target.Flags().Args()
+	if err := target.ValidateArgs(positional); err != nil {
+		return err
+	}
+	if target.RunE != nil {
+		return target.RunE(target, positional)
+	}
+	target.Run(target, positional)
+	return nil
+}
+
+// saveFlags records the values and changed state of a command's flags,
+// including the persistent flags it inherits, and returns a function restoring them
+func saveFlags(target *cobra.Command) func() {
+	type saved struct {
+		value   string
+		slice   []string
+		changed bool
+	}
+	state := make(map[*pflag.Flag]saved)
+	record := func(f *pflag.Flag) {
+		s := saved{value: f.Value.String(), changed: f.Changed}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			s.slice = sv.GetSlice()
+		}
+		state[f] = s
+	}
+	target.LocalFlags().VisitAll(record)
+	target.InheritedFlags().VisitAll(record)
+
+	return func() {
+		for f, s := range state {
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				sv.Replace(s.slice)
+			} else {
+				f.Value.Set(s.value)
+			}
+			f.Changed = s.changed
+		}
+	}
+}
+
+// shellCompletion builds the completion tree from the catalog and the CLI commands
+func shellCompletion() *shell.Node {
+	commandNames := func() []string {
+		commands, err := catalog.Load()
+		if err != nil {
+			return nil
+		}
+		names := make([]string, len(commands))
+		for i, c := range commands {
+			names[i] = c.Command
+		}
+		return names
+	}
+	favoriteNames := func() []string {
+		fm, err := newFavoritesManager()
+		if err != nil {
+			return nil
+		}
+		list, err := fm.List()
+		if err != nil {
+			return nil
+		}
+		names := make([]string, len(list))
+		for i, f := range list {
+			names[i] = f.ID()
+		}
+		return names
+	}
+	categories, _ := search.GetCategories()
+	filters := map[string][]string{"category": categories, "mode": catalog.Modes}
+	for _, key := range search.FilterKeys {
+		if _, ok := filters[key]; !ok {
+			filters[key] = nil
+		}
+	}
+	for _, key := range []string{"posix", "vim", "nvim"} {
+		filters[key] = []string{"yes", "no"}
+	}
+	for alias, key := range search.FilterAliases {
+		filters[alias] = filters[key]
+	}
+	levels := func() []string { return learn.Levels }
+
+	// Subcommands complete by name; those taking a command get the catalog or favorites
+	subcommands := func(parent *cobra.Command, values map[string]func() []string) *shell.Node {
+		node := &shell.Node{Words: make(map[string]*shell.Node)}
+		for _, sub := range parent.Commands() {
+			if sub.Hidden || sub.Name() == "help" {
+				continue
+			}
+			node.Words[sub.Name()] = &shell.Node{Values: values[sub.Name()], Args: 1}
+		}
+		return node
+	}
+
+	root := &shell.Node{Words: map[string]*shell.Node{
+		"search":  {Values: shell.FilterValues(filters)},
+		"explain": {Values: commandNames, Args: 1},
+		"related": {Values: commandNames, Args: 1},
+		"fav": subcommands(favoritesCmd, map[string]func() []string{
+			"add":    commandNames,
+			"remove": favoriteNames,
+			"tag":    favoriteNames,
+			"untag":  favoriteNames,
+			"note":   favoriteNames,
+		}),
+		"learn": subcommands(learnCmd, map[string]func() []string{
+			"start": levels,
+			"list":  levels,
+		}),
+		"help": {Values: func() []string { return shellCommands }, Args: 1},
+	}}
+	for _, name := range shellBuiltins {
+		if _, ok := root.Words[name]; !ok {
+			root.Words[name] = &shell.Node{Args: 1}
+		}
+	}
+	return root
+}
+
+// builtinHelp describes the shell's own commands in Korean and English
+var builtinHelp = map[string][2]string{
+	"help":    {"이 목록이나 명령어 도움말 보기", "Show this list or help for a command"},
+	"history": {"이전 입력 보기", "Show previous input"},
+	"exit":    {"셸 종료", "Leave the shell"},
+	"quit":    {"셸 종료", "Leave the shell"},
+}
+
+func printShellHelp(lang string) {
+	names := append(append([]string(nil), shellCommands...), shellBuiltins...)
+	sort.Strings(names)
+	if lang == "en" {
+		fmt.Println("Commands:")
+	} else {
+		fmt.Println("명령어:")
+	}
+	for _, name := range names {
+		short := ""
+		if text, ok := builtinHelp[name]; ok {
+			short = text[0]
+			if lang == "en" {
+				short = text[1]
+			}
+		} else if target, _, err := rootCmd.Find([]string{name}); err == nil {
+			short = target.Short
+		}
+		fmt.Printf("  %-8s %s\n", name, short)
+	}
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
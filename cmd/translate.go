@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/editors"
+	"vi-assistant/internal/explain"
+	"vi-assistant/internal/style"
+)
+
+// translate 명령어 플래그
+var (
+	translateFrom string   // 이 편집기의 키를 vi 명령어로
+	translateTo   []string // 이 편집기의 대응 키만
+)
+
+var translateCmd = &cobra.Command{
+	Use:   "translate [command|keys]",
+	Short: "vi 명령어와 다른 편집기(Emacs, VS Code, nano)의 키를 서로 바꿔 보여줍니다",
+	Long: `vi 명령어가 다른 편집기에서는 어떤 키인지, 다른 편집기의 키가 vi에서는 어떤 명령어인지 보여줍니다.
+VSCodeVim, IdeaVim은 Vim과 다르게 동작하는 점을 알려줍니다.
+
+편집기마다 data/editors 아래 파일 하나가 있으며, 키는 편집기의 표기(^K, C-k, Ctrl+K)를 모두 받습니다.
+
+사용 예시:
+  vi-assistant translate dd                  dd는 다른 편집기에서?
+  vi-assistant translate :wq --to vscode     VS Code에서만
+  vi-assistant translate --from nano ^K      nano의 ^K는 vi에서?
+  vi-assistant translate --from emacs "C-x C-s" --to nano
+  vi-assistant translate --from emacs        Emacs 전체 대응표
+  vi-assistant translate                     편집기 목록`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		db, err := editors.Load()
+		if err != nil {
+			fmt.Printf("편집기 데이터 오류: %v\n", err)
+			return
+		}
+		var to []*editors.Editor
+		for _, name := range translateTo {
+			e, err := db.Editor(strings.TrimSpace(name))
+			if err != nil {
+				fmt.Printf("번역 오류: %v\n", err)
+				return
+			}
+			to = append(to, e)
+		}
+
+		switch {
+		case translateFrom != "":
+			from, err := db.Editor(translateFrom)
+			if err != nil {
+				fmt.Printf("번역 오류: %v\n", err)
+				return
+			}
+			translateFromEditor(db, from, strings.Join(args, " "), to, lang)
+		case len(args) > 0:
+			translateCommand(db, strings.Join(args, " "), to, lang)
+		default:
+			listEditors(db, lang)
+		}
+	},
+}
+
+// translateCommand shows the equivalents of a vi command, looked up like explain does
+func translateCommand(db *editors.DB, command string, to []*editors.Editor, lang string) {
+	result, err := explain.Explain(command)
+	if err != nil {
+		fmt.Printf("번역 오류: %v\n", err)
+		return
+	}
+	if !result.Found {
+		if viper.GetString("output.format") == "json" {
+			printJSON(result)
+		} else {
+			fmt.Print(explain.FormatExplanation(result, lang))
+		}
+		return
+	}
+
+	equivalents := filterEquivalents(db.Equivalents(result.Command.Command), to)
+	if viper.GetString("output.format") == "json" {
+		printJSON(map[string]interface{}{"command": result.Command, "ime": result.IME, "equivalents": equivalents})
+		return
+	}
+
+	en := lang == "en"
+	if result.IME != nil {
+		fmt.Println(result.IME.Warning(lang))
+	}
+	fmt.Printf("%s - %s\n\n", style.Command(result.Command.Command), result.Command.Description)
+	if len(equivalents) == 0 {
+		fmt.Println(pick(en, "다른 편집기의 대응 키가 없습니다.", "No equivalents in other editors."))
+		return
+	}
+	fmt.Print(editors.FormatEquivalents(equivalents, lang))
+	if len(to) == 1 {
+		fmt.Printf("\n%s\n", to[0].Describe(lang))
+	}
+}
+
+// translateFromEditor finds the vi commands of keys in an editor, or lists
+// the whole editor when no keys are given
+func translateFromEditor(db *editors.DB, from *editors.Editor, keys string, to []*editors.Editor, lang string) {
+	en := lang == "en"
+	mappings := from.Mappings
+	if keys != "" {
+		mappings = from.Find(keys)
+	}
+
+	commands, err := catalog.Load()
+	if err != nil {
+		fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+		return
+	}
+	described := make(map[string]string)
+	for _, cmd := range commands {
+		if _, exists := described[cmd.Command]; !exists {
+			described[cmd.Command] = cmd.Description
+		}
+	}
+
+	type translation struct {
+		Command     string               `json:"command"`
+		Description string               `json:"description"`
+		Keys        []string             `json:"keys"`
+		Note        string               `json:"note"`
+		Equivalents []editors.Equivalent `json:"equivalents,omitempty"`
+	}
+	var translations []translation
+	for _, m := range mappings {
+		if len(m.Keys) == 0 {
+			continue // nothing to type in the editor
+		}
+		var equivalents []editors.Equivalent
+		if len(to) > 0 {
+			equivalents = filterEquivalents(db.Equivalents(m.Command), to)
+		}
+		translations = append(translations, translation{m.Command, described[m.Command], m.Keys, m.Describe(lang), equivalents})
+	}
+
+	if viper.GetString("output.format") == "json" {
+		printJSON(map[string]interface{}{"editor": from.ID, "keys": keys, "commands": translations})
+		return
+	}
+
+	if len(translations) == 0 {
+		fmt.Printf(pick(en, "%s의 %s에 대응하는 vi 명령어가 없습니다.\n", "No vi command matches %[2]s in %[1]s.\n"), from.Name, keys)
+		return
+	}
+	if keys != "" {
+		fmt.Printf(pick(en, "%s의 %s → vi:\n\n", "%s %s in vi:\n\n"), from.Name, keys)
+	} else {
+		fmt.Printf(pick(en, "%s → vi (%d개):\n%s\n\n", "%s to vi (%d):\n%s\n\n"), from.Name, len(translations), from.Describe(lang))
+	}
+	for _, t := range translations {
+		if keys != "" {
+			fmt.Printf("  %s - %s\n", style.Command(fmt.Sprintf("%-14s", t.Command)), t.Description)
+			fmt.Printf("  %-14s %s: %s\n", "", from.Name, t.Note)
+		} else {
+			fmt.Printf("  %-24s %s - %s\n", strings.Join(t.Keys, ", "), style.Command(t.Command), t.Note)
+		}
+		if len(t.Equivalents) > 0 {
+			fmt.Print(indent(editors.FormatEquivalents(t.Equivalents, lang), "  "))
+		}
+	}
+	if keys != "" && from.Kind == editors.KindEditor {
+		fmt.Printf("\n%s\n", from.Describe(lang))
+	}
+}
+
+// filterEquivalents keeps the equivalents in the given editors, or all of them when none are given
+func filterEquivalents(equivalents []editors.Equivalent, to []*editors.Editor) []editors.Equivalent {
+	if len(to) == 0 {
+		return equivalents
+	}
+	var kept []editors.Equivalent
+	for _, eq := range equivalents {
+		for _, e := range to {
+			if eq.Editor == e.ID {
+				kept = append(kept, eq)
+			}
+		}
+	}
+	return kept
+}
+
+// listEditors prints the editors with their notes
+func listEditors(db *editors.DB, lang string) {
+	if viper.GetString("output.format") == "json" {
+		printJSON(db.Editors)
+		return
+	}
+	en := lang == "en"
+	kinds := map[string]string{
+		editors.KindEditor:    pick(en, "편집기", "editor"),
+		editors.KindEmulation: pick(en, "Vim 에뮬레이션", "Vim emulation"),
+	}
+	for _, e := range db.Editors {
+		fmt.Printf("%-10s %s (%s, %s)\n", e.ID, e.Name, kinds[e.Kind], pick(en, fmt.Sprintf("명령어 %d개", len(e.Mappings)), fmt.Sprintf("%d commands", len(e.Mappings))))
+		fmt.Printf("%-10s %s\n", "", e.Describe(lang))
+	}
+}
+
+// indent prefixes every line of s
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func init() {
+	translateCmd.Flags().StringVar(&translateFrom, "from", "", "이 편집기의 키를 vi 명령어로 바꿉니다 (emacs/vscode/nano/...)")
+	translateCmd.Flags().StringSliceVar(&translateTo, "to", nil, "이 편집기의 대응 키만 (쉼표로 구분하거나 반복)")
+	translateCmd.RegisterFlagCompletionFunc("from", completeEditors)
+	translateCmd.RegisterFlagCompletionFunc("to", completeEditors)
+	rootCmd.AddCommand(translateCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/search"
+	"vi-assistant/internal/style"
+	"vi-assistant/internal/ui"
+)
+
+var uiCmd = &cobra.Command{
+	Use:   "ui [query]",
+	Short: "전체 화면에서 명령어를 찾아보고 즐겨찾기를 관리합니다",
+	Long: `카테고리 목록, 명령어 목록, 설명 창으로 이루어진 전체 화면 브라우저를 엽니다.
+vi처럼 키보드로 움직이고, 입력하는 대로 결과가 바뀌는 검색을 사용합니다.
+
+키:
+  j/k, ↓/↑       이동
+  h/l, Tab       카테고리 / 명령어 / 설명 창 전환
+  gg, G          처음 / 마지막
+  Ctrl+d/Ctrl+u  반 페이지 이동
+  /              검색 (search 명령과 같은 필터 사용 가능, Esc로 취소)
+  f              즐겨찾기 추가/제거
+  ?              도움말
+  q              종료
+
+사용 예시:
+  vi-assistant ui
+  vi-assistant ui "delete mode:visual"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 데이터를 로드할 수 없습니다: %v\n", err)
+			return
+		}
+		categories, err := search.GetCategories()
+		if err != nil {
+			fmt.Printf("카테고리 오류: %v\n", err)
+			return
+		}
+		sort.Strings(categories)
+
+		opts := ui.Options{
+			Commands:   commands,
+			Categories: categories,
+			Lang:       viper.GetString("lang"),
+			Query:      strings.Join(args, " "),
+		}
+		// Browsing still works when the favorites file cannot be opened
+		if fm, err := newFavoritesManager(); err == nil {
+			opts.Favorites = fm
+		}
+
+		tty, err := ui.OpenTTY()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ui 오류: %v\n", err)
+			os.Exit(1)
+		}
+		defer tty.Restore()
+
+		// The browser draws its own highlighting; the explanation text must stay plain
+		style.Enabled = false
+		if err := ui.Run(tty, ui.New(opts)); err != nil {
+			tty.Restore()
+			fmt.Fprintf(os.Stderr, "ui 오류: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
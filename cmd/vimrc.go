@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/options"
+	"vi-assistant/internal/vimrc"
+)
+
+var vimrcCmd = &cobra.Command{
+	Use:   "vimrc [path]",
+	Short: "vimrc 설정 파일을 한 줄씩 설명합니다",
+	Long: `vimrc 파일을 읽어 각 줄이 무엇을 하는지 설명합니다.
+
+설명하는 내용:
+  set/setlocal   옵션 데이터베이스로 옵션의 뜻과 값을 설명 (no/inv 접두사, +=, -= 포함)
+  map 계열       모드(nnoremap, inoremap 등), 재귀 여부, <silent> 같은 인자, <leader> 변환
+  autocmd        이벤트, 대상 파일, 실행할 명령
+  let            mapleader, &옵션, g: 변수
+  command        사용자 정의 명령
+
+내장 명령어를 덮어쓰는 매핑(예: nnoremap x ...)과 알 수 없는 옵션은 경고로 표시합니다.
+
+경로를 생략하면 ~/.vimrc, ~/.vim/vimrc, ~/.config/nvim/init.vim 순서로 찾습니다.
+경로가 - 이면 표준 입력에서 읽습니다.
+
+사용 예시:
+  vi-assistant vimrc
+  vi-assistant vimrc ~/dotfiles/vimrc
+  cat vimrc | vi-assistant vimrc -`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := viper.GetString("lang")
+
+		path := ""
+		if len(args) == 1 {
+			path = args[0]
+		} else {
+			path = findVimrc()
+			if path == "" {
+				if lang == "en" {
+					fmt.Println("No vimrc found (~/.vimrc, ~/.vim/vimrc, ~/.config/nvim/init.vim). Pass a path.")
+				} else {
+					fmt.Println("vimrc 파일을 찾지 못했습니다 (~/.vimrc, ~/.vim/vimrc, ~/.config/nvim/init.vim). 경로를 지정해 주세요.")
+				}
+				return
+			}
+		}
+
+		var input io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				fmt.Printf("vimrc 파일을 열 수 없습니다: %v\n", err)
+				return
+			}
+			defer f.Close()
+			input = f
+		}
+
+		commands, err := catalog.Load()
+		if err != nil {
+			fmt.Printf("명령어 카탈로그 오류: %v\n", err)
+			return
+		}
+		db, err := options.Load()
+		if err != nil {
+			fmt.Printf("옵션 데이터베이스 오류: %v\n", err)
+			return
+		}
+
+		report, err := vimrc.NewAnalyzer(commands, db, lang).Analyze(path, input)
+		if err != nil {
+			fmt.Printf("vimrc 분석 오류: %v\n", err)
+			return
+		}
+
+		if viper.GetString("output.format") == "json" {
+			printJSON(report)
+			return
+		}
+		fmt.Print(vimrc.FormatReport(report, lang))
+	},
+}
+
+// findVimrc 함수는 기본 위치에서 처음 발견한 vimrc 경로를 반환합니다
+func findVimrc() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, candidate := range vimrc.Candidates(home, os.Getenv("XDG_CONFIG_HOME")) {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(vimrcCmd)
+}
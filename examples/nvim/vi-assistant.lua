@@ -0,0 +1,82 @@
+-- Minimal Neovim client for `vi-assistant rpc`.
+-- Put it on 'runtimepath' (e.g. ~/.config/nvim/lua/vi-assistant.lua) and call
+--   require("vi-assistant").setup()
+-- :ViExplain [command] explains the given command or the WORD under the cursor
+-- in a floating window.
+local M = {}
+
+local job, next_id, pending, partial = nil, 1, {}, ""
+
+local function on_stdout(_, data)
+  -- data is split on newlines; the last item is the start of an unfinished line
+  data[1] = partial .. data[1]
+  partial = table.remove(data)
+  for _, line in ipairs(data) do
+    if line ~= "" then
+      local ok, resp = pcall(vim.json.decode, line)
+      if ok and pending[resp.id] then
+        local callback = pending[resp.id]
+        pending[resp.id] = nil
+        callback(resp.result, resp.error)
+      end
+    end
+  end
+end
+
+local function start()
+  if job then
+    return
+  end
+  job = vim.fn.jobstart({ "vi-assistant", "rpc" }, {
+    on_stdout = on_stdout,
+    on_exit = function()
+      job = nil
+    end,
+  })
+end
+
+-- request sends one method call; callback receives (result, error)
+function M.request(method, params, callback)
+  start()
+  local id = next_id
+  next_id = next_id + 1
+  pending[id] = callback
+  vim.fn.chansend(job, vim.json.encode({ jsonrpc = "2.0", id = id, method = method, params = params }) .. "\n")
+end
+
+local function popup(lines)
+  local buf = vim.api.nvim_create_buf(false, true)
+  vim.api.nvim_buf_set_lines(buf, 0, -1, false, lines)
+  local width = 0
+  for _, line in ipairs(lines) do
+    width = math.max(width, vim.fn.strdisplaywidth(line))
+  end
+  vim.api.nvim_open_win(buf, false, {
+    relative = "cursor", row = 1, col = 0, width = width, height = #lines,
+    style = "minimal", border = "rounded",
+  })
+  vim.api.nvim_create_autocmd("CursorMoved", { once = true, callback = function()
+    pcall(vim.api.nvim_buf_delete, buf, { force = true })
+  end })
+end
+
+function M.explain(command)
+  M.request("explain", { command = command }, function(result, err)
+    if err then
+      vim.notify(err.message, vim.log.levels.ERROR)
+    elseif not result.found then
+      vim.notify("vi-assistant: " .. command .. " not found")
+    else
+      local c = result.command
+      popup({ c.command .. "  [" .. c.category .. "]", c.description, c.example })
+    end
+  end)
+end
+
+function M.setup()
+  vim.api.nvim_create_user_command("ViExplain", function(opts)
+    M.explain(opts.args ~= "" and opts.args or vim.fn.expand("<cWORD>"))
+  end, { nargs = "?" })
+end
+
+return M
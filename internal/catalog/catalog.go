@@ -0,0 +1,296 @@
+// Package catalog loads the vi command catalog shared by search and explain.
+//
+// The catalog is built from the built-in sources (data/commands.json by default)
+// followed by user command packs: every *.json file in the pack directory, using
+// the same schema as commands.json (see SchemaVersion). Packs are applied in file name order.
+// When a pack defines a command that already exists, PrecedencePack (the default)
+// lets the pack entry replace it, while PrecedenceBuiltin keeps the built-in entry;
+// between packs, the later file always wins.
+package catalog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"vi-assistant/internal/paths"
+)
+
+// Command represents a vi command entry in a catalog file
+type Command struct {
+	Keyword     string `json:"keyword"`          // 검색 키워드
+	Command     string `json:"command"`          // 실제 vi 명령어 (예: :wq, yy)
+	Description string `json:"description"`      // 명령어 설명
+	Example     string `json:"example"`          // 사용 예제
+	Category    string `json:"category"`         // 카테고리 (file, edit, navigation 등)
+	Source      string `json:"source,omitempty"` // 출처 (built-in 또는 팩 이름)
+
+	// Optional metadata (see metadata.go)
+	Modes        []string      `json:"modes,omitempty"`        // 적용되는 모드 (normal, insert, visual, command-line)
+	Availability *Availability `json:"availability,omitempty"` // POSIX vi/Vim/Neovim 지원 여부
+	Aliases      []string      `json:"aliases,omitempty"`      // 같은 동작의 다른 표기 (예: :q의 :quit)
+	SeeAlso      []string      `json:"see_also,omitempty"`     // 함께 보면 좋은 명령어
+
+	// Typed links to other commands, keyed by DeclaredRelations (see graph.go)
+	Relations map[string][]string `json:"relations,omitempty"` // 예: {"inverse": ["Ctrl+r"]}
+
+	// Vim's own documentation, filled in by the help importer
+	HelpTag  string `json:"help_tag,omitempty"`  // :help 태그 (예: dd, CTRL-R)
+	HelpText string `json:"help_text,omitempty"` // Vim 도움말의 원문 설명
+}
+
+// BuiltinSource is the source label of the built-in catalog
+const BuiltinSource = "built-in"
+
+// Collision precedence between packs and built-in sources
+const (
+	PrecedencePack    = "pack"    // pack entries replace built-in ones
+	PrecedenceBuiltin = "builtin" // built-in entries are kept
+)
+
+// DefaultSource is the built-in catalog, relative to the working directory
+// or to the directory of the executable
+var DefaultSource = filepath.Join("data", "commands.json")
+
+var (
+	sources    = []string{DefaultSource} // built-in catalog files, in order
+	packDir    = ""                      // directory holding user command packs
+	precedence = PrecedencePack          // collision rule between packs and built-ins
+
+	keepLoaded = false // reuse the merged catalog between loads
+	loaded     *Result // catalog kept while keepLoaded is set
+)
+
+// Collision records a command defined by more than one source
+type Collision struct {
+	Command string
+	Winner  string // source label of the entry that was kept
+	Loser   string // source label of the entry that was dropped
+}
+
+// Pack describes a loaded command pack file
+type Pack struct {
+	Name     string
+	Path     string
+	Commands int
+}
+
+// Result is the outcome of loading the whole catalog
+type Result struct {
+	Commands   []Command
+	Packs      []Pack
+	Collisions []Collision
+	Warnings   []string // packs that could not be loaded
+}
+
+// SetSources replaces the built-in catalog files to load.
+// An empty list restores data/commands.json.
+func SetSources(paths []string) {
+	if len(paths) == 0 {
+		sources = []string{DefaultSource}
+		loaded = nil
+		return
+	}
+	sources = append([]string(nil), paths...)
+	loaded = nil
+}
+
+// Sources returns the configured built-in catalog files
+func Sources() []string {
+	return append([]string(nil), sources...)
+}
+
+// SetPackDir sets the directory scanned for command packs.
+// An empty directory disables packs.
+func SetPackDir(dir string) {
+	packDir = dir
+	loaded = nil
+}
+
+// PackDir returns the configured pack directory
+func PackDir() string {
+	return packDir
+}
+
+// SetPrecedence sets how collisions between packs and built-in commands are resolved
+func SetPrecedence(p string) error {
+	switch p {
+	case PrecedencePack, PrecedenceBuiltin:
+		precedence = p
+		loaded = nil
+		return nil
+	}
+	return fmt.Errorf("알 수 없는 우선순위입니다: %s (사용 가능: %s, %s)", p, PrecedencePack, PrecedenceBuiltin)
+}
+
+// KeepLoaded makes Load and LoadAll read the catalog files once and return
+// the same catalog afterwards, for long-running sessions such as the shell.
+// Changing the sources, pack directory or precedence reads the files again.
+// Callers must not modify the returned commands while this is on.
+func KeepLoaded(keep bool) {
+	keepLoaded = keep
+	loaded = nil
+}
+
+// Load returns the merged catalog.
+// Broken packs are skipped and reported on stderr so one bad file
+// does not make every command fail.
+func Load() ([]Command, error) {
+	if keepLoaded && loaded != nil {
+		return loaded.Commands, nil
+	}
+	result, err := LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+	return result.Commands, nil
+}
+
+// LoadAll loads the built-in sources and every pack and reports how they were merged
+func LoadAll() (*Result, error) {
+	if keepLoaded && loaded != nil {
+		return loaded, nil
+	}
+	result := &Result{}
+	index := make(map[string]int) // command -> position in result.Commands
+
+	// Built-in sources are concatenated as before; they are the base of the catalog
+	for _, source := range sources {
+		cmds, err := LoadFile(resolve(source))
+		if err != nil {
+			return nil, err
+		}
+		label := SourceLabel(source)
+		for _, cmd := range cmds {
+			if cmd.Source == "" {
+				cmd.Source = label
+			}
+			if _, exists := index[cmd.Command]; !exists {
+				index[cmd.Command] = len(result.Commands)
+			}
+			result.Commands = append(result.Commands, cmd)
+		}
+	}
+	builtinCount := len(result.Commands)
+
+	packFiles, err := packFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range packFiles {
+		doc, err := readDocument(path)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("명령어 팩을 건너뜁니다: %v", err))
+			continue
+		}
+
+		// A versioned pack may name itself; otherwise the file name is used
+		name := doc.Name
+		if name == "" {
+			name = packName(path)
+		}
+		result.Packs = append(result.Packs, Pack{Name: name, Path: path, Commands: len(doc.Commands)})
+
+		for _, cmd := range doc.Commands {
+			if cmd.Source == "" {
+				cmd.Source = name
+			}
+
+			i, exists := index[cmd.Command]
+			if !exists {
+				index[cmd.Command] = len(result.Commands)
+				result.Commands = append(result.Commands, cmd)
+				continue
+			}
+
+			existing := result.Commands[i]
+			if i < builtinCount && precedence == PrecedenceBuiltin {
+				result.Collisions = append(result.Collisions, Collision{Command: cmd.Command, Winner: existing.Source, Loser: cmd.Source})
+				continue
+			}
+
+			// Replace in place so the entry keeps its position in the listing
+			result.Collisions = append(result.Collisions, Collision{Command: cmd.Command, Winner: cmd.Source, Loser: existing.Source})
+			result.Commands[i] = cmd
+		}
+	}
+
+	if keepLoaded {
+		loaded = result
+	}
+	return result, nil
+}
+
+// LoadFile reads the commands of a single catalog file in either schema form
+func LoadFile(path string) ([]Command, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Commands, nil
+}
+
+// readDocument reads and parses a catalog file
+func readDocument(path string) (*Document, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s 파일을 읽을 수 없습니다: %v", filepath.Base(path), err)
+	}
+
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s JSON 파싱 오류: %v", filepath.Base(path), err)
+	}
+	return doc, nil
+}
+
+// SourceLabel returns the label used for commands loaded from a built-in source
+func SourceLabel(source string) string {
+	if filepath.Clean(source) == filepath.Clean(DefaultSource) {
+		return BuiltinSource
+	}
+	return packName(source)
+}
+
+// packName derives a pack name from its file name, e.g. "fugitive.json" -> "fugitive"
+func packName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// packFiles lists the pack files in name order
+func packFiles() ([]string, error) {
+	if packDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(packDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("명령어 팩 디렉토리를 읽을 수 없습니다: %v", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".json" {
+			continue
+		}
+		files = append(files, filepath.Join(packDir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// resolve finds a relative catalog path in the working directory first,
+// then next to the executable so the binary also works from other directories
+func resolve(path string) string {
+	return paths.Bundled(path)
+}
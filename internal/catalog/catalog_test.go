@@ -0,0 +1,205 @@
+package catalog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeepLoaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands.json")
+	write := func(command string) {
+		data := `{"schema_version": 1, "commands": [{"command": "` + command + `", "description": "d", "category": "edit"}]}`
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	load := func() string {
+		commands, err := Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commands[0].Command
+	}
+
+	SetSources([]string{path})
+	defer SetSources(nil)
+	KeepLoaded(true)
+	defer KeepLoaded(false)
+
+	write("dd")
+	if got := load(); got != "dd" {
+		t.Fatalf("first load = %s", got)
+	}
+
+	// The file is not read again while the catalog is kept
+	write("yy")
+	if got := load(); got != "dd" {
+		t.Errorf("kept catalog = %s, want dd", got)
+	}
+
+	// Changing the configuration reads the files again
+	SetSources([]string{path})
+	if got := load(); got != "yy" {
+		t.Errorf("after SetSources = %s, want yy", got)
+	}
+
+	KeepLoaded(false)
+	write("p")
+	if got := load(); got != "p" {
+		t.Errorf("without KeepLoaded = %s, want p", got)
+	}
+}
+
+func TestLoadAllPacks(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base.json")
+	data := `[{"command": "dd", "description": "base dd", "category": "delete"}, {"command": "yy", "description": "base yy", "category": "copy"}]`
+	if err := ioutil.WriteFile(base, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		precedence string
+		packs      map[string]string // file name -> contents
+		want       string            // command=source of every command, in catalog order
+		packNames  string
+		collisions string // command:winner>loser
+		warnings   int
+	}{
+		{
+			name:       "no packs",
+			precedence: PrecedencePack,
+			want:       "dd=base yy=base",
+		},
+		{
+			name:       "pack replaces a built-in command",
+			precedence: PrecedencePack,
+			packs:      map[string]string{"surround.json": `[{"command": "dd", "description": "pack dd"}, {"command": "ys"}]`},
+			want:       "dd=surround yy=base ys=surround",
+			packNames:  "surround",
+			collisions: "dd:surround>base",
+		},
+		{
+			name:       "built-in precedence keeps the built-in command",
+			precedence: PrecedenceBuiltin,
+			packs:      map[string]string{"surround.json": `[{"command": "dd"}, {"command": "ys"}]`},
+			want:       "dd=base yy=base ys=surround",
+			packNames:  "surround",
+			collisions: "dd:base>surround",
+		},
+		{
+			name:       "later pack overrides an earlier one even with built-in precedence",
+			precedence: PrecedenceBuiltin,
+			packs: map[string]string{
+				"a.json": `[{"command": "gs"}]`,
+				"b.json": `{"schema_version": 1, "name": "fugitive", "commands": [{"command": "gs"}]}`,
+			},
+			want:       "dd=base yy=base gs=fugitive",
+			packNames:  "a fugitive",
+			collisions: "gs:fugitive>a",
+		},
+		{
+			name:       "a source set in the pack is kept",
+			precedence: PrecedencePack,
+			packs:      map[string]string{"mine.json": `[{"command": "zz", "source": "vim-unimpaired"}]`},
+			want:       "dd=base yy=base zz=vim-unimpaired",
+			packNames:  "mine",
+		},
+		{
+			name:       "broken packs and other files are skipped",
+			precedence: PrecedencePack,
+			packs: map[string]string{
+				"a.json":    `{`,
+				"b.json":    `{"schema_version": 99, "commands": []}`,
+				"c.json":    `[{"command": "zz"}]`,
+				"notes.txt": `[{"command": "qq"}]`,
+			},
+			want:      "dd=base yy=base zz=c",
+			packNames: "c",
+			warnings:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tt.packs {
+				if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			SetSources([]string{base})
+			SetPackDir(dir)
+			if err := SetPrecedence(tt.precedence); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				SetSources(nil)
+				SetPackDir("")
+				SetPrecedence(PrecedencePack)
+			})
+
+			result, err := LoadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got, packs, collisions []string
+			for _, cmd := range result.Commands {
+				got = append(got, cmd.Command+"="+cmd.Source)
+			}
+			for _, p := range result.Packs {
+				packs = append(packs, p.Name)
+			}
+			for _, c := range result.Collisions {
+				collisions = append(collisions, fmt.Sprintf("%s:%s>%s", c.Command, c.Winner, c.Loser))
+			}
+			if s := strings.Join(got, " "); s != tt.want {
+				t.Errorf("commands = %s, want %s", s, tt.want)
+			}
+			if s := strings.Join(packs, " "); s != tt.packNames {
+				t.Errorf("packs = %s, want %s", s, tt.packNames)
+			}
+			if s := strings.Join(collisions, " "); s != tt.collisions {
+				t.Errorf("collisions = %s, want %s", s, tt.collisions)
+			}
+			if len(result.Warnings) != tt.warnings {
+				t.Errorf("warnings = %v, want %d", result.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestLoadAllReplacesInPlace(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	packs := filepath.Join(dir, "packs")
+	os.Mkdir(packs, 0755)
+	ioutil.WriteFile(base, []byte(`[{"command": "dd", "description": "base"}, {"command": "yy"}]`), 0644)
+	ioutil.WriteFile(filepath.Join(packs, "p.json"), []byte(`[{"command": "dd", "description": "pack"}]`), 0644)
+	SetSources([]string{base})
+	SetPackDir(packs)
+	t.Cleanup(func() { SetSources(nil); SetPackDir("") })
+
+	result, err := LoadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Pack{{Name: "p", Path: filepath.Join(packs, "p.json"), Commands: 1}}
+	if !reflect.DeepEqual(result.Packs, want) {
+		t.Errorf("packs = %+v, want %+v", result.Packs, want)
+	}
+	if len(result.Commands) != 2 || result.Commands[0].Description != "pack" {
+		t.Errorf("commands = %+v, want the pack's dd first", result.Commands)
+	}
+
+	// A missing pack directory is not an error
+	SetPackDir(filepath.Join(dir, "missing"))
+	if result, err := LoadAll(); err != nil || len(result.Commands) != 2 {
+		t.Errorf("missing pack directory: %v, %v", result, err)
+	}
+}
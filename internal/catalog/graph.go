@@ -0,0 +1,192 @@
+package catalog
+
+import (
+	"sort"
+)
+
+// Relation kinds between commands.
+//
+// Catalog entries declare inverse, counterpart and generalization links in
+// their "relations" object; the graph adds the reverse edges (a
+// generalization is seen as a specialization from the other side), plain
+// "see_also" links and neighbors from the same category.
+const (
+	RelationInverse        = "inverse"        // undoes the other command (u / Ctrl+r)
+	RelationCounterpart    = "counterpart"    // same action in the other direction (p / P, o / O)
+	RelationGeneralization = "generalization" // more general form (dd -> d{motion})
+	RelationSpecialization = "specialization" // more specific form (d{motion} -> dd)
+	RelationSeeAlso        = "see-also"       // listed in see_also
+	RelationCategory       = "category"       // same category
+)
+
+// RelationKinds lists the kinds in display order; earlier kinds win when
+// two commands are linked more than once
+var RelationKinds = []string{
+	RelationInverse, RelationCounterpart, RelationGeneralization,
+	RelationSpecialization, RelationSeeAlso, RelationCategory,
+}
+
+// DeclaredRelations are the kinds a catalog entry may declare in "relations"
+var DeclaredRelations = []string{RelationInverse, RelationCounterpart, RelationGeneralization}
+
+// reverseRelation is the kind of the edge added in the opposite direction
+var reverseRelation = map[string]string{
+	RelationInverse:        RelationInverse,
+	RelationCounterpart:    RelationCounterpart,
+	RelationGeneralization: RelationSpecialization,
+}
+
+// RelationTitle returns the localized heading for a relation kind
+func RelationTitle(kind, lang string) string {
+	titles := map[string][2]string{
+		RelationInverse:        {"반대 동작", "Inverse"},
+		RelationCounterpart:    {"짝 명령어", "Counterpart"},
+		RelationGeneralization: {"일반형", "General form"},
+		RelationSpecialization: {"구체적인 형태", "Specific forms"},
+		RelationSeeAlso:        {"관련 명령어", "Related"},
+		RelationCategory:       {"같은 카테고리", "Same category"},
+	}
+	title, ok := titles[kind]
+	if !ok {
+		return kind
+	}
+	if lang == "en" {
+		return title[1]
+	}
+	return title[0]
+}
+
+func relationRank(kind string) int {
+	for i, k := range RelationKinds {
+		if k == kind {
+			return i
+		}
+	}
+	return len(RelationKinds)
+}
+
+// Neighbor is a command reached while walking the graph
+type Neighbor struct {
+	Command Command `json:"command"`
+	Kind    string  `json:"kind"`  // relation to Via
+	Depth   int     `json:"depth"` // number of links from the start command
+	Via     string  `json:"via"`   // command this one was reached from
+}
+
+// Graph links catalog commands by their relations
+type Graph struct {
+	commands   map[string]Command
+	order      map[string]int               // catalog position, for stable output
+	edges      map[string]map[string]string // from -> to -> kind
+	byCategory map[string][]string
+}
+
+// NewGraph builds the relation graph of a catalog.
+// Links to commands that are not in the catalog are ignored.
+func NewGraph(commands []Command) *Graph {
+	g := &Graph{
+		commands:   make(map[string]Command),
+		order:      make(map[string]int),
+		edges:      make(map[string]map[string]string),
+		byCategory: make(map[string][]string),
+	}
+
+	for i, cmd := range commands {
+		if _, exists := g.commands[cmd.Command]; exists {
+			continue
+		}
+		g.commands[cmd.Command] = cmd
+		g.order[cmd.Command] = i
+		g.byCategory[cmd.Category] = append(g.byCategory[cmd.Category], cmd.Command)
+	}
+
+	for _, cmd := range commands {
+		for _, kind := range DeclaredRelations {
+			for _, target := range cmd.Relations[kind] {
+				g.link(cmd.Command, target, kind)
+				g.link(target, cmd.Command, reverseRelation[kind])
+			}
+		}
+		for _, target := range cmd.SeeAlso {
+			g.link(cmd.Command, target, RelationSeeAlso)
+		}
+	}
+	return g
+}
+
+// link adds an edge, keeping the strongest kind when one already exists
+func (g *Graph) link(from, to, kind string) {
+	if from == to {
+		return
+	}
+	if _, ok := g.commands[from]; !ok {
+		return
+	}
+	if _, ok := g.commands[to]; !ok {
+		return
+	}
+	if g.edges[from] == nil {
+		g.edges[from] = make(map[string]string)
+	}
+	if existing, ok := g.edges[from][to]; ok && relationRank(existing) <= relationRank(kind) {
+		return
+	}
+	g.edges[from][to] = kind
+}
+
+// Has reports whether the command is in the graph
+func (g *Graph) Has(command string) bool {
+	_, ok := g.commands[command]
+	return ok
+}
+
+// Walk returns the commands reachable from start within depth links,
+// nearest first and then by kind and catalog order.
+// Explicit relations are followed at every depth; same-category neighbors
+// are only added for the start command, since following them would pull in
+// whole categories.
+func (g *Graph) Walk(start string, depth int) []Neighbor {
+	if !g.Has(start) || depth < 1 {
+		return nil
+	}
+
+	visited := map[string]bool{start: true}
+	frontier := []string{start}
+	var result []Neighbor
+
+	for d := 1; d <= depth && len(frontier) > 0; d++ {
+		var level []Neighbor
+		for _, from := range frontier {
+			for to, kind := range g.edges[from] {
+				if !visited[to] {
+					visited[to] = true
+					level = append(level, Neighbor{Command: g.commands[to], Kind: kind, Depth: d, Via: from})
+				}
+			}
+		}
+		if d == 1 {
+			for _, to := range g.byCategory[g.commands[start].Category] {
+				if !visited[to] {
+					visited[to] = true
+					level = append(level, Neighbor{Command: g.commands[to], Kind: RelationCategory, Depth: d, Via: start})
+				}
+			}
+		}
+
+		sort.Slice(level, func(i, j int) bool {
+			if ri, rj := relationRank(level[i].Kind), relationRank(level[j].Kind); ri != rj {
+				return ri < rj
+			}
+			return g.order[level[i].Command.Command] < g.order[level[j].Command.Command]
+		})
+
+		frontier = frontier[:0]
+		for _, n := range level {
+			if n.Kind != RelationCategory {
+				frontier = append(frontier, n.Command.Command)
+			}
+		}
+		result = append(result, level...)
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package catalog
+
+import "testing"
+
+func graphFixture() []Command {
+	return []Command{
+		{Command: "dd", Category: "delete", SeeAlso: []string{"p"}, Relations: map[string][]string{RelationGeneralization: {"d{motion}"}}},
+		{Command: "d{motion}", Category: "delete"},
+		{Command: "dw", Category: "delete", Relations: map[string][]string{RelationGeneralization: {"d{motion}"}}},
+		{Command: "x", Category: "delete"},
+		{Command: "p", Category: "paste", Relations: map[string][]string{RelationCounterpart: {"P"}}},
+		{Command: "P", Category: "paste"},
+		{Command: "u", Category: "edit", Relations: map[string][]string{RelationInverse: {"Ctrl+r", "missing"}}},
+		{Command: "Ctrl+r", Category: "edit"},
+	}
+}
+
+func TestGraphWalk(t *testing.T) {
+	g := NewGraph(graphFixture())
+
+	got := g.Walk("dd", 2)
+	want := []struct {
+		command, kind string
+		depth         int
+	}{
+		{"d{motion}", RelationGeneralization, 1},
+		{"p", RelationSeeAlso, 1},
+		{"dw", RelationCategory, 1},
+		{"x", RelationCategory, 1},
+		{"P", RelationCounterpart, 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Walk(dd, 2) = %+v", got)
+	}
+	for i, w := range want {
+		if got[i].Command.Command != w.command || got[i].Kind != w.kind || got[i].Depth != w.depth {
+			t.Errorf("neighbor %d = %s/%s/%d, want %s/%s/%d", i, got[i].Command.Command, got[i].Kind, got[i].Depth, w.command, w.kind, w.depth)
+		}
+	}
+}
+
+func TestGraphReverseEdges(t *testing.T) {
+	g := NewGraph(graphFixture())
+
+	// Declared on u only, but walking from Ctrl+r still finds its inverse
+	if got := g.Walk("Ctrl+r", 1); len(got) == 0 || got[0].Command.Command != "u" || got[0].Kind != RelationInverse {
+		t.Errorf("Walk(Ctrl+r, 1) = %+v", got)
+	}
+
+	// Generalization is seen as specialization from the general form
+	for _, n := range g.Walk("d{motion}", 1) {
+		if (n.Command.Command == "dd" || n.Command.Command == "dw") && n.Kind != RelationSpecialization {
+			t.Errorf("%s reached as %s, want %s", n.Command.Command, n.Kind, RelationSpecialization)
+		}
+	}
+
+	if g.Walk("missing", 1) != nil {
+		t.Error("walk from an unknown command returned neighbors")
+	}
+}
+
+func TestRelationTitle(t *testing.T) {
+	if got := RelationTitle(RelationInverse, "ko"); got != "반대 동작" {
+		t.Errorf("RelationTitle(inverse, ko) = %q", got)
+	}
+	if got := RelationTitle(RelationInverse, "en"); got != "Inverse" {
+		t.Errorf("RelationTitle(inverse, en) = %q", got)
+	}
+	if got := RelationTitle("unknown", "en"); got != "unknown" {
+		t.Errorf("RelationTitle(unknown, en) = %q, want the kind itself", got)
+	}
+}
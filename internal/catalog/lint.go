@@ -0,0 +1,433 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Issue severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a single problem found in a catalog file
+type Issue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`              // 1-based line of the entry (or of the file problem)
+	Index    int    `json:"index"`             // 0-based entry index, -1 for file-level problems
+	Command  string `json:"command,omitempty"` // command of the entry, when known
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the issue as "file:line: severity: [#index command] message"
+func (i Issue) String() string {
+	location := fmt.Sprintf("%s:%d", i.File, i.Line)
+	if i.Index < 0 {
+		return fmt.Sprintf("%s: %s: %s", location, i.Severity, i.Message)
+	}
+	entry := fmt.Sprintf("#%d", i.Index)
+	if i.Command != "" {
+		entry += " " + i.Command
+	}
+	if i.Field != "" {
+		entry += " (" + i.Field + ")"
+	}
+	return fmt.Sprintf("%s: %s: [%s] %s", location, i.Severity, entry, i.Message)
+}
+
+// HasErrors reports whether any issue is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderMarkers start the variable part of a command such as "/pattern" or "d{motion}".
+// The literal part before them is what an example is expected to mention.
+var placeholderMarkers = []string{"{", "pattern", "old", "new"}
+
+// LintFile validates a catalog file against the schema.
+// "see_also" references may point at any command of the configured catalog.
+func LintFile(path string) ([]Issue, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s 파일을 읽을 수 없습니다: %v", path, err)
+	}
+
+	var known []Command
+	if result, err := LoadAll(); err == nil {
+		known = result.Commands
+	}
+	return LintWith(path, data, known), nil
+}
+
+// LintAll validates every configured built-in source and pack file
+func LintAll() ([]Issue, error) {
+	files := make([]string, 0, len(sources))
+	for _, source := range sources {
+		files = append(files, resolve(source))
+	}
+	packs, err := packFiles()
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, packs...)
+
+	var issues []Issue
+	for _, file := range files {
+		fileIssues, err := LintFile(file)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, fileIssues...)
+	}
+	return issues, nil
+}
+
+// Lint validates catalog file contents; name is used in issue locations
+func Lint(name string, data []byte) []Issue {
+	return LintWith(name, data, nil)
+}
+
+// LintWith validates catalog file contents like Lint, also accepting
+// "see_also" references to the known commands of other files
+func LintWith(name string, data []byte, known []Command) []Issue {
+	fileIssue := func(line int, message string) []Issue {
+		return []Issue{{File: name, Line: line, Index: -1, Severity: SeverityError, Message: message}}
+	}
+
+	// Syntax errors are reported with the line they occur on
+	if !json.Valid(data) {
+		err := json.Unmarshal(data, new(interface{}))
+		line := 1
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line = lineAt(data, int(syntaxErr.Offset))
+		}
+		return fileIssue(line, fmt.Sprintf("JSON 문법 오류: %v", err))
+	}
+
+	entries, header, err := splitEntries(data)
+	if err != nil {
+		return fileIssue(1, err.Error())
+	}
+
+	var issues []Issue
+	if header != nil {
+		if header.SchemaVersion < 1 || header.SchemaVersion > SchemaVersion {
+			issues = append(issues, fileIssue(1, fmt.Sprintf("지원하지 않는 schema_version입니다: %d (지원: 1-%d)", header.SchemaVersion, SchemaVersion))...)
+		}
+	}
+
+	categories := make(map[string]bool)
+	for _, c := range KnownCategories {
+		categories[c] = true
+	}
+	if header != nil {
+		for _, c := range header.Categories {
+			categories[c] = true
+		}
+	}
+
+	seen := make(map[string]int) // command -> first index
+	var commands []Command
+	var positions []rawEntry // index and line of each decoded command
+
+	for i, entry := range entries {
+		add := func(field, severity, message string, cmd string) {
+			issues = append(issues, Issue{
+				File: name, Line: entry.line, Index: i, Command: cmd,
+				Field: field, Severity: severity, Message: message,
+			})
+		}
+
+		// Strict decoding catches misspelled field names
+		var cmd Command
+		dec := json.NewDecoder(bytes.NewReader(entry.raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cmd); err != nil {
+			add("", SeverityError, fmt.Sprintf("항목을 해석할 수 없습니다: %v", err), "")
+			continue
+		}
+
+		for _, field := range RequiredFields {
+			value := fieldValue(cmd, field)
+			if strings.TrimSpace(value) == "" {
+				add(field, SeverityError, "값이 비어 있습니다", cmd.Command)
+			} else if value != strings.TrimSpace(value) {
+				add(field, SeverityWarning, "앞뒤 공백이 있습니다", cmd.Command)
+			}
+		}
+
+		if cmd.Category != "" && !categories[cmd.Category] {
+			add("category", SeverityWarning,
+				fmt.Sprintf("알 수 없는 카테고리입니다: %s (알려진 카테고리: %s, 팩은 \"categories\"로 선언 가능)", cmd.Category, strings.Join(KnownCategories, ", ")),
+				cmd.Command)
+		}
+
+		if first, dup := seen[cmd.Command]; dup && cmd.Command != "" {
+			add("command", SeverityError, fmt.Sprintf("#%d 항목과 명령어가 중복됩니다", first), cmd.Command)
+		} else {
+			seen[cmd.Command] = i
+		}
+
+		if stem := literalStem(cmd.Command); stem != "" && cmd.Example != "" && !strings.Contains(cmd.Example, stem) {
+			add("example", SeverityWarning, fmt.Sprintf("예제에 명령어(%s)가 나오지 않습니다", stem), cmd.Command)
+		}
+
+		for _, mode := range cmd.Modes {
+			if NormalizeMode(mode) != mode {
+				add("modes", SeverityError, fmt.Sprintf("알 수 없는 모드입니다: %s (사용 가능: %s)", mode, strings.Join(Modes, ", ")), cmd.Command)
+			}
+		}
+
+		if a := cmd.Availability; a != nil {
+			for _, v := range []struct{ field, version string }{{"availability.vim", a.Vim}, {"availability.neovim", a.Neovim}} {
+				if v.version != "" && !ValidVersion(v.version) {
+					add(v.field, SeverityError, fmt.Sprintf("버전 형식이 잘못되었습니다: %s (예: 7.4, %s)", v.version, AllVersions), cmd.Command)
+				}
+			}
+		}
+
+		commands = append(commands, cmd)
+		positions = append(positions, rawEntry{index: i, line: entry.line})
+	}
+
+	issues = append(issues, overlapIssues(name, commands, positions)...)
+	issues = append(issues, referenceIssues(name, commands, positions, known)...)
+
+	sort.SliceStable(issues, func(a, b int) bool {
+		return issues[a].Line < issues[b].Line
+	})
+	return issues
+}
+
+// overlapIssues warns about commands that are another command followed by a bare
+// argument word, such as ":help" and ":help command". Arguments should be written
+// as a {placeholder} so it is clear the entry describes a form, not a literal.
+// A literal argument that the example actually uses (":Git blame") is a real
+// subcommand and is not reported.
+func overlapIssues(name string, commands []Command, positions []rawEntry) []Issue {
+	var issues []Issue
+	for j, b := range commands {
+		if strings.Contains(b.Example, b.Command) {
+			continue
+		}
+		for i, a := range commands {
+			if i == j || a.Command == "" || !strings.HasPrefix(b.Command, a.Command+" ") {
+				continue
+			}
+			rest := strings.TrimSpace(strings.TrimPrefix(b.Command, a.Command))
+			if strings.HasPrefix(rest, "{") && strings.HasSuffix(rest, "}") {
+				continue
+			}
+			issues = append(issues, Issue{
+				File: name, Line: positions[j].line, Index: positions[j].index, Command: b.Command, Field: "command",
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s 명령어와 겹칩니다 - 인수는 {이름} 형태로 표기하세요 (예: %s {%s})", a.Command, a.Command, rest),
+			})
+			break
+		}
+	}
+	return issues
+}
+
+// referenceIssues warns about "see_also" and "relations" links to commands that
+// do not exist, unknown relation kinds and aliases that are themselves commands of the file
+func referenceIssues(name string, commands []Command, positions []rawEntry, known []Command) []Issue {
+	local := make(map[string]bool)
+	for _, cmd := range commands {
+		local[cmd.Command] = true
+	}
+	exists := make(map[string]bool)
+	for _, cmd := range known {
+		exists[cmd.Command] = true
+	}
+
+	var issues []Issue
+	for i, cmd := range commands {
+		add := func(field, message string) {
+			issues = append(issues, Issue{
+				File: name, Line: positions[i].line, Index: positions[i].index, Command: cmd.Command,
+				Field: field, Severity: SeverityWarning, Message: message,
+			})
+		}
+		checkRef := func(field, ref string) {
+			if ref == cmd.Command {
+				add(field, "자기 자신을 가리킵니다")
+			} else if !local[ref] && !exists[ref] {
+				add(field, fmt.Sprintf("카탈로그에 없는 명령어입니다: %s", ref))
+			}
+		}
+		for _, ref := range cmd.SeeAlso {
+			checkRef("see_also", ref)
+		}
+
+		kinds := make([]string, 0, len(cmd.Relations))
+		for kind := range cmd.Relations {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			if _, ok := reverseRelation[kind]; !ok {
+				add("relations", fmt.Sprintf("알 수 없는 관계입니다: %s (사용 가능: %s)", kind, strings.Join(DeclaredRelations, ", ")))
+				continue
+			}
+			for _, ref := range cmd.Relations[kind] {
+				checkRef("relations."+kind, ref)
+			}
+		}
+		for _, alias := range cmd.Aliases {
+			if local[alias] {
+				add("aliases", fmt.Sprintf("별칭 %s가 다른 명령어와 같습니다", alias))
+			}
+		}
+	}
+	return issues
+}
+
+// literalStem returns the part of a command before its first placeholder
+func literalStem(command string) string {
+	end := len(command)
+	for _, marker := range placeholderMarkers {
+		if i := strings.Index(command, marker); i >= 0 && i < end {
+			end = i
+		}
+	}
+	return strings.TrimSpace(command[:end])
+}
+
+// fieldValue returns a command field by its JSON name
+func fieldValue(cmd Command, field string) string {
+	v := reflect.ValueOf(cmd)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == field {
+			return v.Field(i).String()
+		}
+	}
+	return ""
+}
+
+// rawEntry is an undecoded command entry with its position in the file
+type rawEntry struct {
+	raw   json.RawMessage
+	index int
+	line  int
+}
+
+// splitEntries walks the file with a token decoder so every command entry
+// keeps the line it starts on. It returns the document header for the
+// versioned form and nil for a bare array.
+func splitEntries(data []byte) ([]rawEntry, *Document, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		entries, err := readArray(dec, data)
+		return entries, nil, err
+
+	case json.Delim('{'):
+		header := &Document{}
+		var entries []rawEntry
+		foundCommands := false
+
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, nil, err
+			}
+			key, _ := keyTok.(string)
+
+			switch key {
+			case "commands":
+				open, err := dec.Token()
+				if err != nil {
+					return nil, nil, err
+				}
+				if open != json.Delim('[') {
+					return nil, nil, fmt.Errorf("commands는 배열이어야 합니다")
+				}
+				if entries, err = readArray(dec, data); err != nil {
+					return nil, nil, err
+				}
+				foundCommands = true
+			case "schema_version":
+				if err := dec.Decode(&header.SchemaVersion); err != nil {
+					return nil, nil, fmt.Errorf("schema_version은 정수여야 합니다")
+				}
+			case "name":
+				if err := dec.Decode(&header.Name); err != nil {
+					return nil, nil, fmt.Errorf("name은 문자열이어야 합니다")
+				}
+			case "categories":
+				if err := dec.Decode(&header.Categories); err != nil {
+					return nil, nil, fmt.Errorf("categories는 문자열 배열이어야 합니다")
+				}
+			default:
+				return nil, nil, fmt.Errorf("알 수 없는 최상위 필드입니다: %s", key)
+			}
+		}
+
+		if !foundCommands {
+			return nil, nil, fmt.Errorf("commands 필드가 없습니다")
+		}
+		return entries, header, nil
+
+	default:
+		return nil, nil, fmt.Errorf("카탈로그 파일은 배열이나 객체여야 합니다")
+	}
+}
+
+// readArray reads the elements of an array whose opening bracket was consumed
+func readArray(dec *json.Decoder, data []byte) ([]rawEntry, error) {
+	var entries []rawEntry
+	for dec.More() {
+		start := skipSeparators(data, int(dec.InputOffset()))
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		entries = append(entries, rawEntry{raw: raw, index: len(entries), line: lineAt(data, start)})
+	}
+	if _, err := dec.Token(); err != nil { // closing bracket
+		return nil, err
+	}
+	return entries, nil
+}
+
+// skipSeparators advances past whitespace and commas to the start of the next value
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineAt converts a byte offset to a 1-based line number
+func lineAt(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestBuiltinCatalogIsClean keeps the shipped catalog and example packs lint-free
+func TestBuiltinCatalogIsClean(t *testing.T) {
+	builtin := filepath.Join("..", "..", "data", "commands.json")
+	SetSources([]string{builtin})
+	defer SetSources(nil)
+
+	files := []string{builtin}
+	packs, err := filepath.Glob(filepath.Join("..", "..", "examples", "packs", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, packs...)
+
+	for _, file := range files {
+		issues, err := LintFile(file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, issue := range issues {
+			t.Errorf("%s", issue)
+		}
+
+		if _, err := LoadFile(file); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
+
+func TestLintReportsLocations(t *testing.T) {
+	issues, err := LintFile(filepath.Join("testdata", "bad.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		line     int
+		index    int
+		field    string
+		severity string
+	}{
+		{5, 0, "description", SeverityError},
+		{12, 1, "command", SeverityError},
+		{19, 2, "category", SeverityWarning},
+		{19, 2, "example", SeverityWarning},
+		{26, 3, "", SeverityError},
+		{34, 4, "example", SeverityWarning},
+		{34, 4, "command", SeverityWarning},
+	}
+
+	if len(issues) != len(want) {
+		for _, issue := range issues {
+			t.Log(issue)
+		}
+		t.Fatalf("got %d issues, want %d", len(issues), len(want))
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Line != w.line || got.Index != w.index || got.Field != w.field || got.Severity != w.severity {
+			t.Errorf("issue %d = %s, want line %d #%d %s %s", i, got, w.line, w.index, w.field, w.severity)
+		}
+	}
+	if !HasErrors(issues) {
+		t.Error("HasErrors = false, want true")
+	}
+}
+
+func TestLintFileErrors(t *testing.T) {
+	tests := []struct {
+		file string
+		line int
+	}{
+		{"syntax.json", 3},
+		{"version.json", 1},
+	}
+
+	for _, tt := range tests {
+		issues, err := LintFile(filepath.Join("testdata", tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || issues[0].Index != -1 || issues[0].Line != tt.line || issues[0].Severity != SeverityError {
+			t.Errorf("%s: got %v, want one file error on line %d", tt.file, issues, tt.line)
+		}
+	}
+}
+
+func TestLintBareArray(t *testing.T) {
+	data := []byte(`[
+  {"keyword": "save", "command": ":w", "description": "저장", "example": "':w'", "category": "file"},
+  {"keyword": "git", "command": ":Git", "description": "상태", "example": "':Git'", "category": "git"}
+]`)
+
+	issues := Lint("inline.json", data)
+	if len(issues) != 1 || issues[0].Line != 3 || issues[0].Field != "category" {
+		t.Errorf("got %v, want one category warning on line 3", issues)
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{"schema_version": 1, "name": "team", "categories": ["git"], "commands": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Name != "team" || len(doc.Categories) != 1 {
+		t.Errorf("got %+v", doc)
+	}
+
+	if _, err := ParseDocument([]byte(`{"schema_version": 0, "commands": []}`)); err == nil {
+		t.Error("schema_version 0 accepted")
+	}
+}
+
+func TestLintMetadata(t *testing.T) {
+	issues, err := LintFile(filepath.Join("testdata", "meta.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"modes":               SeverityError,
+		"availability.neovim": SeverityError,
+		"see_also":            SeverityWarning,
+		"aliases":             SeverityWarning,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %v, want %d issues", issues, len(want))
+	}
+	for _, issue := range issues {
+		if issue.Line != 4 || want[issue.Field] != issue.Severity {
+			t.Errorf("unexpected issue %s", issue)
+		}
+	}
+}
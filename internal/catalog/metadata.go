@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Editor modes a command applies in
+const (
+	ModeNormal      = "normal"
+	ModeInsert      = "insert"
+	ModeVisual      = "visual"
+	ModeCommandLine = "command-line"
+)
+
+// Modes lists the known modes in display order
+var Modes = []string{ModeNormal, ModeInsert, ModeVisual, ModeCommandLine}
+
+// modeAliases maps short mode names accepted in filters to Modes
+var modeAliases = map[string]string{
+	"n": ModeNormal, "normal": ModeNormal,
+	"i": ModeInsert, "insert": ModeInsert,
+	"v": ModeVisual, "x": ModeVisual, "visual": ModeVisual,
+	"c": ModeCommandLine, "cmdline": ModeCommandLine, "command-line": ModeCommandLine, "ex": ModeCommandLine,
+}
+
+// NormalizeMode returns the canonical mode name, or "" for an unknown mode
+func NormalizeMode(name string) string {
+	return modeAliases[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// AllVersions marks a command that every release of an editor supports
+const AllVersions = "all"
+
+// Editors whose availability is tracked
+const (
+	EditorPOSIX  = "posix"
+	EditorVim    = "vim"
+	EditorNeovim = "neovim"
+)
+
+// Availability records which editors implement a command.
+// Vim and Neovim hold the first version with the command ("7.0", "0.5"),
+// AllVersions, or "" when the editor does not have it.
+type Availability struct {
+	POSIX  bool   `json:"posix"`            // POSIX vi에 있는지 여부
+	Vim    string `json:"vim,omitempty"`    // Vim 지원 시작 버전
+	Neovim string `json:"neovim,omitempty"` // Neovim 지원 시작 버전
+}
+
+// HasMode reports whether the command applies in mode (a name accepted by NormalizeMode)
+func (c Command) HasMode(mode string) bool {
+	mode = NormalizeMode(mode)
+	for _, m := range c.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailableIn reports whether the command exists in editor.
+// For Vim and Neovim a non-empty version also requires the command to exist in that release.
+// Commands without availability data are treated as unknown and never match.
+func (c Command) AvailableIn(editor, version string) bool {
+	if c.Availability == nil {
+		return false
+	}
+
+	var since string
+	switch strings.ToLower(editor) {
+	case EditorPOSIX, "vi":
+		return c.Availability.POSIX
+	case EditorVim:
+		since = c.Availability.Vim
+	case EditorNeovim, "nvim":
+		since = c.Availability.Neovim
+	default:
+		return false
+	}
+
+	if since == "" {
+		return false
+	}
+	if since == AllVersions || version == "" {
+		return true
+	}
+	return CompareVersions(since, version) <= 0
+}
+
+// MatchesAlias reports whether name is one of the command's aliases
+func (c Command) MatchesAlias(name string) bool {
+	for _, alias := range c.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareVersions compares dotted version numbers such as "7.4" and "8.2.1978".
+// Missing components count as zero.
+func CompareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// ValidVersion reports whether v is AllVersions or a dotted version number
+func ValidVersion(v string) bool {
+	if v == AllVersions {
+		return true
+	}
+	for _, part := range strings.Split(v, ".") {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatAvailability describes where a command exists, e.g. "POSIX vi, Vim 7.0+, Neovim"
+func FormatAvailability(a *Availability, lang string) string {
+	if a == nil {
+		if lang == "en" {
+			return "unknown"
+		}
+		return "알 수 없음"
+	}
+
+	var parts []string
+	if a.POSIX {
+		parts = append(parts, "POSIX vi")
+	}
+	for _, e := range []struct{ name, since string }{{"Vim", a.Vim}, {"Neovim", a.Neovim}} {
+		switch e.since {
+		case "":
+		case AllVersions:
+			parts = append(parts, e.name)
+		default:
+			parts = append(parts, fmt.Sprintf("%s %s+", e.name, e.since))
+		}
+	}
+
+	if len(parts) == 0 {
+		if lang == "en" {
+			return "none"
+		}
+		return "없음"
+	}
+	return strings.Join(parts, ", ")
+}
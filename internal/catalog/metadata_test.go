@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"7.4", "8.0", -1},
+		{"8.2.1978", "8.2", 1},
+		{"0.5", "0.5.0", 0},
+		{"9.0", "10.0", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAvailableIn(t *testing.T) {
+	cmd := Command{Availability: &Availability{POSIX: false, Vim: "8.0", Neovim: AllVersions}}
+
+	tests := []struct {
+		editor, version string
+		want            bool
+	}{
+		{EditorPOSIX, "", false},
+		{EditorVim, "", true},
+		{EditorVim, "7.4", false},
+		{EditorVim, "8.2", true},
+		{"nvim", "0.1", true},
+	}
+	for _, tt := range tests {
+		if got := cmd.AvailableIn(tt.editor, tt.version); got != tt.want {
+			t.Errorf("AvailableIn(%q, %q) = %v, want %v", tt.editor, tt.version, got, tt.want)
+		}
+	}
+
+	if (Command{}).AvailableIn(EditorVim, "") {
+		t.Error("command without availability data reported as available")
+	}
+}
+
+func TestHasMode(t *testing.T) {
+	cmd := Command{Modes: []string{ModeNormal, ModeVisual}}
+	if !cmd.HasMode("v") || !cmd.HasMode("normal") || cmd.HasMode("insert") {
+		t.Errorf("HasMode mismatch for %v", cmd.Modes)
+	}
+}
+
+// TestBuiltinAvailability keeps Vim extensions from being labelled POSIX vi
+func TestBuiltinAvailability(t *testing.T) {
+	commands, err := LoadFile(filepath.Join("..", "..", "data", "commands.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]Command)
+	for _, cmd := range commands {
+		byName[cmd.Command] = cmd
+	}
+
+	vimOnly := []string{"Ctrl+r", "v", "V", "gg", ":help", ":help {subject}"}
+	posix := []string{"u", "dd", "yy", "Y", "p", ":wq", ":x", "G", "/pattern", ":%s/old/new/g"}
+	for _, name := range vimOnly {
+		if cmd, ok := byName[name]; !ok || cmd.AvailableIn(EditorPOSIX, "") || !cmd.AvailableIn(EditorVim, "") {
+			t.Errorf("%s should be a Vim extension, not POSIX vi", name)
+		}
+	}
+	for _, name := range posix {
+		if cmd, ok := byName[name]; !ok || !cmd.AvailableIn(EditorPOSIX, "") {
+			t.Errorf("%s should be available in POSIX vi", name)
+		}
+	}
+	for _, cmd := range commands {
+		if cmd.Availability == nil {
+			t.Errorf("%s has no availability data", cmd.Command)
+		}
+	}
+}
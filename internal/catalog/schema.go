@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the catalog file schema version understood by this build.
+//
+// A catalog file is either a bare JSON array of commands (implicitly version 1,
+// the original commands.json layout) or a versioned document:
+//
+//	{
+//	  "schema_version": 1,
+//	  "name": "fugitive",
+//	  "categories": ["git"],
+//	  "commands": [ ... ]
+//	}
+//
+// "categories" declares extra categories used by a pack in addition to KnownCategories.
+const SchemaVersion = 1
+
+// KnownCategories are the categories used by the built-in catalog
+var KnownCategories = []string{"file", "mode", "edit", "copy", "paste", "delete", "navigation", "search", "help"}
+
+// RequiredFields lists the command fields that must not be empty
+var RequiredFields = []string{"keyword", "command", "description", "example", "category"}
+
+// Document is a parsed catalog file
+type Document struct {
+	SchemaVersion int       `json:"schema_version"`
+	Name          string    `json:"name,omitempty"`
+	Categories    []string  `json:"categories,omitempty"`
+	Commands      []Command `json:"commands"`
+}
+
+// ParseDocument parses a catalog file in either schema form
+func ParseDocument(data []byte) (*Document, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var commands []Command
+		if err := json.Unmarshal(trimmed, &commands); err != nil {
+			return nil, err
+		}
+		return &Document{SchemaVersion: 1, Commands: commands}, nil
+	}
+
+	var doc Document
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return nil, err
+	}
+	if doc.SchemaVersion < 1 || doc.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("지원하지 않는 schema_version입니다: %d (지원: 1-%d)", doc.SchemaVersion, SchemaVersion)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,256 @@
+// Package cheatsheet renders compact, category-grouped command sheets
+// in terminal, Markdown, HTML and plain-text layouts.
+package cheatsheet
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Output formats accepted by Render
+const (
+	FormatTerminal = "terminal"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatText     = "text"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatTerminal, FormatMarkdown, FormatHTML, FormatText}
+
+// Entry is a single line of a cheat sheet
+type Entry struct {
+	Command     string
+	Description string
+	Category    string
+	Note        string
+}
+
+// Section groups entries under a heading
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Sheet is a titled list of sections
+type Sheet struct {
+	Title       string
+	Description string
+	Sections    []Section
+}
+
+// categoryOrder is the display order for well-known categories,
+// matching the order used by the quick reference
+var categoryOrder = []string{"file", "mode", "edit", "copy", "paste", "delete", "navigation", "search", "help"}
+
+// categoryTitles holds the Korean and English section titles for well-known categories
+var categoryTitles = map[string][2]string{
+	"file":       {"📁 파일 작업", "📁 File Operations"},
+	"mode":       {"🎯 모드 전환", "🎯 Mode Switching"},
+	"edit":       {"✂️ 편집", "✂️ Edit Operations"},
+	"copy":       {"📋 복사", "📋 Copy"},
+	"paste":      {"📌 붙여넣기", "📌 Paste"},
+	"delete":     {"🗑 삭제", "🗑 Delete"},
+	"navigation": {"🧭 이동", "🧭 Navigation"},
+	"search":     {"🔍 검색 및 바꾸기", "🔍 Search & Replace"},
+	"help":       {"❓ 도움말", "❓ Help"},
+}
+
+// CategoryTitle returns the localized section title for a category
+func CategoryTitle(category, lang string) string {
+	if titles, ok := categoryTitles[category]; ok {
+		if lang == "en" {
+			return titles[1]
+		}
+		return titles[0]
+	}
+	if category == "" {
+		if lang == "en" {
+			return "Other"
+		}
+		return "기타"
+	}
+	return category
+}
+
+// Group builds a sheet by grouping entries by category.
+// Well-known categories come first in a fixed order, the rest alphabetically;
+// entries keep their input order within a section.
+func Group(title string, entries []Entry, lang string) Sheet {
+	byCategory := make(map[string][]Entry)
+	var categories []string
+	for _, entry := range entries {
+		if _, seen := byCategory[entry.Category]; !seen {
+			categories = append(categories, entry.Category)
+		}
+		byCategory[entry.Category] = append(byCategory[entry.Category], entry)
+	}
+
+	rank := make(map[string]int)
+	for i, category := range categoryOrder {
+		rank[category] = i
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		ri, okI := rank[categories[i]]
+		rj, okJ := rank[categories[j]]
+		switch {
+		case okI && okJ:
+			return ri < rj
+		case okI != okJ:
+			return okI
+		default:
+			return categories[i] < categories[j]
+		}
+	})
+
+	sheet := Sheet{Title: title}
+	for _, category := range categories {
+		sheet.Sections = append(sheet.Sections, Section{
+			Title:   CategoryTitle(category, lang),
+			Entries: byCategory[category],
+		})
+	}
+	return sheet
+}
+
+// Render renders the sheet in the given format
+func Render(sheet Sheet, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case FormatTerminal, "":
+		return renderTerminal(sheet), nil
+	case FormatMarkdown, "md":
+		return renderMarkdown(sheet), nil
+	case FormatHTML:
+		return renderHTML(sheet), nil
+	case FormatText, "txt":
+		return renderText(sheet), nil
+	default:
+		return "", fmt.Errorf("지원하지 않는 형식입니다: %s (사용 가능: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// commandWidth returns the column width needed for the widest command,
+// never narrower than the 10 columns used by the quick reference
+func commandWidth(sheet Sheet) int {
+	width := 10
+	for _, section := range sheet.Sections {
+		for _, entry := range section.Entries {
+			if n := utf8.RuneCountInString(entry.Command); n > width {
+				width = n
+			}
+		}
+	}
+	return width
+}
+
+// describe joins the description and the optional note
+func describe(entry Entry) string {
+	if entry.Note == "" {
+		return entry.Description
+	}
+	return fmt.Sprintf("%s (%s)", entry.Description, entry.Note)
+}
+
+func renderTerminal(sheet Sheet) string {
+	const bold, cyan, reset = "\033[1m", "\033[36m", "\033[0m"
+
+	var output strings.Builder
+	width := commandWidth(sheet)
+
+	output.WriteString(bold + sheet.Title + reset + "\n")
+	if sheet.Description != "" {
+		output.WriteString(sheet.Description + "\n")
+	}
+
+	for _, section := range sheet.Sections {
+		output.WriteString("\n" + bold + section.Title + reset + "\n")
+		for _, entry := range section.Entries {
+			output.WriteString(fmt.Sprintf("  %s%-*s%s %s\n", cyan, width, entry.Command, reset, describe(entry)))
+		}
+	}
+
+	return output.String()
+}
+
+func renderText(sheet Sheet) string {
+	var output strings.Builder
+	width := commandWidth(sheet)
+
+	output.WriteString(sheet.Title + "\n")
+	output.WriteString(strings.Repeat("=", utf8.RuneCountInString(sheet.Title)) + "\n")
+	if sheet.Description != "" {
+		output.WriteString(sheet.Description + "\n")
+	}
+
+	for _, section := range sheet.Sections {
+		output.WriteString("\n" + section.Title + "\n")
+		for _, entry := range section.Entries {
+			output.WriteString(fmt.Sprintf("  %-*s %s\n", width, entry.Command, describe(entry)))
+		}
+	}
+
+	return output.String()
+}
+
+func renderMarkdown(sheet Sheet) string {
+	var output strings.Builder
+
+	output.WriteString("# " + sheet.Title + "\n")
+	if sheet.Description != "" {
+		output.WriteString("\n" + sheet.Description + "\n")
+	}
+
+	for _, section := range sheet.Sections {
+		output.WriteString("\n## " + section.Title + "\n\n")
+		output.WriteString("| Command | Description |\n")
+		output.WriteString("|---|---|\n")
+		for _, entry := range section.Entries {
+			command := strings.ReplaceAll(entry.Command, "|", `\|`)
+			description := strings.ReplaceAll(describe(entry), "|", `\|`)
+			output.WriteString(fmt.Sprintf("| `%s` | %s |\n", command, description))
+		}
+	}
+
+	return output.String()
+}
+
+func renderHTML(sheet Sheet) string {
+	var output strings.Builder
+
+	output.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	output.WriteString("<title>" + html.EscapeString(sheet.Title) + "</title>\n")
+	output.WriteString(`<style>
+body { font-family: sans-serif; margin: 1.5em; }
+h1 { font-size: 1.4em; margin-bottom: 0.2em; }
+.sections { columns: 2 18em; column-gap: 2em; }
+section { break-inside: avoid; margin-bottom: 1em; }
+h2 { font-size: 1.05em; border-bottom: 1px solid #999; }
+table { border-collapse: collapse; width: 100%; }
+td { padding: 0.1em 0.4em; vertical-align: top; }
+td.cmd { font-family: monospace; font-weight: bold; white-space: nowrap; }
+@media print { body { margin: 0; font-size: 10pt; } }
+</style>
+</head>
+<body>
+`)
+	output.WriteString("<h1>" + html.EscapeString(sheet.Title) + "</h1>\n")
+	if sheet.Description != "" {
+		output.WriteString("<p>" + html.EscapeString(sheet.Description) + "</p>\n")
+	}
+
+	output.WriteString("<div class=\"sections\">\n")
+	for _, section := range sheet.Sections {
+		output.WriteString("<section>\n<h2>" + html.EscapeString(section.Title) + "</h2>\n<table>\n")
+		for _, entry := range section.Entries {
+			output.WriteString(fmt.Sprintf("<tr><td class=\"cmd\">%s</td><td>%s</td></tr>\n",
+				html.EscapeString(entry.Command), html.EscapeString(describe(entry))))
+		}
+		output.WriteString("</table>\n</section>\n")
+	}
+	output.WriteString("</div>\n</body>\n</html>\n")
+
+	return output.String()
+}
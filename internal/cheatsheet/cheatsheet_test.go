@@ -0,0 +1,18 @@
+package cheatsheet
+
+import "testing"
+
+func TestCategoryTitle(t *testing.T) {
+	tests := []struct{ category, lang, want string }{
+		{"copy", "ko", "📋 복사"},
+		{"copy", "en", "📋 Copy"},
+		{"", "ko", "기타"},
+		{"", "en", "Other"},
+		{"git", "en", "git"},
+	}
+	for _, tt := range tests {
+		if got := CategoryTitle(tt.category, tt.lang); got != tt.want {
+			t.Errorf("CategoryTitle(%q, %q) = %q, want %q", tt.category, tt.lang, got, tt.want)
+		}
+	}
+}
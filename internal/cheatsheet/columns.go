@@ -0,0 +1,173 @@
+package cheatsheet
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// columnWidth is the preferred width of one column of a dense layout
+const columnWidth = 38
+
+// columnGap separates the columns of a dense layout
+const columnGap = "  "
+
+// Columns lays the sheet out for a terminal of the given width, flowing
+// sections into as many columns as fit. Sections are kept whole unless one
+// is taller than a column; long descriptions wrap within their column.
+// With ansi set, titles and commands are highlighted.
+func Columns(sheet Sheet, termWidth int, ansi bool) string {
+	const bold, cyan, reset = "\033[1m", "\033[36m", "\033[0m"
+	paint := func(s, code string) string {
+		if !ansi || s == "" {
+			return s
+		}
+		return code + s + reset
+	}
+
+	n := (termWidth + len(columnGap)) / (columnWidth + len(columnGap))
+	if n < 1 {
+		n = 1
+	}
+	colWidth := (termWidth - (n-1)*len(columnGap)) / n
+	if colWidth < 20 {
+		colWidth = 20
+	}
+
+	// Lay out every section as lines of exactly colWidth cells
+	var blocks [][]string
+	total := 0
+	for _, section := range sheet.Sections {
+		cmdWidth := 0
+		for _, entry := range section.Entries {
+			if w := textWidth(entry.Command); w > cmdWidth {
+				cmdWidth = w
+			}
+		}
+		if cmdWidth > colWidth/3 {
+			cmdWidth = colWidth / 3
+		}
+
+		block := []string{paint(pad(section.Title, colWidth), bold)}
+		for _, entry := range section.Entries {
+			command := entry.Command
+			descWidth := colWidth - cmdWidth - 1
+			lines := wrap(describe(entry), descWidth)
+			if textWidth(command) > cmdWidth {
+				// A long command gets a line of its own
+				block = append(block, paint(pad(command, colWidth), cyan))
+				command = ""
+			}
+			if len(lines) == 0 {
+				lines = []string{""}
+			}
+			for i, line := range lines {
+				if i > 0 {
+					command = ""
+				}
+				block = append(block, paint(pad(command, cmdWidth), cyan)+" "+pad(line, descWidth))
+			}
+		}
+		blocks = append(blocks, block)
+		total += len(block) + 1
+	}
+
+	// Fill the columns in order, starting a new one once the target height is reached
+	target := (total + n - 1) / n
+	columns := make([][]string, 1, n)
+	for _, block := range blocks {
+		last := len(columns) - 1
+		if len(columns[last]) > 0 && len(columns[last])+len(block) > target && len(columns) < n {
+			columns = append(columns, nil)
+			last++
+		}
+		if len(columns[last]) > 0 {
+			columns[last] = append(columns[last], strings.Repeat(" ", colWidth))
+		}
+		columns[last] = append(columns[last], block...)
+	}
+
+	height := 0
+	for _, column := range columns {
+		if len(column) > height {
+			height = len(column)
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString(paint(sheet.Title, bold) + "\n")
+	if sheet.Description != "" {
+		output.WriteString(sheet.Description + "\n")
+	}
+	output.WriteString("\n")
+	for row := 0; row < height; row++ {
+		var line strings.Builder
+		for i, column := range columns {
+			if i > 0 {
+				line.WriteString(columnGap)
+			}
+			if row < len(column) {
+				line.WriteString(column[row])
+			} else {
+				line.WriteString(strings.Repeat(" ", colWidth))
+			}
+		}
+		output.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return output.String()
+}
+
+// textWidth returns the number of terminal cells s occupies
+func textWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		switch {
+		case r < 0x20 || unicode.Is(unicode.Mn, r):
+		case width.LookupRune(r).Kind() == width.EastAsianWide, width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// pad truncates or pads s with spaces to exactly w cells; truncated text ends with "…"
+func pad(s string, w int) string {
+	if n := textWidth(s); n <= w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		rw := textWidth(string(r))
+		if n+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		n += rw
+	}
+	return b.String() + "…" + strings.Repeat(" ", w-1-n)
+}
+
+// wrap breaks s into lines of at most w cells at spaces; words wider than w are truncated
+func wrap(s string, w int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case textWidth(line)+1+textWidth(word) <= w:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
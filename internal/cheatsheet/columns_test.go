@@ -0,0 +1,44 @@
+package cheatsheet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	sheet := Group("vi", []Entry{
+		{Command: ":w", Description: "현재 파일을 저장합니다", Category: "file"},
+		{Command: ":q", Description: "종료합니다", Category: "file"},
+		{Command: "h", Description: "커서를 왼쪽으로 이동합니다", Category: "navigation"},
+		{Command: "j", Description: "커서를 아래로 이동합니다", Category: "navigation"},
+		{Command: "dd", Description: "현재 줄을 삭제합니다 그리고 이 설명은 한 단에 들어가지 않을 만큼 깁니다", Category: "delete"},
+	}, "en")
+
+	out := Columns(sheet, 80, false)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if lines[0] != "vi" {
+		t.Errorf("title line = %q", lines[0])
+	}
+	for _, line := range lines {
+		if w := textWidth(line); w > 80 {
+			t.Errorf("line is %d cells wide: %q", w, line)
+		}
+	}
+	// Two columns: the first section heading shares its line with another
+	if !strings.Contains(lines[2], "📁 File Operations") || strings.TrimSpace(strings.TrimPrefix(lines[2], "📁 File Operations")) == "" {
+		t.Errorf("sections are not laid out in columns:\n%s", out)
+	}
+	if strings.Contains(out, "\033[") {
+		t.Error("escape sequences without ansi")
+	}
+	if !strings.Contains(Columns(sheet, 80, true), "\033[36m") {
+		t.Error("commands are not highlighted with ansi")
+	}
+
+	// A narrow terminal gets a single column
+	for _, line := range strings.Split(Columns(sheet, 30, false), "\n") {
+		if textWidth(line) > 30 {
+			t.Errorf("narrow line is %d cells wide: %q", textWidth(line), line)
+		}
+	}
+}
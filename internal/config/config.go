@@ -0,0 +1,363 @@
+// Package config describes the documented configuration keys and validates their values.
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Value types used by the schema
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypeBool   = "bool"
+	TypeList   = "list"
+)
+
+// Key describes a single configuration key
+type Key struct {
+	Name          string
+	Type          string
+	Default       interface{}
+	Allowed       []string // allowed values for string keys; empty means any
+	DescriptionKO string
+	DescriptionEN string
+}
+
+// Description returns the localized description
+func (k Key) Description(lang string) string {
+	if lang == "en" {
+		return k.DescriptionEN
+	}
+	return k.DescriptionKO
+}
+
+// Keys is the configuration schema, in documentation order
+var Keys = []Key{
+	{
+		Name: "lang", Type: TypeString, Default: "ko", Allowed: []string{"ko", "en"},
+		DescriptionKO: "출력 언어",
+		DescriptionEN: "Output language",
+	},
+	{
+		Name: "output.format", Type: TypeString, Default: "text", Allowed: []string{"text", "json"},
+		DescriptionKO: "search/explain 출력 형식",
+		DescriptionEN: "Output format for search/explain",
+	},
+	{
+		Name: "color", Type: TypeString, Default: "auto", Allowed: []string{"auto", "always", "never"},
+		DescriptionKO: "색상 사용 (auto는 터미널이고 NO_COLOR가 없을 때만)",
+		DescriptionEN: "Use colors (auto: only on a terminal without NO_COLOR)",
+	},
+	{
+		Name: "search.limit", Type: TypeInt, Default: 0,
+		DescriptionKO: "검색 결과 최대 표시 개수 (0은 제한 없음)",
+		DescriptionEN: "Maximum number of search results shown (0 means no limit)",
+	},
+	{
+		Name: "related.depth", Type: TypeInt, Default: 2,
+		DescriptionKO: "'related' 명령어가 관계를 따라가는 기본 깊이",
+		DescriptionEN: "Default number of links followed by 'related'",
+	},
+	{
+		Name: "data.sources", Type: TypeList, Default: []string{},
+		DescriptionKO: "명령어 카탈로그 파일 목록 (비어 있으면 내장 data/commands.json)",
+		DescriptionEN: "Command catalog files (empty means the built-in data/commands.json)",
+	},
+	{
+		Name: "packs.dir", Type: TypeString, Default: "",
+		DescriptionKO: "사용자 명령어 팩 디렉토리 (비어 있으면 데이터 디렉토리의 packs)",
+		DescriptionEN: "User command pack directory (empty means packs in the data directory)",
+	},
+	{
+		Name: "packs.precedence", Type: TypeString, Default: "pack", Allowed: []string{"pack", "builtin"},
+		DescriptionKO: "팩과 내장 명령어가 겹칠 때 우선할 쪽",
+		DescriptionEN: "Which side wins when a pack redefines a built-in command",
+	},
+	{
+		Name: "favorites.path", Type: TypeString, Default: "",
+		DescriptionKO: "즐겨찾기 파일 경로 (비어 있으면 데이터 디렉토리의 favorites.json)",
+		DescriptionEN: "Favorites file (empty means favorites.json in the data directory)",
+	},
+	{
+		Name: "learn.level", Type: TypeString, Default: "beginner", Allowed: []string{"beginner", "intermediate"},
+		DescriptionKO: "'learn start'에서 레벨을 생략할 때의 기본 레벨",
+		DescriptionEN: "Level used by 'learn start' when no level is given",
+	},
+	{
+		Name: "learn.pause", Type: TypeBool, Default: true,
+		DescriptionKO: "강의 사이마다 Enter 입력을 기다릴지 여부",
+		DescriptionEN: "Wait for Enter between lessons",
+	},
+	{
+		Name: "keymap.layout", Type: TypeString, Default: "qwerty", Allowed: []string{"qwerty", "dvorak", "colemak", "korean"},
+		DescriptionKO: "'keymap'과 키보드 치트시트의 자판 배열 (korean은 한글 2벌식)",
+		DescriptionEN: "Keyboard layout of 'keymap' and the keyboard cheat sheet (korean is the Hangul 2-set)",
+	},
+	{
+		Name: "serve.addr", Type: TypeString, Default: "127.0.0.1:8420",
+		DescriptionKO: "'serve'가 기다리는 주소 (host:port)",
+		DescriptionEN: "Address 'serve' listens on (host:port)",
+	},
+	{
+		Name: "serve.cors_origins", Type: TypeList, Default: []string{},
+		DescriptionKO: "'serve' API를 호출할 수 있는 브라우저 출처 (*는 모두 허용)",
+		DescriptionEN: "Browser origins allowed to call the 'serve' API (* allows any)",
+	},
+	{
+		Name: "paths.data", Type: TypeString, Default: "",
+		DescriptionKO: "데이터 디렉토리 (비어 있으면 $XDG_DATA_HOME/vi-assistant)",
+		DescriptionEN: "Data directory (empty means $XDG_DATA_HOME/vi-assistant)",
+	},
+	{
+		Name: "paths.state", Type: TypeString, Default: "",
+		DescriptionKO: "상태 디렉토리 (비어 있으면 $XDG_STATE_HOME/vi-assistant)",
+		DescriptionEN: "State directory (empty means $XDG_STATE_HOME/vi-assistant)",
+	},
+}
+
+// Lookup returns the schema entry for a key
+func Lookup(name string) (Key, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// UnknownKeyError reports a key that is not in the schema
+type UnknownKeyError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownKeyError) Error() string {
+	msg := fmt.Sprintf("알 수 없는 설정 키입니다: %s", e.Name)
+	if len(e.Suggestions) > 0 {
+		msg += fmt.Sprintf(" (혹시 %s?)", strings.Join(e.Suggestions, ", "))
+	}
+	return msg + " - 'config list'로 사용 가능한 키를 확인하세요"
+}
+
+// Parse validates a raw string value for a key and converts it to the key's type.
+// List values are comma separated.
+func Parse(name, raw string) (interface{}, error) {
+	key, ok := Lookup(name)
+	if !ok {
+		return nil, &UnknownKeyError{Name: name, Suggestions: Suggest(name)}
+	}
+
+	raw = strings.TrimSpace(raw)
+	switch key.Type {
+	case TypeInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s 값은 정수여야 합니다: %q", key.Name, raw)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("%s 값은 0 이상이어야 합니다: %d", key.Name, n)
+		}
+		return n, nil
+	case TypeBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s 값은 true 또는 false여야 합니다: %q", key.Name, raw)
+		}
+		return b, nil
+	case TypeList:
+		list := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list, nil
+	default:
+		if err := checkAllowed(key, raw); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	}
+}
+
+// Validate checks a value already loaded from a config file
+func Validate(name string, value interface{}) error {
+	key, ok := Lookup(name)
+	if !ok {
+		return &UnknownKeyError{Name: name, Suggestions: Suggest(name)}
+	}
+
+	switch key.Type {
+	case TypeInt:
+		switch v := value.(type) {
+		case int, int64, float64:
+			_, err := Parse(name, fmt.Sprint(v))
+			return err
+		}
+	case TypeBool:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+	case TypeList:
+		switch value.(type) {
+		case []interface{}, []string:
+			return nil
+		}
+	default:
+		if s, ok := value.(string); ok {
+			return checkAllowed(key, s)
+		}
+	}
+	return fmt.Errorf("%s 값의 형식이 잘못되었습니다 (%s 필요): %v", key.Name, key.Type, value)
+}
+
+// checkAllowed verifies a string value against the key's allowed values
+func checkAllowed(key Key, value string) error {
+	if len(key.Allowed) == 0 {
+		return nil
+	}
+	for _, allowed := range key.Allowed {
+		if value == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s 값이 잘못되었습니다: %q (사용 가능: %s)", key.Name, value, strings.Join(key.Allowed, ", "))
+}
+
+// Suggest returns known keys that look like name, closest first
+func Suggest(name string) []string {
+	name = strings.ToLower(name)
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, k := range Keys {
+		d := levenshtein(name, k.Name)
+		// Also match on the last segment, e.g. "limit" -> "search.limit"
+		if i := strings.LastIndex(k.Name, "."); i >= 0 && k.Name[i+1:] == name {
+			d = 0
+		}
+		if d <= 3 {
+			candidates = append(candidates, candidate{k.Name, d})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	var result []string
+	for i, c := range candidates {
+		if i == 3 {
+			break
+		}
+		result = append(result, c.name)
+	}
+	return result
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// FormatValue formats a value for display
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Template returns a commented YAML config file with every key at its default value
+func Template(lang string) string {
+	var output strings.Builder
+	if lang == "en" {
+		output.WriteString("# vi-assistant configuration\n# Run 'vi-assistant config list' to see the effective values.\n")
+	} else {
+		output.WriteString("# vi-assistant 설정 파일\n# 'vi-assistant config list'로 현재 적용된 값을 확인할 수 있습니다.\n")
+	}
+
+	// Group dotted keys under their section
+	section := ""
+	for _, k := range Keys {
+		parts := strings.SplitN(k.Name, ".", 2)
+		name := k.Name
+		indent := ""
+		if len(parts) == 2 {
+			if parts[0] != section {
+				section = parts[0]
+				output.WriteString("\n" + section + ":\n")
+			}
+			name = parts[1]
+			indent = "  "
+		} else {
+			section = ""
+			output.WriteString("\n")
+		}
+
+		output.WriteString(fmt.Sprintf("%s# %s", indent, k.Description(lang)))
+		if len(k.Allowed) > 0 {
+			output.WriteString(" (" + strings.Join(k.Allowed, "/") + ")")
+		}
+		output.WriteString("\n")
+		output.WriteString(fmt.Sprintf("%s%s: %s\n", indent, name, yamlValue(k.Default)))
+	}
+
+	return output.String()
+}
+
+// yamlValue renders a default value as a YAML scalar or flow sequence
+func yamlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case []string:
+		quoted := make([]string, len(v))
+		for i, item := range v {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name, raw string
+		want      interface{}
+		wantErr   bool
+	}{
+		{"lang", "en", "en", false},
+		{"LANG", " ko ", "ko", false},
+		{"lang", "fr", nil, true},
+		{"search.limit", "10", 10, false},
+		{"search.limit", "-1", nil, true},
+		{"search.limit", "ten", nil, true},
+		{"learn.pause", "false", false, false},
+		{"learn.pause", "maybe", nil, true},
+		{"data.sources", "a.json, ,b.json", []string{"a.json", "b.json"}, false},
+		{"data.sources", "", []string{}, false},
+		{"favorites.path", "~/fav.json", "~/fav.json", false},
+		{"serch.limit", "1", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.name, tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q, %q) error = %v, wantErr %v", tt.name, tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q, %q) = %#v, want %#v", tt.name, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"lang", "en", false},
+		{"lang", "fr", true},
+		{"lang", 3, true},
+		{"search.limit", 5, false},
+		{"search.limit", float64(5), false},
+		{"search.limit", -2, true},
+		{"search.limit", "5", true},
+		{"learn.pause", true, false},
+		{"learn.pause", "yes", true},
+		{"data.sources", []interface{}{"a.json"}, false},
+		{"data.sources", "a.json", true},
+		{"unknown.key", "x", true},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.name, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %#v) error = %v, wantErr %v", tt.name, tt.value, err, tt.wantErr)
+		}
+	}
+
+	err := Validate("serch.limit", 1)
+	if unknown, ok := err.(*UnknownKeyError); !ok || len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "search.limit" {
+		t.Errorf("Validate(serch.limit) = %v, want a suggestion of search.limit", err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := map[string]string{
+		"serch.limit": "search.limit",
+		"limit":       "search.limit",
+		"colour":      "color",
+		"Lang":        "lang",
+	}
+	for name, want := range tests {
+		if got := Suggest(name); len(got) == 0 || got[0] != want {
+			t.Errorf("Suggest(%q) = %v, want %s first", name, got, want)
+		}
+	}
+	if got := Suggest("completely.different"); len(got) != 0 {
+		t.Errorf("Suggest(completely.different) = %v, want nothing", got)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	for _, lang := range []string{"ko", "en"} {
+		out := Template(lang)
+		for _, want := range []string{"\nlang: ko\n", "\nsearch:\n  # ", "  limit: 0\n", "  sources: []\n", " (ko/en)\n", "\nserve:\n"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("Template(%s) is missing %q:\n%s", lang, want, out)
+			}
+		}
+		// Every key appears once with its default value
+		for _, k := range Keys {
+			name := k.Name[strings.LastIndex(k.Name, ".")+1:]
+			if !strings.Contains(out, name+": ") {
+				t.Errorf("Template(%s) is missing %s", lang, k.Name)
+			}
+		}
+	}
+	if !strings.Contains(Template("en"), "# Output language") {
+		t.Error("English template should use English descriptions")
+	}
+}
@@ -0,0 +1,215 @@
+// Package editors maps catalog commands to their equivalents in other
+// editors (Emacs, VS Code, nano) and to the differences in Vim emulations
+// (VSCodeVim, IdeaVim). Every editor is one file in data/editors.
+package editors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"vi-assistant/internal/paths"
+)
+
+// Kinds of editors
+const (
+	KindEditor    = "editor"    // another editor with its own keys
+	KindEmulation = "emulation" // a Vim emulation that differs in places
+)
+
+// Mapping is the equivalent of one catalog command in an editor
+type Mapping struct {
+	Command     string            `json:"command"`     // catalog command (vi side)
+	Keys        []string          `json:"keys"`        // key sequences in the editor's notation; empty when it has none
+	Description map[string]string `json:"description"` // 언어별 설명 (ko, en)
+}
+
+// Describe returns the description in lang, falling back to Korean
+func (m Mapping) Describe(lang string) string {
+	return localized(m.Description, lang)
+}
+
+// Editor is one data file: an editor and its mappings
+type Editor struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Kind     string            `json:"kind"`
+	Note     map[string]string `json:"note,omitempty"` // notation and platform, 언어별 (ko, en)
+	Mappings []Mapping         `json:"mappings"`
+}
+
+// Describe returns the note in lang, falling back to Korean
+func (e Editor) Describe(lang string) string {
+	return localized(e.Note, lang)
+}
+
+// Lookup returns the mapping of a catalog command
+func (e Editor) Lookup(command string) (Mapping, bool) {
+	for _, m := range e.Mappings {
+		if m.Command == command {
+			return m, true
+		}
+	}
+	return Mapping{}, false
+}
+
+// Find returns the mappings whose keys match keys, in any of the notations
+// NormalizeKeys understands ("^K", "C-k", "Ctrl+K")
+func (e Editor) Find(keys string) []Mapping {
+	want := NormalizeKeys(keys)
+	var found []Mapping
+	for _, m := range e.Mappings {
+		for _, k := range m.Keys {
+			if NormalizeKeys(k) == want {
+				found = append(found, m)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func localized(text map[string]string, lang string) string {
+	if t, ok := text[lang]; ok && t != "" {
+		return t
+	}
+	return text["ko"]
+}
+
+// DefaultDir is the bundled directory of editor files, relative to the
+// working directory or to the directory of the executable
+var DefaultDir = filepath.Join("data", "editors")
+
+var dir = DefaultDir
+
+// SetDir replaces the directory of editor files. An empty path restores DefaultDir.
+func SetDir(path string) {
+	if path == "" {
+		path = DefaultDir
+	}
+	dir = path
+}
+
+// DB is the loaded set of editors: other editors first, then emulations,
+// each by name
+type DB struct {
+	Editors []Editor
+}
+
+// document is the editor file layout
+type document struct {
+	SchemaVersion int `json:"schema_version"`
+	Editor
+}
+
+// Load reads every editor file
+func Load() (*DB, error) {
+	files, err := filepath.Glob(filepath.Join(paths.Bundled(dir), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s에 편집기 파일이 없습니다", dir)
+	}
+
+	db := &DB{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s 파일을 읽을 수 없습니다: %v", filepath.Base(file), err)
+		}
+		var doc document
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s JSON 파싱 오류: %v", filepath.Base(file), err)
+		}
+		if doc.SchemaVersion != 1 {
+			return nil, fmt.Errorf("%s: 지원하지 않는 schema_version입니다: %d", filepath.Base(file), doc.SchemaVersion)
+		}
+		if doc.ID == "" || (doc.Kind != KindEditor && doc.Kind != KindEmulation) {
+			return nil, fmt.Errorf("%s: id와 kind(%s 또는 %s)가 필요합니다", filepath.Base(file), KindEditor, KindEmulation)
+		}
+		db.Editors = append(db.Editors, doc.Editor)
+	}
+
+	sort.SliceStable(db.Editors, func(i, j int) bool {
+		a, b := db.Editors[i], db.Editors[j]
+		if a.Kind != b.Kind {
+			return a.Kind == KindEditor
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return db, nil
+}
+
+// IDs returns the editor ids in DB order
+func (db *DB) IDs() []string {
+	ids := make([]string, len(db.Editors))
+	for i, e := range db.Editors {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+// Editor finds an editor by id or name, ignoring case and spaces ("VS Code" is vscode)
+func (db *DB) Editor(name string) (*Editor, error) {
+	key := strings.ToLower(strings.Join(strings.Fields(name), ""))
+	for i, e := range db.Editors {
+		if e.ID == key || strings.ToLower(strings.Join(strings.Fields(e.Name), "")) == key {
+			return &db.Editors[i], nil
+		}
+	}
+	return nil, fmt.Errorf("알 수 없는 편집기입니다: %s (사용 가능: %s)", name, strings.Join(db.IDs(), ", "))
+}
+
+// Equivalent is the mapping of a command in one editor
+type Equivalent struct {
+	Editor string `json:"editor"` // editor id
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Mapping
+}
+
+// Equivalents returns the mappings of a catalog command in every editor that has one
+func (db *DB) Equivalents(command string) []Equivalent {
+	var equivalents []Equivalent
+	for _, e := range db.Editors {
+		if m, ok := e.Lookup(command); ok {
+			equivalents = append(equivalents, Equivalent{Editor: e.ID, Name: e.Name, Kind: e.Kind, Mapping: m})
+		}
+	}
+	return equivalents
+}
+
+// FormatEquivalents lists equivalents one editor per line: the keys and
+// the description of other editors, and what differs in emulations
+func FormatEquivalents(equivalents []Equivalent, lang string) string {
+	var b strings.Builder
+	for _, eq := range equivalents {
+		keys := strings.Join(eq.Keys, ", ")
+		switch {
+		case eq.Kind == KindEmulation && len(eq.Keys) == 0:
+			keys = label("unsupported", lang)
+		case eq.Kind == KindEmulation:
+			keys = label("differs", lang)
+		case len(eq.Keys) == 0:
+			keys = "-"
+		}
+		b.WriteString(fmt.Sprintf("  %-10s %s - %s\n", eq.Name, keys, eq.Describe(lang)))
+	}
+	return b.String()
+}
+
+var labels = map[string][2]string{
+	"unsupported": {"지원 안 함", "not supported"},
+	"differs":     {"차이", "differs"},
+}
+
+func label(key, lang string) string {
+	if lang == "en" {
+		return labels[key][1]
+	}
+	return labels[key][0]
+}
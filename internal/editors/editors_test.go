@@ -0,0 +1,119 @@
+package editors
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/testenv"
+)
+
+func loadDB(t *testing.T) *DB {
+	t.Helper()
+	SetDir(filepath.Join("..", "..", "data", "editors"))
+	t.Cleanup(func() { SetDir("") })
+
+	db, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestNormalizeKeys(t *testing.T) {
+	tests := map[string]string{
+		"^K":              "ctrl+k",
+		"C-k":             "ctrl+k",
+		"Ctrl+K":          "ctrl+k",
+		"<C-k>":           "ctrl+k",
+		"Ctrl+Shift+K":    "ctrl+shift+k",
+		"C-S-<backspace>": "ctrl+shift+backspace",
+		"M-<":             "alt+<",
+		"M-U":             "alt+u",
+		"^\\":             "ctrl+\\",
+		"C-x C-s":         "ctrl+x ctrl+s",
+		"C-SPC":           "ctrl+space",
+		"Escape":          "esc",
+		"Ctrl++":          "ctrl++",
+		"G":               "G", // case matters without a modifier
+		"^A Enter Up":     "ctrl+a enter up",
+	}
+	for keys, want := range tests {
+		if got := NormalizeKeys(keys); got != want {
+			t.Errorf("NormalizeKeys(%q) = %q, want %q", keys, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	db := loadDB(t)
+
+	if got := strings.Join(db.IDs(), ","); got != "emacs,nano,vscode,ideavim,vscodevim" {
+		t.Errorf("IDs = %s, want other editors first, then emulations", got)
+	}
+	for _, name := range []string{"VS Code", "vscode", "Nano"} {
+		if _, err := db.Editor(name); err != nil {
+			t.Errorf("Editor(%q): %v", name, err)
+		}
+	}
+	if _, err := db.Editor("notepad"); err == nil {
+		t.Error("Editor(notepad) should fail")
+	}
+}
+
+// Every mapping must name a catalog command and describe itself in both languages
+func TestMappingsMatchCatalog(t *testing.T) {
+	db := loadDB(t)
+	known := make(map[string]bool)
+	for _, cmd := range testenv.Catalog(t) {
+		known[cmd.Command] = true
+	}
+
+	for _, e := range db.Editors {
+		seen := make(map[string]bool)
+		for _, m := range e.Mappings {
+			if !known[m.Command] {
+				t.Errorf("%s: %s is not in the catalog", e.ID, m.Command)
+			}
+			if seen[m.Command] {
+				t.Errorf("%s: %s is mapped twice", e.ID, m.Command)
+			}
+			seen[m.Command] = true
+			if m.Description["ko"] == "" || m.Description["en"] == "" {
+				t.Errorf("%s: %s needs ko and en descriptions", e.ID, m.Command)
+			}
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	db := loadDB(t)
+	nano, err := db.Editor("nano")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, keys := range []string{"^K", "Ctrl+K", "C-k"} {
+		found := nano.Find(keys)
+		// ^K also cuts the marked region
+		if len(found) != 2 || found[0].Command != "dd" || found[1].Command != "d{motion}" {
+			t.Errorf("nano Find(%q) = %v, want dd and d{motion}", keys, found)
+		}
+	}
+	if found := nano.Find("^Z"); len(found) != 0 {
+		t.Errorf("nano Find(^Z) = %v, want nothing", found)
+	}
+}
+
+func TestEquivalents(t *testing.T) {
+	db := loadDB(t)
+
+	eqs := db.Equivalents("dd")
+	if len(eqs) != 3 || eqs[0].Editor != "emacs" {
+		t.Fatalf("Equivalents(dd) = %v, want emacs, nano and vscode", eqs)
+	}
+	out := FormatEquivalents(db.Equivalents(":help"), "en")
+	if !strings.Contains(out, "C-h ?") || !strings.Contains(out, "IdeaVim    not supported") {
+		t.Errorf("FormatEquivalents(:help) =\n%s", out)
+	}
+}
@@ -0,0 +1,79 @@
+package editors
+
+import "strings"
+
+// modifierNames maps the ways editors write a modifier to one name
+var modifierNames = map[string]string{
+	"c": "ctrl", "ctrl": "ctrl", "control": "ctrl", "^": "ctrl",
+	"m": "alt", "meta": "alt", "alt": "alt", "option": "alt", "opt": "alt",
+	"s": "shift", "shift": "shift",
+	"cmd": "cmd", "command": "cmd", "super": "cmd", "win": "cmd",
+}
+
+// modifierOrder is the order of modifiers in a normalized chord
+var modifierOrder = []string{"ctrl", "alt", "shift", "cmd"}
+
+// keyNames maps other names of special keys to one name
+var keyNames = map[string]string{
+	"spc": "space", "ret": "enter", "return": "enter", "escape": "esc",
+	"del": "delete", "bsp": "backspace", "bs": "backspace",
+	"pgup": "pageup", "pgdn": "pagedown",
+}
+
+// NormalizeKeys writes a key sequence in one notation so that the notations
+// of Emacs ("C-k", "M-<"), nano ("^K", "M-U"), VS Code ("Ctrl+Shift+K") and
+// Vim ("<C-k>") compare equal: chords separated by spaces, each as
+// "ctrl+alt+shift+key". Letters are lowercased unless typed without a modifier.
+func NormalizeKeys(keys string) string {
+	fields := strings.Fields(keys)
+	for i, f := range fields {
+		fields[i] = normalizeChord(f)
+	}
+	return strings.Join(fields, " ")
+}
+
+func normalizeChord(chord string) string {
+	if len(chord) > 2 && chord[0] == '<' && chord[len(chord)-1] == '>' {
+		chord = chord[1 : len(chord)-1]
+	}
+
+	mods := make(map[string]bool)
+	key := chord
+	for {
+		switch {
+		case len(key) > 1 && key[0] == '^':
+			mods["ctrl"] = true
+			key = key[1:]
+			continue
+		case len(key) > 2 && key[1] == '-' && modifierNames[strings.ToLower(key[:1])] != "":
+			mods[modifierNames[strings.ToLower(key[:1])]] = true
+			key = key[2:]
+			continue
+		}
+		if i := strings.Index(key, "+"); i > 0 && i < len(key)-1 {
+			if name := modifierNames[strings.ToLower(key[:i])]; name != "" {
+				mods[name] = true
+				key = key[i+1:]
+				continue
+			}
+		}
+		break
+	}
+	if len(key) > 2 && key[0] == '<' && key[len(key)-1] == '>' {
+		key = key[1 : len(key)-1]
+	}
+
+	if name, ok := keyNames[strings.ToLower(key)]; ok {
+		key = name
+	} else if len(key) > 1 || len(mods) > 0 {
+		key = strings.ToLower(key)
+	}
+
+	var parts []string
+	for _, m := range modifierOrder {
+		if mods[m] {
+			parts = append(parts, m)
+		}
+	}
+	return strings.Join(append(parts, key), "+")
+}
@@ -1,30 +1,40 @@
 package explain
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
 	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/editors"
+	"vi-assistant/internal/hangul"
+	"vi-assistant/internal/keylog"
+	"vi-assistant/internal/style"
 )
 
 // Command represents a vi command structure
-type Command struct {
-	Keyword     string `json:"keyword"`
-	Command     string `json:"command"`
-	Description string `json:"description"`
-	Example     string `json:"example"`
-	Category    string `json:"category"`
-}
+type Command = catalog.Command
 
 // ExplainResult represents explanation result
 type ExplainResult struct {
-	Command     Command
-	Found       bool
-	Suggestions []Command
+	Command     Command              `json:"command"`
+	Found       bool                 `json:"found"`
+	Suggestions []Command            `json:"suggestions"`
+	Related     []catalog.Neighbor   `json:"related,omitempty"`     // directly related commands
+	IME         *hangul.Guess        `json:"ime,omitempty"`         // set when the command was typed in Hangul input mode
+	Sequence    []Command            `json:"sequence,omitempty"`    // the commands the keys type, when they are not one command
+	Equivalents []editors.Equivalent `json:"equivalents,omitempty"` // the command in other editors and Vim emulations
+}
+
+// RelatedResult is the outcome of walking the relation graph from a command
+type RelatedResult struct {
+	Command   Command            `json:"command"`
+	Depth     int                `json:"depth"`
+	Neighbors []catalog.Neighbor `json:"neighbors"`
 }
 
+// maxCategoryNeighbors limits same-category commands in the see also section
+const maxCategoryNeighbors = 5
+
 // Explain explains a specific vi command
 func Explain(command string) (*ExplainResult, error) {
 	commands, err := loadCommands()
@@ -32,10 +42,67 @@ func Explain(command string) (*ExplainResult, error) {
 		return nil, fmt.Errorf("명령어 데이터를 로드할 수 없습니다: %v", err)
 	}
 
+	result := lookup(commands, command)
+	if !result.Found {
+		if typed := lookupHangul(commands, command); typed != nil {
+			result = typed
+		}
+	}
+	if result.Found {
+		result.Related = catalog.NewGraph(commands).Walk(result.Command.Command, 1)
+		// Equivalents are extra; missing editor files do not stop the explanation
+		if db, err := editors.Load(); err == nil {
+			result.Equivalents = db.Equivalents(result.Command.Command)
+		}
+	}
+	return result, nil
+}
+
+// Related walks the relation graph from a command up to depth links.
+// The command is looked up like Explain does; a missing command gives an ExplainResult
+// with suggestions instead.
+func Related(command string, depth int) (*RelatedResult, *ExplainResult, error) {
+	commands, err := loadCommands()
+	if err != nil {
+		return nil, nil, fmt.Errorf("명령어 데이터를 로드할 수 없습니다: %v", err)
+	}
+
+	found := lookup(commands, command)
+	if !found.Found {
+		return nil, found, nil
+	}
+	return &RelatedResult{
+		Command:   found.Command,
+		Depth:     depth,
+		Neighbors: catalog.NewGraph(commands).Walk(found.Command.Command, depth),
+	}, found, nil
+}
+
+// lookup finds a command by exact name, alias or case-insensitive name,
+// collecting similar commands as suggestions when there is no match
+func lookup(commands []Command, command string) *ExplainResult {
 	command = strings.TrimSpace(command)
 	var result ExplainResult
 	var suggestions []Command
 
+	// 대소문자까지 같은 명령어를 우선합니다 (p와 P, gt와 gT는 다른 명령어)
+	for _, cmd := range commands {
+		if cmd.Command == command {
+			result.Command = cmd
+			result.Found = true
+			return &result
+		}
+	}
+
+	// 별칭으로도 찾습니다 (예: :quit → :q)
+	for _, cmd := range commands {
+		if cmd.MatchesAlias(command) {
+			result.Command = cmd
+			result.Found = true
+			return &result
+		}
+	}
+
 	for _, cmd := range commands {
 		if strings.EqualFold(cmd.Command, command) {
 			result.Command = cmd
@@ -51,78 +118,51 @@ func Explain(command string) (*ExplainResult, error) {
 	}
 
 	result.Suggestions = suggestions
-	return &result, nil
+	return &result
 }
 
-// GetQuickReference returns a quick reference for common commands
-func GetQuickReference(lang string) string {
-	commonCommands := []struct {
-		Command     string
-		Description string
-		Category    string
-	}{
-		{":w", "파일 저장", "file"},
-		{":q", "종료", "file"},
-		{":wq", "저장 후 종료", "file"},
-		{":q!", "강제 종료", "file"},
-		{"i", "삽입 모드", "mode"},
-		{"Esc", "명령 모드", "mode"},
-		{"yy", "줄 복사", "copy"},
-		{"dd", "줄 삭제", "delete"},
-		{"p", "붙여넣기", "paste"},
-		{"u", "실행 취소", "edit"},
-		{"h", "왼쪽 이동", "navigation"},
-		{"j", "아래 이동", "navigation"},
-		{"k", "위 이동", "navigation"},
-		{"l", "오른쪽 이동", "navigation"},
-		{"/pattern", "검색", "search"},
-		{":s/old/new", "바꾸기", "edit"},
-	}
-
-	var output strings.Builder
-	
-	if lang == "en" {
-		output.WriteString("Quick Reference - Common vi Commands:\n\n")
-	} else {
-		output.WriteString("빠른 참조 - 자주 사용하는 vi 명령어:\n\n")
-	}
-
-	output.WriteString("📁 File Operations:\n")
-	for _, cmd := range commonCommands {
-		if cmd.Category == "file" {
-			output.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.Command, cmd.Description))
+// lookupHangul reads a command typed in Hangul input mode back as the keys
+// that were pressed and looks them up: first as one command (ㅇㅇ is dd),
+// then as a sequence of commands (ㅗㅓㅏㅣ is h, j, k, l). A sequence is only
+// read from standalone jamo and must use every key, since almost any Korean
+// word parses as some keys (종료 is whdfy). It returns nil when the command
+// has no Hangul or the keys mean nothing.
+func lookupHangul(commands []Command, command string) *ExplainResult {
+	guesses := hangul.Guesses(strings.TrimSpace(command))
+	for i := range guesses {
+		if result := lookup(commands, guesses[i].Keys); result.Found {
+			result.IME = &guesses[i]
+			return result
 		}
 	}
 
-	output.WriteString("\n🎯 Mode Switching:\n")
-	for _, cmd := range commonCommands {
-		if cmd.Category == "mode" {
-			output.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.Command, cmd.Description))
-		}
+	if !hangul.OnlyJamo(command) {
+		return nil
 	}
-
-	output.WriteString("\n✂️ Edit Operations:\n")
-	for _, cmd := range commonCommands {
-		if cmd.Category == "copy" || cmd.Category == "delete" || cmd.Category == "paste" || cmd.Category == "edit" {
-			output.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.Command, cmd.Description))
+	an := keylog.NewAnalyzer(commands, "")
+	byName := make(map[string]Command)
+	for _, cmd := range commands {
+		if _, exists := byName[cmd.Command]; !exists {
+			byName[cmd.Command] = cmd
 		}
 	}
-
-	output.WriteString("\n🧭 Navigation:\n")
-	for _, cmd := range commonCommands {
-		if cmd.Category == "navigation" {
-			output.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.Command, cmd.Description))
+	for i := range guesses {
+		var sequence []Command
+		var typed strings.Builder
+		for _, tok := range keylog.Parse(keylog.SplitNotation(guesses[i].Keys)) {
+			cmd, ok := byName[an.CatalogName(tok)]
+			if !ok {
+				sequence = nil
+				break
+			}
+			sequence = append(sequence, cmd)
+			typed.WriteString(tok.Keys)
 		}
-	}
-
-	output.WriteString("\n🔍 Search & Replace:\n")
-	for _, cmd := range commonCommands {
-		if cmd.Category == "search" {
-			output.WriteString(fmt.Sprintf("  %-10s %s\n", cmd.Command, cmd.Description))
+		if len(sequence) > 0 && typed.String() == guesses[i].Keys {
+			return &ExplainResult{IME: &guesses[i], Sequence: sequence}
 		}
 	}
-
-	return output.String()
+	return nil
 }
 
 // GetCommandByCategory returns commands grouped by category
@@ -144,47 +184,47 @@ func GetCommandByCategory(category string) ([]Command, error) {
 	return results, nil
 }
 
-// loadCommands loads commands from the JSON file
+// loadCommands loads commands from the configured catalog files
 func loadCommands() ([]Command, error) {
-	// Get the current working directory
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	// Construct the path to commands.json
-	dataPath := filepath.Join(wd, "data", "commands.json")
-
-	// Read the JSON file
-	data, err := ioutil.ReadFile(dataPath)
-	if err != nil {
-		return nil, fmt.Errorf("commands.json 파일을 읽을 수 없습니다: %v", err)
-	}
-
-	// Parse JSON
-	var commands []Command
-	if err := json.Unmarshal(data, &commands); err != nil {
-		return nil, fmt.Errorf("JSON 파싱 오류: %v", err)
-	}
-
-	return commands, nil
+	return catalog.Load()
 }
 
 // FormatExplanation formats explanation result for display
 func FormatExplanation(result *ExplainResult, lang string) string {
 	var output strings.Builder
 
+	if result.IME != nil {
+		output.WriteString(result.IME.Warning(lang) + "\n")
+	}
+
 	if result.Found {
 		if lang == "en" {
-			output.WriteString(fmt.Sprintf("Command: %s\n", result.Command.Command))
+			output.WriteString(fmt.Sprintf("Command: %s\n", style.Command(result.Command.Command)))
 			output.WriteString(fmt.Sprintf("Category: %s\n", result.Command.Category))
 			output.WriteString(fmt.Sprintf("Description: %s\n", result.Command.Description))
 			output.WriteString(fmt.Sprintf("Example: %s\n", result.Command.Example))
+			output.WriteString(fmt.Sprintf("Source: %s\n", result.Command.Source))
+			writeMetadata(&output, result.Command, lang)
+			writeSeeAlso(&output, result.Related, lang)
+			writeEquivalents(&output, result.Equivalents, lang)
 		} else {
-			output.WriteString(fmt.Sprintf("명령어: %s\n", result.Command.Command))
+			output.WriteString(fmt.Sprintf("명령어: %s\n", style.Command(result.Command.Command)))
 			output.WriteString(fmt.Sprintf("카테고리: %s\n", result.Command.Category))
 			output.WriteString(fmt.Sprintf("설명: %s\n", result.Command.Description))
 			output.WriteString(fmt.Sprintf("예제: %s\n", result.Command.Example))
+			output.WriteString(fmt.Sprintf("출처: %s\n", result.Command.Source))
+			writeMetadata(&output, result.Command, lang)
+			writeSeeAlso(&output, result.Related, lang)
+			writeEquivalents(&output, result.Equivalents, lang)
+		}
+	} else if len(result.Sequence) > 0 {
+		if lang == "en" {
+			output.WriteString("The keys type these commands:\n")
+		} else {
+			output.WriteString("입력한 키는 다음 명령어들입니다:\n")
+		}
+		for _, cmd := range result.Sequence {
+			output.WriteString(fmt.Sprintf("  %s - %s\n", style.Command(fmt.Sprintf("%-10s", cmd.Command)), cmd.Description))
 		}
 	} else {
 		if lang == "en" {
@@ -204,12 +244,125 @@ func FormatExplanation(result *ExplainResult, lang string) string {
 				break
 			}
 			if lang == "en" {
-				output.WriteString(fmt.Sprintf("  %s - %s\n", suggestion.Command, suggestion.Description))
+				output.WriteString(fmt.Sprintf("  %s - %s [%s]\n", suggestion.Command, suggestion.Description, suggestion.Source))
+			} else {
+				output.WriteString(fmt.Sprintf("  %s - %s [%s]\n", suggestion.Command, suggestion.Description, suggestion.Source))
+			}
+		}
+	}
+
+	return output.String()
+}
+
+// writeMetadata 함수는 모드, 지원 편집기, 별칭, Vim 도움말 중 데이터가 있는 항목만 출력합니다
+func writeMetadata(output *strings.Builder, cmd Command, lang string) {
+	labels := map[string][2]string{
+		"modes":        {"모드", "Modes"},
+		"availability": {"지원", "Available in"},
+		"aliases":      {"별칭", "Aliases"},
+		"help_tag":     {"Vim 도움말", "Vim help"},
+		"help_text":    {"Vim 원문 설명", "Vim documentation"},
+	}
+	label := func(key string) string {
+		if lang == "en" {
+			return labels[key][1]
+		}
+		return labels[key][0]
+	}
+
+	if len(cmd.Modes) > 0 {
+		output.WriteString(fmt.Sprintf("%s: %s\n", label("modes"), strings.Join(cmd.Modes, ", ")))
+	}
+	if cmd.Availability != nil {
+		output.WriteString(fmt.Sprintf("%s: %s\n", label("availability"), catalog.FormatAvailability(cmd.Availability, lang)))
+	}
+	if len(cmd.Aliases) > 0 {
+		output.WriteString(fmt.Sprintf("%s: %s\n", label("aliases"), strings.Join(cmd.Aliases, ", ")))
+	}
+	if cmd.HelpTag != "" {
+		output.WriteString(fmt.Sprintf("%s: :help %s\n", label("help_tag"), cmd.HelpTag))
+	}
+	if cmd.HelpText != "" {
+		output.WriteString(fmt.Sprintf("%s: %s\n", label("help_text"), cmd.HelpText))
+	}
+}
+
+// writeSeeAlso 함수는 관련 명령어를 관계 종류별로 묶어 "함께 보기" 섹션으로 출력합니다
+func writeSeeAlso(output *strings.Builder, related []catalog.Neighbor, lang string) {
+	if len(related) == 0 {
+		return
+	}
+
+	if lang == "en" {
+		output.WriteString("\nSee also:\n")
+	} else {
+		output.WriteString("\n함께 보기:\n")
+	}
+
+	byKind := make(map[string][]string)
+	for _, n := range related {
+		if n.Kind == catalog.RelationCategory && len(byKind[n.Kind]) >= maxCategoryNeighbors {
+			continue
+		}
+		byKind[n.Kind] = append(byKind[n.Kind], style.Command(n.Command.Command))
+	}
+	for _, kind := range catalog.RelationKinds {
+		if names := byKind[kind]; len(names) > 0 {
+			output.WriteString(fmt.Sprintf("  %s: %s\n", catalog.RelationTitle(kind, lang), strings.Join(names, ", ")))
+		}
+	}
+}
+
+// writeEquivalents 함수는 다른 편집기와 Vim 에뮬레이션에서의 대응 키를 "다른 편집기에서" 섹션으로 출력합니다
+func writeEquivalents(output *strings.Builder, equivalents []editors.Equivalent, lang string) {
+	if len(equivalents) == 0 {
+		return
+	}
+
+	if lang == "en" {
+		output.WriteString("\nIn other editors:\n")
+	} else {
+		output.WriteString("\n다른 편집기에서:\n")
+	}
+	output.WriteString(editors.FormatEquivalents(equivalents, lang))
+}
+
+// FormatRelated 함수는 관계 그래프 탐색 결과를 깊이별로 출력합니다
+func FormatRelated(result *RelatedResult, lang string) string {
+	var output strings.Builder
+
+	if lang == "en" {
+		output.WriteString(fmt.Sprintf("Commands related to %s (depth %d):\n", style.Command(result.Command.Command), result.Depth))
+	} else {
+		output.WriteString(fmt.Sprintf("%s 관련 명령어 (깊이 %d):\n", style.Command(result.Command.Command), result.Depth))
+	}
+
+	if len(result.Neighbors) == 0 {
+		if lang == "en" {
+			output.WriteString("\nNo related commands.\n")
+		} else {
+			output.WriteString("\n관련 명령어가 없습니다.\n")
+		}
+		return output.String()
+	}
+
+	depth := 0
+	for _, n := range result.Neighbors {
+		if n.Depth != depth {
+			depth = n.Depth
+			if lang == "en" {
+				output.WriteString(fmt.Sprintf("\n[%d link(s) away]\n", depth))
 			} else {
-				output.WriteString(fmt.Sprintf("  %s - %s\n", suggestion.Command, suggestion.Description))
+				output.WriteString(fmt.Sprintf("\n[%d단계]\n", depth))
 			}
 		}
+
+		relation := catalog.RelationTitle(n.Kind, lang)
+		if n.Depth > 1 {
+			relation = fmt.Sprintf("%s ← %s", relation, n.Via)
+		}
+		output.WriteString(fmt.Sprintf("  %s [%s] %s\n", style.Command(fmt.Sprintf("%-14s", n.Command.Command)), relation, n.Command.Description))
 	}
 
 	return output.String()
-} 
\ No newline at end of file
+}
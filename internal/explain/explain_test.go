@@ -0,0 +1,44 @@
+package explain
+
+import (
+	"testing"
+
+	"vi-assistant/internal/testenv"
+)
+
+func TestLookupHangul(t *testing.T) {
+	commands := testenv.Catalog(t)
+
+	result := lookupHangul(commands, "ㅇㅇ")
+	if result == nil || result.Command.Command != "dd" || result.IME == nil || result.IME.Keys != "dd" {
+		t.Errorf("lookupHangul(ㅇㅇ) = %+v, want dd", result)
+	}
+
+	result = lookupHangul(commands, "ㅗㅓㅏㅣ")
+	if result == nil || len(result.Sequence) != 4 {
+		t.Fatalf("lookupHangul(ㅗㅓㅏㅣ) = %+v, want the sequence h j k l", result)
+	}
+	for i, want := range []string{"h", "j", "k", "l"} {
+		if result.Sequence[i].Command != want {
+			t.Errorf("sequence[%d] = %s, want %s", i, result.Sequence[i].Command, want)
+		}
+	}
+
+	// Korean words parse as keys too: 종료 is whdfy on 2-set and 이동 is jduva on 3-set
+	for _, word := range []string{"종료", "이동", "야", "삭제"} {
+		if result := lookupHangul(commands, word); result != nil {
+			t.Errorf("lookupHangul(%s) = %+v (keys %s), want nil", word, result, result.IME.Keys)
+		}
+	}
+}
+
+func TestExplainHangulWord(t *testing.T) {
+	testenv.UseBundledData(t)
+	result, err := Explain("종료")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Found || result.IME != nil || len(result.Sequence) > 0 {
+		t.Errorf("Explain(종료) = %+v, want not found without an input method guess", result)
+	}
+}
@@ -0,0 +1,246 @@
+package favorites
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"vi-assistant/internal/cheatsheet"
+)
+
+// Collection is a named group of favorite commands, e.g. "refactoring"
+type Collection struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Commands    []string `json:"commands"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// collectionNamePattern restricts names to something safe to type and use as a file name
+var collectionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// collectionName normalizes a collection name the way CreateCollection stores it
+func collectionName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// collectionsPath returns the path of collections.json, stored next to favorites.json
+func (fm *FavoritesManager) collectionsPath() string {
+	return filepath.Join(filepath.Dir(fm.filePath), "collections.json")
+}
+
+// CreateCollection creates a new, empty collection
+func (fm *FavoritesManager) CreateCollection(name, description string) error {
+	name = collectionName(name)
+	if !collectionNamePattern.MatchString(name) {
+		return fmt.Errorf("컬렉션 이름은 영문 소문자, 숫자, '-', '_'만 사용할 수 있습니다: %s", name)
+	}
+
+	return fm.updateCollections(func(collections []Collection) ([]Collection, error) {
+		for _, c := range collections {
+			if c.Name == name {
+				return nil, fmt.Errorf("이미 존재하는 컬렉션입니다: %s", name)
+			}
+		}
+		return append(collections, Collection{
+			Name:        name,
+			Description: strings.TrimSpace(description),
+			Commands:    []string{},
+			CreatedAt:   getCurrentTime(),
+		}), nil
+	})
+}
+
+// DeleteCollection deletes a collection; the favorites themselves are kept
+func (fm *FavoritesManager) DeleteCollection(name string) error {
+	name = collectionName(name)
+	return fm.updateCollections(func(collections []Collection) ([]Collection, error) {
+		for i, c := range collections {
+			if c.Name == name {
+				return append(collections[:i], collections[i+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("컬렉션을 찾을 수 없습니다: %s", name)
+	})
+}
+
+// AddToCollection adds favorites to a collection.
+// Every command must be a favorite, named by its command or key. The favorites
+// stay locked until the collection is saved, so none can be removed in between.
+func (fm *FavoritesManager) AddToCollection(name string, commands ...string) error {
+	return withLock(fm.filePath, func() error {
+		favorites, err := fm.loadFavorites()
+		if err != nil {
+			return err
+		}
+		known := make(map[string]bool)
+		for _, fav := range favorites {
+			known[fav.ID()] = true
+		}
+		for _, command := range commands {
+			if !known[command] {
+				return fmt.Errorf("즐겨찾기에 없는 명령어입니다: %s (먼저 'fav add'로 추가하세요)", command)
+			}
+		}
+
+		return fm.modifyCollection(name, func(c *Collection) {
+			for _, command := range commands {
+				if !containsString(c.Commands, command) {
+					c.Commands = append(c.Commands, command)
+				}
+			}
+		})
+	})
+}
+
+// RemoveFromCollection removes commands from a collection
+func (fm *FavoritesManager) RemoveFromCollection(name string, commands ...string) error {
+	return fm.modifyCollection(name, func(c *Collection) {
+		var kept []string
+		for _, command := range c.Commands {
+			if !containsString(commands, command) {
+				kept = append(kept, command)
+			}
+		}
+		c.Commands = kept
+	})
+}
+
+// Collections returns all collections sorted by name
+func (fm *FavoritesManager) Collections() ([]Collection, error) {
+	var collections []Collection
+	err := withLock(fm.collectionsPath(), func() error {
+		var err error
+		collections, err = fm.loadCollections()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+	return collections, nil
+}
+
+// Collection returns a single collection by name
+func (fm *FavoritesManager) Collection(name string) (*Collection, error) {
+	name = collectionName(name)
+	collections, err := fm.Collections()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range collections {
+		if c.Name == name {
+			return &c, nil
+		}
+	}
+	return nil, fmt.Errorf("컬렉션을 찾을 수 없습니다: %s", name)
+}
+
+// CollectionSheet builds a category-grouped cheat sheet from a collection.
+// Commands that are no longer favorites are left out.
+func (fm *FavoritesManager) CollectionSheet(name, lang string) (cheatsheet.Sheet, error) {
+	collection, err := fm.Collection(name)
+	if err != nil {
+		return cheatsheet.Sheet{}, err
+	}
+
+	favorites, err := fm.List()
+	if err != nil {
+		return cheatsheet.Sheet{}, err
+	}
+	byID := make(map[string]Favorite)
+	for _, fav := range favorites {
+		byID[fav.ID()] = fav
+	}
+
+	var entries []cheatsheet.Entry
+	for _, command := range collection.Commands {
+		fav, ok := byID[command]
+		if !ok {
+			continue
+		}
+		entries = append(entries, cheatsheet.Entry{
+			Command:     fav.Command,
+			Description: fav.Description,
+			Category:    fav.Category,
+			Note:        fav.Note,
+		})
+	}
+
+	sheet := cheatsheet.Group(collection.Name, entries, lang)
+	sheet.Description = collection.Description
+	return sheet, nil
+}
+
+// modifyCollection applies fn to the named collection and saves the result
+func (fm *FavoritesManager) modifyCollection(name string, fn func(c *Collection)) error {
+	name = collectionName(name)
+	return fm.updateCollections(func(collections []Collection) ([]Collection, error) {
+		for i := range collections {
+			if collections[i].Name == name {
+				fn(&collections[i])
+				return collections, nil
+			}
+		}
+		return nil, fmt.Errorf("컬렉션을 찾을 수 없습니다: %s", name)
+	})
+}
+
+// updateCollections performs a locked read-modify-write of collections.json
+func (fm *FavoritesManager) updateCollections(fn func([]Collection) ([]Collection, error)) error {
+	return withLock(fm.collectionsPath(), func() error {
+		collections, err := fm.loadCollections()
+		if err != nil {
+			return err
+		}
+
+		collections, err = fn(collections)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(collections, "", "  ")
+		if err != nil {
+			return fmt.Errorf("컬렉션 저장 오류: %v", err)
+		}
+		if err := writeFileAtomic(fm.collectionsPath(), data, 0644); err != nil {
+			return fmt.Errorf("컬렉션 파일 쓰기 오류: %v", err)
+		}
+		return nil
+	})
+}
+
+// loadCollections loads collections.json. Callers must hold the lock.
+func (fm *FavoritesManager) loadCollections() ([]Collection, error) {
+	data, err := ioutil.ReadFile(fm.collectionsPath())
+	if os.IsNotExist(err) {
+		return []Collection{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("컬렉션 파일을 읽을 수 없습니다: %v", err)
+	}
+
+	var collections []Collection
+	if err := json.Unmarshal(data, &collections); err != nil {
+		return nil, fmt.Errorf("컬렉션 파일 파싱 오류: %v", err)
+	}
+	return collections, nil
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
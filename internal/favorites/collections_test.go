@@ -0,0 +1,103 @@
+package favorites
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollections(t *testing.T) {
+	fm := newTestManager(t)
+	for _, command := range []string{"dd", "yy", "p"} {
+		if err := fm.Add(Favorite{Command: command}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fm.CreateCollection(" Refactoring ", "rename things"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.CreateCollection("refactoring", ""); err == nil {
+		t.Error("creating an existing collection should fail")
+	}
+	if err := fm.CreateCollection("no spaces", ""); err == nil {
+		t.Error("a name with a space should be rejected")
+	}
+
+	// Every operation accepts the name in any case, like CreateCollection
+	if err := fm.AddToCollection("REFACTORING", "dd", "yy", "dd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.AddToCollection("refactoring", "x"); err == nil {
+		t.Error("adding a command that is not a favorite should fail")
+	}
+	// A copy kept by an import is added by its key
+	if err := fm.Add(Favorite{Command: "dd", Key: "dd@team"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.AddToCollection("refactoring", "dd@team"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.RemoveFromCollection("Refactoring", "yy", "dd@team"); err != nil {
+		t.Fatal(err)
+	}
+	c, err := fm.Collection("REFACTORING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "refactoring" || c.Description != "rename things" || strings.Join(c.Commands, ",") != "dd" {
+		t.Errorf("collection = %+v", c)
+	}
+
+	if err := fm.DeleteCollection("Refactoring"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fm.Collection("refactoring"); err == nil {
+		t.Error("deleted collection is still there")
+	}
+	if err := fm.DeleteCollection("refactoring"); err == nil {
+		t.Error("deleting a missing collection should fail")
+	}
+}
+
+func TestCollectionSheet(t *testing.T) {
+	fm := newTestManager(t)
+	favs := []Favorite{
+		{Command: "dd", Description: "delete line", Category: "delete", Note: "often"},
+		{Command: "yy", Description: "copy line", Category: "copy"},
+		{Command: "x", Description: "delete char", Category: "delete"},
+	}
+	for _, fav := range favs {
+		if err := fm.Add(fav); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fm.CreateCollection("edit", "editing"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.AddToCollection("edit", "x", "yy", "dd"); err != nil {
+		t.Fatal(err)
+	}
+	// A command removed from favorites drops out of the sheet
+	if err := fm.Remove("yy"); err != nil {
+		t.Fatal(err)
+	}
+
+	sheet, err := fm.CollectionSheet("EDIT", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sheet.Title != "edit" || sheet.Description != "editing" {
+		t.Errorf("sheet title = %q, description = %q", sheet.Title, sheet.Description)
+	}
+	if len(sheet.Sections) != 1 {
+		t.Fatalf("sections = %+v, want only the delete category", sheet.Sections)
+	}
+	entries := sheet.Sections[0].Entries
+	if len(entries) != 2 || entries[0].Command != "x" || entries[1].Command != "dd" || entries[1].Note != "often" {
+		t.Errorf("entries = %+v, want x then dd with its note", entries)
+	}
+
+	if _, err := fm.CollectionSheet("missing", "en"); err == nil {
+		t.Error("CollectionSheet of a missing collection should fail")
+	}
+}
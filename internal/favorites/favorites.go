@@ -2,104 +2,246 @@ package favorites
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+
+	"vi-assistant/internal/paths"
+	"vi-assistant/internal/style"
 )
 
 // Favorite represents a favorite command
 type Favorite struct {
-	Command     string `json:"command"`
-	Description string `json:"description"`
-	AddedAt     string `json:"added_at"`
+	Key         string   `json:"key,omitempty"` // set when the command is a favorite more than once, e.g. "dd@imported"
+	Command     string   `json:"command"`
+	Description string   `json:"description"`
+	Category    string   `json:"category,omitempty"`
+	AddedAt     string   `json:"added_at"`
+	Tags        []string `json:"tags,omitempty"`
+	Note        string   `json:"note,omitempty"`
+	UseCount    int      `json:"use_count"`
+	LastUsedAt  string   `json:"last_used_at,omitempty"`
+}
+
+// ID returns the name a favorite is looked up by: its key, or else its command
+func (f Favorite) ID() string {
+	if f.Key != "" {
+		return f.Key
+	}
+	return f.Command
 }
 
+// Sort keys accepted by SortFavorites
+const (
+	SortByAdded    = "added"
+	SortByName     = "name"
+	SortByCategory = "category"
+	SortByUsage    = "usage"
+)
+
+// SortKeys lists the valid sort keys in display order
+var SortKeys = []string{SortByAdded, SortByName, SortByCategory, SortByUsage}
+
+// legacyTimeLayout is the format written by older versions of favorites.json
+const legacyTimeLayout = "2006-01-02 15:04:05"
+
 // FavoritesManager manages user favorites
 type FavoritesManager struct {
 	filePath string
+	stateDir string
 }
 
-// NewFavoritesManager creates a new favorites manager
+// NewFavoritesManager creates a favorites manager using the default XDG locations
 func NewFavoritesManager() (*FavoritesManager, error) {
-	homeDir, err := os.UserHomeDir()
+	p, err := paths.Resolve(paths.Paths{})
 	if err != nil {
-		return nil, fmt.Errorf("홈 디렉토리를 찾을 수 없습니다: %v", err)
+		return nil, err
 	}
+	return NewFavoritesManagerAt(p.FavoritesFile(), p.StateDir)
+}
 
-	// Create .vi-assistant directory if it doesn't exist
-	configDir := filepath.Join(homeDir, ".vi-assistant")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return nil, fmt.Errorf("설정 디렉토리를 생성할 수 없습니다: %v", err)
+// NewFavoritesManagerAt creates a favorites manager for an explicit favorites file.
+// Backups and recovered files are kept in stateDir.
+func NewFavoritesManagerAt(filePath, stateDir string) (*FavoritesManager, error) {
+	// Create the data and state directories if they don't exist
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("데이터 디렉토리를 생성할 수 없습니다: %v", err)
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return nil, fmt.Errorf("상태 디렉토리를 생성할 수 없습니다: %v", err)
 	}
 
-	filePath := filepath.Join(configDir, "favorites.json")
-	return &FavoritesManager{filePath: filePath}, nil
+	return &FavoritesManager{filePath: filePath, stateDir: stateDir}, nil
 }
 
-// Add adds a command to favorites
-func (fm *FavoritesManager) Add(command, description string) error {
-	favorites, err := fm.loadFavorites()
-	if err != nil {
-		return err
-	}
+// FilePath returns the path of the favorites file
+func (fm *FavoritesManager) FilePath() string {
+	return fm.filePath
+}
 
-	// Check if already exists
-	for _, fav := range favorites {
-		if fav.Command == command {
-			return fmt.Errorf("이미 즐겨찾기에 추가된 명령어입니다: %s", command)
+// Add adds a command to favorites.
+// AddedAt is always set to the current time; tags are normalized.
+func (fm *FavoritesManager) Add(fav Favorite) error {
+	return fm.update(func(favorites []Favorite) ([]Favorite, error) {
+		// Check if already exists
+		for _, existing := range favorites {
+			if existing.ID() == fav.ID() {
+				return nil, fmt.Errorf("이미 즐겨찾기에 추가된 명령어입니다: %s", fav.ID())
+			}
 		}
-	}
-
-	// Add new favorite
-	newFavorite := Favorite{
-		Command:     command,
-		Description: description,
-		AddedAt:     getCurrentTime(),
-	}
 
-	favorites = append(favorites, newFavorite)
+		// Add new favorite
+		fav.AddedAt = getCurrentTime()
+		fav.Tags = normalizeTags(fav.Tags)
+		fav.UseCount = 0
+		fav.LastUsedAt = ""
 
-	return fm.saveFavorites(favorites)
+		return append(favorites, fav), nil
+	})
 }
 
-// Remove removes a command from favorites
+// Remove removes a command from favorites.
+// Like the other methods taking a command, it also accepts the key of a copy kept by MergeBoth.
 func (fm *FavoritesManager) Remove(command string) error {
-	favorites, err := fm.loadFavorites()
-	if err != nil {
-		return err
-	}
+	return fm.update(func(favorites []Favorite) ([]Favorite, error) {
+		var newFavorites []Favorite
+		found := false
 
-	var newFavorites []Favorite
-	found := false
+		for _, fav := range favorites {
+			if fav.ID() != command {
+				newFavorites = append(newFavorites, fav)
+			} else {
+				found = true
+			}
+		}
 
-	for _, fav := range favorites {
-		if fav.Command != command {
-			newFavorites = append(newFavorites, fav)
-		} else {
-			found = true
+		if !found {
+			return nil, fmt.Errorf("즐겨찾기에서 찾을 수 없는 명령어입니다: %s", command)
 		}
+
+		return newFavorites, nil
+	})
+}
+
+// AddTags adds tags to an existing favorite
+func (fm *FavoritesManager) AddTags(command string, tags ...string) error {
+	return fm.modify(command, func(fav *Favorite) {
+		fav.Tags = normalizeTags(append(fav.Tags, tags...))
+	})
+}
+
+// RemoveTags removes tags from an existing favorite
+func (fm *FavoritesManager) RemoveTags(command string, tags ...string) error {
+	drop := make(map[string]bool)
+	for _, tag := range normalizeTags(tags) {
+		drop[tag] = true
 	}
 
-	if !found {
-		return fmt.Errorf("즐겨찾기에서 찾을 수 없는 명령어입니다: %s", command)
+	return fm.modify(command, func(fav *Favorite) {
+		var kept []string
+		for _, tag := range fav.Tags {
+			if !drop[tag] {
+				kept = append(kept, tag)
+			}
+		}
+		fav.Tags = kept
+	})
+}
+
+// SetNote replaces the note of an existing favorite.
+// An empty note removes it.
+func (fm *FavoritesManager) SetNote(command, note string) error {
+	return fm.modify(command, func(fav *Favorite) {
+		fav.Note = strings.TrimSpace(note)
+	})
+}
+
+// RecordUse increments the usage counter of a favorite.
+// It reports whether the command is a favorite; non-favorites are left untouched.
+func (fm *FavoritesManager) RecordUse(command string) (bool, error) {
+	found := false
+	err := fm.update(func(favorites []Favorite) ([]Favorite, error) {
+		for i := range favorites {
+			if favorites[i].ID() == command {
+				favorites[i].UseCount++
+				favorites[i].LastUsedAt = getCurrentTime()
+				found = true
+				return favorites, nil
+			}
+		}
+		return nil, errNoChange
+	})
+	if err == errNoChange {
+		err = nil
 	}
+	return found, err
+}
 
-	return fm.saveFavorites(newFavorites)
+// modify applies fn to the favorite matching command and saves the result
+func (fm *FavoritesManager) modify(command string, fn func(fav *Favorite)) error {
+	return fm.update(func(favorites []Favorite) ([]Favorite, error) {
+		for i := range favorites {
+			if favorites[i].ID() == command {
+				fn(&favorites[i])
+				return favorites, nil
+			}
+		}
+		return nil, fmt.Errorf("즐겨찾기에서 찾을 수 없는 명령어입니다: %s", command)
+	})
 }
 
 // List returns all favorites
 func (fm *FavoritesManager) List() ([]Favorite, error) {
-	return fm.loadFavorites()
+	var favorites []Favorite
+	err := withLock(fm.filePath, func() error {
+		var err error
+		favorites, err = fm.loadFavorites()
+		return err
+	})
+	return favorites, err
 }
 
 // Clear removes all favorites
 func (fm *FavoritesManager) Clear() error {
-	return fm.saveFavorites([]Favorite{})
+	return fm.update(func([]Favorite) ([]Favorite, error) {
+		return []Favorite{}, nil
+	})
 }
 
-// loadFavorites loads favorites from file
+// errNoChange lets an update callback abort without writing and without failing
+var errNoChange = errors.New("no change")
+
+// update performs a locked read-modify-write of the favorites file.
+// If fn returns an error nothing is written.
+func (fm *FavoritesManager) update(fn func([]Favorite) ([]Favorite, error)) error {
+	return withLock(fm.filePath, func() error {
+		favorites, err := fm.loadFavorites()
+		if err != nil {
+			return err
+		}
+
+		favorites, err = fn(favorites)
+		if err != nil {
+			return err
+		}
+
+		return fm.saveFavorites(favorites)
+	})
+}
+
+// backupPath returns the path of the last known good copy of the favorites file
+func (fm *FavoritesManager) backupPath() string {
+	return filepath.Join(fm.stateDir, filepath.Base(fm.filePath)+".bak")
+}
+
+// loadFavorites loads favorites from file.
+// A corrupted file is moved aside and restored from the backup when possible.
+// Callers must hold the lock.
 func (fm *FavoritesManager) loadFavorites() ([]Favorite, error) {
 	if _, err := os.Stat(fm.filePath); os.IsNotExist(err) {
 		return []Favorite{}, nil
@@ -110,32 +252,186 @@ func (fm *FavoritesManager) loadFavorites() ([]Favorite, error) {
 		return nil, fmt.Errorf("즐겨찾기 파일을 읽을 수 없습니다: %v", err)
 	}
 
+	favorites, parseErr := parseFavorites(data)
+	if parseErr == nil {
+		return favorites, nil
+	}
+
+	// Try to recover from the backup written by the previous successful save
+	backup, err := ioutil.ReadFile(fm.backupPath())
+	if err != nil {
+		return nil, fmt.Errorf("즐겨찾기 파일 파싱 오류: %v (백업 파일도 없습니다)", parseErr)
+	}
+	favorites, err = parseFavorites(backup)
+	if err != nil {
+		return nil, fmt.Errorf("즐겨찾기 파일 파싱 오류: %v (백업 파일도 손상되었습니다)", parseErr)
+	}
+
+	// Keep the corrupted file for inspection, then put the backup in place
+	corruptPath := filepath.Join(fm.stateDir,
+		fmt.Sprintf("%s.corrupt-%s", filepath.Base(fm.filePath), time.Now().Format("20060102-150405")))
+	if err := os.Rename(fm.filePath, corruptPath); err != nil {
+		return nil, fmt.Errorf("손상된 즐겨찾기 파일을 옮길 수 없습니다: %v", err)
+	}
+	if err := writeFileAtomic(fm.filePath, backup, 0644); err != nil {
+		return nil, fmt.Errorf("즐겨찾기 파일 복구 오류: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "손상된 즐겨찾기 파일을 백업에서 복구했습니다 (원본: %s)\n", corruptPath)
+
+	return favorites, nil
+}
+
+// parseFavorites decodes the favorites file contents.
+// Every entry must name a command; anything else is treated as corruption.
+func parseFavorites(data []byte) ([]Favorite, error) {
 	var favorites []Favorite
 	if err := json.Unmarshal(data, &favorites); err != nil {
-		return nil, fmt.Errorf("즐겨찾기 파일 파싱 오류: %v", err)
+		return nil, err
+	}
+	for i, fav := range favorites {
+		if strings.TrimSpace(fav.Command) == "" {
+			return nil, fmt.Errorf("%d번째 항목에 command가 없습니다", i+1)
+		}
+	}
+	if favorites == nil {
+		favorites = []Favorite{}
 	}
-
 	return favorites, nil
 }
 
-// saveFavorites saves favorites to file
+// saveFavorites saves favorites to file atomically.
+// The current file is kept as a backup first if it still parses, so a
+// damaged file never replaces the last good backup.
+// Callers must hold the lock.
 func (fm *FavoritesManager) saveFavorites(favorites []Favorite) error {
 	data, err := json.MarshalIndent(favorites, "", "  ")
 	if err != nil {
 		return fmt.Errorf("즐겨찾기 저장 오류: %v", err)
 	}
 
-	if err := ioutil.WriteFile(fm.filePath, data, 0644); err != nil {
+	if current, err := ioutil.ReadFile(fm.filePath); err == nil && isValidFavorites(current) {
+		if err := writeFileAtomic(fm.backupPath(), current, 0644); err != nil {
+			return fmt.Errorf("즐겨찾기 백업 오류: %v", err)
+		}
+	}
+
+	if err := writeFileAtomic(fm.filePath, data, 0644); err != nil {
 		return fmt.Errorf("즐겨찾기 파일 쓰기 오류: %v", err)
 	}
 
 	return nil
 }
 
-// getCurrentTime returns current time in a readable format
+// isValidFavorites reports whether data is a favorites file loadFavorites would accept
+func isValidFavorites(data []byte) bool {
+	_, err := parseFavorites(data)
+	return err == nil
+}
+
+// getCurrentTime returns the current time as an RFC3339 timestamp
 func getCurrentTime() string {
-	// Simple time format for now
-	return "2024-01-01 12:00:00"
+	return time.Now().Format(time.RFC3339)
+}
+
+// parseTime parses a stored timestamp, accepting the legacy format as well.
+// Unparseable values yield the zero time.
+func parseTime(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation(legacyTimeLayout, value, time.Local); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// formatTime formats a stored timestamp for display in local time
+func formatTime(value string) string {
+	t := parseTime(value)
+	if t.IsZero() {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// normalizeTags lowercases, trims and de-duplicates tags, keeping their order
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// HasTag reports whether the favorite carries the given tag
+func (f Favorite) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, t := range f.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByTag returns the favorites carrying the given tag.
+// An empty tag returns the input unchanged.
+func FilterByTag(favorites []Favorite, tag string) []Favorite {
+	if strings.TrimSpace(tag) == "" {
+		return favorites
+	}
+
+	var result []Favorite
+	for _, fav := range favorites {
+		if fav.HasTag(tag) {
+			result = append(result, fav)
+		}
+	}
+	return result
+}
+
+// SortFavorites sorts favorites in place by the given key.
+// Added date sorts oldest first; usage sorts most used first.
+func SortFavorites(favorites []Favorite, by string) error {
+	var less func(a, b Favorite) bool
+
+	switch by {
+	case SortByAdded, "":
+		less = func(a, b Favorite) bool {
+			return parseTime(a.AddedAt).Before(parseTime(b.AddedAt))
+		}
+	case SortByName:
+		less = func(a, b Favorite) bool {
+			return strings.ToLower(a.Command) < strings.ToLower(b.Command)
+		}
+	case SortByCategory:
+		less = func(a, b Favorite) bool {
+			if a.Category != b.Category {
+				return a.Category < b.Category
+			}
+			return strings.ToLower(a.Command) < strings.ToLower(b.Command)
+		}
+	case SortByUsage:
+		less = func(a, b Favorite) bool {
+			if a.UseCount != b.UseCount {
+				return a.UseCount > b.UseCount
+			}
+			return strings.ToLower(a.Command) < strings.ToLower(b.Command)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 정렬 기준입니다: %s (사용 가능: %s)", by, strings.Join(SortKeys, ", "))
+	}
+
+	sort.SliceStable(favorites, func(i, j int) bool {
+		return less(favorites[i], favorites[j])
+	})
+	return nil
 }
 
 // FormatFavorites formats favorites for display
@@ -156,13 +452,37 @@ func FormatFavorites(favorites []Favorite, lang string) string {
 	}
 
 	for i, fav := range favorites {
-		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, fav.Command))
+		output.WriteString(fmt.Sprintf("%d. %s", i+1, style.Command(fav.Command)))
+		if fav.Key != "" {
+			output.WriteString(fmt.Sprintf(" (%s)", fav.Key))
+		}
+		output.WriteString("\n")
 		if lang == "en" {
 			output.WriteString(fmt.Sprintf("   Description: %s\n", fav.Description))
-			output.WriteString(fmt.Sprintf("   Added: %s\n", fav.AddedAt))
+			if fav.Category != "" {
+				output.WriteString(fmt.Sprintf("   Category: %s\n", fav.Category))
+			}
+			if len(fav.Tags) > 0 {
+				output.WriteString(fmt.Sprintf("   Tags: %s\n", strings.Join(fav.Tags, ", ")))
+			}
+			if fav.Note != "" {
+				output.WriteString(fmt.Sprintf("   Note: %s\n", fav.Note))
+			}
+			output.WriteString(fmt.Sprintf("   Added: %s\n", formatTime(fav.AddedAt)))
+			output.WriteString(fmt.Sprintf("   Used: %d time(s)\n", fav.UseCount))
 		} else {
 			output.WriteString(fmt.Sprintf("   설명: %s\n", fav.Description))
-			output.WriteString(fmt.Sprintf("   추가일: %s\n", fav.AddedAt))
+			if fav.Category != "" {
+				output.WriteString(fmt.Sprintf("   카테고리: %s\n", fav.Category))
+			}
+			if len(fav.Tags) > 0 {
+				output.WriteString(fmt.Sprintf("   태그: %s\n", strings.Join(fav.Tags, ", ")))
+			}
+			if fav.Note != "" {
+				output.WriteString(fmt.Sprintf("   메모: %s\n", fav.Note))
+			}
+			output.WriteString(fmt.Sprintf("   추가일: %s\n", formatTime(fav.AddedAt)))
+			output.WriteString(fmt.Sprintf("   사용 횟수: %d회\n", fav.UseCount))
 		}
 		output.WriteString("\n")
 	}
@@ -0,0 +1,489 @@
+package favorites
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestManager(t *testing.T) *FavoritesManager {
+	t.Helper()
+	dir := t.TempDir()
+	fm, err := NewFavoritesManagerAt(filepath.Join(dir, "data", "favorites.json"), filepath.Join(dir, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fm
+}
+
+func commandsOf(t *testing.T, fm *FavoritesManager) string {
+	t.Helper()
+	list, err := fm.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range list {
+		names = append(names, f.Command)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "favorites.json")
+
+	for _, content := range []string{"[]", `[{"command":"dd"}]`} {
+		if err := writeFileAtomic(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Fatalf("read back %q, %v; want %q", data, err, content)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("permissions = %o, want 600", perm)
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+
+	// A failed write leaves the old file alone
+	if err := writeFileAtomic(filepath.Join(dir, "missing", "favorites.json"), []byte("[]"), 0644); err == nil {
+		t.Error("writing into a missing directory should fail")
+	}
+}
+
+func TestWithLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+
+	held := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		withLock(path, func() error {
+			close(held)
+			<-release
+			return nil
+		})
+	}()
+	<-held
+
+	go func() {
+		withLock(path, func() error { return nil })
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("second withLock ran while the lock was held")
+	default:
+	}
+	close(release)
+	<-done
+
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("lock file: %v", err)
+	}
+	if err := withLock(path, func() error { return fmt.Errorf("boom") }); err == nil || err.Error() != "boom" {
+		t.Errorf("withLock error = %v, want the callback's error", err)
+	}
+}
+
+// Two writers with their own managers, like two processes, must not lose updates
+func TestConcurrentWriters(t *testing.T) {
+	fm := newTestManager(t)
+	const perWriter = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*perWriter)
+	for w := 0; w < 2; w++ {
+		writer, err := NewFavoritesManagerAt(fm.filePath, fm.stateDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				errs <- writer.Add(Favorite{Command: fmt.Sprintf("w%d-%d", w, i)})
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := fm.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2*perWriter {
+		t.Errorf("got %d favorites after concurrent writes, want %d", len(list), 2*perWriter)
+	}
+}
+
+func TestBackupRecovery(t *testing.T) {
+	fm := newTestManager(t)
+	if err := fm.Add(Favorite{Command: "dd"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.Add(Favorite{Command: "yy"}); err != nil {
+		t.Fatal(err)
+	}
+	// The backup holds the file as it was before the last save
+	backup, err := ioutil.ReadFile(fm.backupPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(backup), `"dd"`) || strings.Contains(string(backup), `"yy"`) {
+		t.Fatalf("backup = %s, want only dd", backup)
+	}
+
+	if err := ioutil.WriteFile(fm.filePath, []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := commandsOf(t, fm); got != "dd" {
+		t.Errorf("recovered favorites = %q, want dd", got)
+	}
+	corrupt, _ := filepath.Glob(filepath.Join(fm.stateDir, "favorites.json.corrupt-*"))
+	if len(corrupt) != 1 {
+		t.Errorf("corrupted file not kept: %v", corrupt)
+	}
+
+	// Without a usable backup the error is reported instead
+	os.Remove(fm.backupPath())
+	ioutil.WriteFile(fm.filePath, []byte("{broken"), 0644)
+	if _, err := fm.List(); err == nil {
+		t.Error("List of a broken file without a backup should fail")
+	}
+}
+
+// A file that is JSON but not a favorites list must not replace the last good backup
+func TestBackupKeepsLastGoodCopy(t *testing.T) {
+	fm := newTestManager(t)
+	if err := fm.Add(Favorite{Command: "dd"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.Add(Favorite{Command: "yy"}); err != nil {
+		t.Fatal(err)
+	}
+	good, err := ioutil.ReadFile(fm.backupPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, broken := range []string{`{"x":1}`, `[{"description":"no command"}]`, `"text"`} {
+		if err := ioutil.WriteFile(fm.filePath, []byte(broken), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := withLock(fm.filePath, func() error {
+			return fm.saveFavorites([]Favorite{{Command: "x"}})
+		}); err != nil {
+			t.Fatal(err)
+		}
+		backup, _ := ioutil.ReadFile(fm.backupPath())
+		if string(backup) != string(good) {
+			t.Errorf("saving over %s replaced the backup with %s", broken, backup)
+		}
+	}
+
+	// An empty list is a valid file and does become the backup
+	if err := fm.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.Add(Favorite{Command: "p"}); err != nil {
+		t.Fatal(err)
+	}
+	if backup, _ := ioutil.ReadFile(fm.backupPath()); strings.TrimSpace(string(backup)) != "[]" {
+		t.Errorf("backup after clearing = %s, want []", backup)
+	}
+}
+
+// Backticks that are part of a command survive a Markdown round trip
+func TestMarkdownRoundTrip(t *testing.T) {
+	in := []Favorite{{Command: "``"}, {Command: "`a"}, {Command: "dd"}}
+	var buf strings.Builder
+	if err := Export(&buf, in, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	out, _, err := Parse(strings.NewReader(buf.String()), FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("parsed %d favorites, want %d", len(out), len(in))
+	}
+	for i := range in {
+		if out[i].Command != in[i].Command {
+			t.Errorf("command %d = %q, want %q", i, out[i].Command, in[i].Command)
+		}
+	}
+
+	// Hand-written tables may leave the command unquoted
+	out, _, err = Parse(strings.NewReader("| Command |\n|---|\n| dd |\n"), FormatMarkdown)
+	if err != nil || len(out) != 1 || out[0].Command != "dd" {
+		t.Errorf("unquoted command = %v, %v", out, err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	fm := newTestManager(t)
+	if err := fm.Add(Favorite{Command: "dd", Note: "mine"}); err != nil {
+		t.Fatal(err)
+	}
+	incoming := []Favorite{{Command: "dd", Note: "theirs"}, {Command: "yy"}}
+
+	result, err := fm.Import(incoming, MergeSkip, "")
+	if err != nil || result.Added != 1 || result.Skipped != 1 {
+		t.Fatalf("skip: %+v, %v", result, err)
+	}
+	result, err = fm.Import(incoming, MergeOverwrite, "")
+	if err != nil || result.Overwritten != 2 {
+		t.Fatalf("overwrite: %+v, %v", result, err)
+	}
+	list, _ := fm.List()
+	if len(list) != 2 || list[0].Note != "theirs" {
+		t.Errorf("after overwrite = %+v, want one dd with the imported note", list)
+	}
+	if _, err := fm.Import(incoming, "merge", ""); err == nil {
+		t.Error("unknown strategy should fail")
+	}
+}
+
+// Both copies of a command stay reachable, each under its own name
+func TestImportBoth(t *testing.T) {
+	fm := newTestManager(t)
+	if err := fm.Add(Favorite{Command: "dd", Note: "mine"}); err != nil {
+		t.Fatal(err)
+	}
+	incoming := []Favorite{{Command: "dd", Note: "theirs"}, {Command: "yy"}}
+
+	result, err := fm.Import(incoming, MergeBoth, "team")
+	if err != nil || result.Added != 1 || result.Duplicated != 1 {
+		t.Fatalf("both: %+v, %v", result, err)
+	}
+	if got := commandsOf(t, fm); got != "dd,dd,yy" {
+		t.Fatalf("favorites = %s, want dd,dd,yy", got)
+	}
+	list, _ := fm.List()
+	mine, theirs := list[0], list[1]
+	if mine.ID() != "dd" || len(mine.Tags) != 0 || mine.Note != "mine" {
+		t.Errorf("existing favorite changed: %+v", mine)
+	}
+	if theirs.ID() != "dd@team" || theirs.Note != "theirs" || strings.Join(theirs.Tags, ",") != "team" {
+		t.Errorf("imported copy = %+v, want key dd@team tagged team", theirs)
+	}
+
+	// Importing again gets a new key instead of colliding with the first copy
+	if _, err := fm.Import(incoming, MergeBoth, "team"); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = fm.List()
+	if len(list) != 5 || list[3].ID() != "dd@team2" || list[4].ID() != "yy@team" {
+		t.Errorf("second import = %+v, want dd@team2 and yy@team added", list)
+	}
+
+	// Each copy is modified and removed by its own name
+	if err := fm.SetNote("dd@team", "copy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.Remove("dd"); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = fm.List()
+	if len(list) != 4 || list[0].ID() != "dd@team" || list[0].Note != "copy" {
+		t.Errorf("after removing dd = %+v", list)
+	}
+	if err := fm.Add(Favorite{Command: "dd"}); err != nil {
+		t.Errorf("dd can be added again after removing it: %v", err)
+	}
+
+	// The default tag is used when none is given, and the key survives a CSV round trip
+	result, err = fm.Import([]Favorite{{Command: "yy"}}, MergeBoth, " ")
+	if err != nil || result.Duplicated != 1 {
+		t.Fatalf("default tag: %+v, %v", result, err)
+	}
+	list, _ = fm.List()
+	var buf strings.Builder
+	if err := Export(&buf, list, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, err := Parse(strings.NewReader(buf.String()), FormatCSV)
+	if err != nil || parsed[len(parsed)-1].ID() != "yy@"+DefaultConflictTag {
+		t.Errorf("CSV round trip = %+v, %v", parsed, err)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := map[string]time.Time{
+		"2024-03-01 09:00:00":       time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local), // legacy, local time
+		"2024-03-01T09:00:00Z":      time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		"2024-03-01T09:00:00+09:00": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"yesterday":                 {},
+		"":                          {},
+	}
+	for value, want := range tests {
+		if got := parseTime(value); !got.Equal(want) {
+			t.Errorf("parseTime(%q) = %v, want %v", value, got, want)
+		}
+	}
+	if got := formatTime("yesterday"); got != "yesterday" {
+		t.Errorf("formatTime of an unparseable value = %q, want it unchanged", got)
+	}
+}
+
+func TestSortFavorites(t *testing.T) {
+	// Files written by older versions mix the legacy and RFC3339 formats
+	fm := newTestManager(t)
+	data := `[
+  {"command": "yy", "category": "copy", "added_at": "2024-03-01 09:00:00", "use_count": 2},
+  {"command": "dd", "category": "delete", "added_at": "2024-01-15T08:00:00Z", "use_count": 5},
+  {"command": "P", "category": "paste", "added_at": "2024-02-10T12:00:00+09:00", "use_count": 2},
+  {"command": "x", "category": "delete", "added_at": "2023-12-31 23:00:00", "use_count": 0}
+]`
+	if err := ioutil.WriteFile(fm.filePath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ by, want string }{
+		{SortByAdded, "x,dd,P,yy"},
+		{"", "x,dd,P,yy"},
+		{SortByName, "dd,P,x,yy"},     // case-insensitive
+		{SortByCategory, "yy,dd,x,P"}, // then by name within a category
+		{SortByUsage, "dd,P,yy,x"},    // most used first, ties by name
+	}
+	for _, tt := range tests {
+		list, err := fm.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := SortFavorites(list, tt.by); err != nil {
+			t.Fatalf("SortFavorites(%q): %v", tt.by, err)
+		}
+		var names []string
+		for _, f := range list {
+			names = append(names, f.Command)
+		}
+		if got := strings.Join(names, ","); got != tt.want {
+			t.Errorf("SortFavorites(%q) = %s, want %s", tt.by, got, tt.want)
+		}
+	}
+
+	if err := SortFavorites(nil, "popularity"); err == nil {
+		t.Error("unknown sort key should fail")
+	}
+}
+
+func TestTags(t *testing.T) {
+	fm := newTestManager(t)
+	if err := fm.Add(Favorite{Command: "dd", Tags: []string{" Git ", "git", "", "Edit"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fm.Add(Favorite{Command: "yy"}); err != nil {
+		t.Fatal(err)
+	}
+	tagsOf := func(command string) string {
+		list, err := fm.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range list {
+			if f.ID() == command {
+				return strings.Join(f.Tags, ",")
+			}
+		}
+		t.Fatalf("%s is not a favorite", command)
+		return ""
+	}
+
+	if got := tagsOf("dd"); got != "git,edit" {
+		t.Errorf("tags after Add = %s, want git,edit", got)
+	}
+	if err := fm.AddTags("dd", "EDIT", "vim "); err != nil {
+		t.Fatal(err)
+	}
+	if got := tagsOf("dd"); got != "git,edit,vim" {
+		t.Errorf("tags after AddTags = %s, want git,edit,vim", got)
+	}
+	if err := fm.RemoveTags("dd", " GIT", "missing"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tagsOf("dd"); got != "edit,vim" {
+		t.Errorf("tags after RemoveTags = %s, want edit,vim", got)
+	}
+	if err := fm.AddTags("zz", "git"); err == nil {
+		t.Error("tagging a command that is not a favorite should fail")
+	}
+
+	list, _ := fm.List()
+	if got := FilterByTag(list, " VIM "); len(got) != 1 || got[0].Command != "dd" {
+		t.Errorf("FilterByTag(VIM) = %+v, want dd", got)
+	}
+	if got := FilterByTag(list, "git"); len(got) != 0 {
+		t.Errorf("FilterByTag(git) = %+v, want none after removing the tag", got)
+	}
+	if got := FilterByTag(list, " "); len(got) != 2 {
+		t.Errorf("FilterByTag with an empty tag = %+v, want every favorite", got)
+	}
+}
+
+func TestSetNoteAndRecordUse(t *testing.T) {
+	fm := newTestManager(t)
+	for _, command := range []string{"dd", "yy"} {
+		if err := fm.Add(Favorite{Command: command}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fm.SetNote("dd", "  delete a line  "); err != nil {
+		t.Fatal(err)
+	}
+	list, _ := fm.List()
+	if list[0].Note != "delete a line" {
+		t.Errorf("note = %q, want it trimmed", list[0].Note)
+	}
+	if err := fm.SetNote("dd", ""); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ = fm.List(); list[0].Note != "" {
+		t.Errorf("note = %q, want it removed", list[0].Note)
+	}
+	if err := fm.SetNote("zz", "note"); err == nil {
+		t.Error("SetNote on a command that is not a favorite should fail")
+	}
+
+	for i := 0; i < 2; i++ {
+		if found, err := fm.RecordUse("yy"); !found || err != nil {
+			t.Fatalf("RecordUse(yy) = %v, %v", found, err)
+		}
+	}
+	if found, err := fm.RecordUse("zz"); found || err != nil {
+		t.Errorf("RecordUse(zz) = %v, %v; want false without an error", found, err)
+	}
+	list, _ = fm.List()
+	if list[0].UseCount != 0 || list[0].LastUsedAt != "" {
+		t.Errorf("dd = %+v, want it unused", list[0])
+	}
+	if list[1].UseCount != 2 || parseTime(list[1].LastUsedAt).IsZero() {
+		t.Errorf("yy = %+v, want two uses and a last use time", list[1])
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package favorites
+
+import "os"
+
+// lockFile does nothing on platforms without advisory file locks
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile does nothing
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,449 @@
+package favorites
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Export/import formats
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the supported export/import formats
+var Formats = []string{FormatJSON, FormatCSV, FormatMarkdown}
+
+// Merge strategies used by Import when a command is already a favorite
+const (
+	MergeSkip      = "skip"      // keep the existing favorite
+	MergeOverwrite = "overwrite" // replace it with the imported one
+	MergeBoth      = "both"      // keep both, tagging the imported copy
+)
+
+// MergeStrategies lists the valid merge strategies
+var MergeStrategies = []string{MergeSkip, MergeOverwrite, MergeBoth}
+
+// DefaultConflictTag is the tag given to imported copies under MergeBoth
+const DefaultConflictTag = "imported"
+
+// csvHeader is the column order used for CSV export and import
+var csvHeader = []string{"command", "description", "category", "tags", "note", "added_at", "use_count", "last_used_at", "key"}
+
+// TeamSet is a shareable, read-only set of favorites meant to be kept
+// in version control (e.g. a dotfiles repository)
+type TeamSet struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Favorites   []Favorite `json:"favorites"`
+}
+
+// ImportResult summarizes what Import did
+type ImportResult struct {
+	Added       int
+	Skipped     int
+	Overwritten int
+	Duplicated  int
+}
+
+// FormatFromPath guesses the format from a file extension, defaulting to JSON
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".md", ".markdown":
+		return FormatMarkdown
+	default:
+		return FormatJSON
+	}
+}
+
+// normalizeFormat accepts "md" as an alias for markdown and validates the name
+func normalizeFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "md" {
+		format = FormatMarkdown
+	}
+	for _, f := range Formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("지원하지 않는 형식입니다: %s (사용 가능: %s)", format, strings.Join(Formats, ", "))
+}
+
+// Export writes favorites to w in the given format
+func Export(w io.Writer, favorites []Favorite, format string) error {
+	format, err := normalizeFormat(format)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, favorites)
+	case FormatMarkdown:
+		return exportMarkdown(w, favorites)
+	default:
+		return exportJSON(w, favorites)
+	}
+}
+
+// ExportTeamSet writes favorites as a named team set in JSON
+func ExportTeamSet(w io.Writer, name, description string, favorites []Favorite) error {
+	// Personal usage data does not belong in a shared file
+	shared := make([]Favorite, len(favorites))
+	for i, fav := range favorites {
+		fav.UseCount = 0
+		fav.LastUsedAt = ""
+		shared[i] = fav
+	}
+
+	data, err := json.MarshalIndent(TeamSet{Name: name, Description: description, Favorites: shared}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func exportJSON(w io.Writer, favorites []Favorite) error {
+	if favorites == nil {
+		favorites = []Favorite{}
+	}
+	data, err := json.MarshalIndent(favorites, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func exportCSV(w io.Writer, favorites []Favorite) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, fav := range favorites {
+		record := []string{
+			fav.Command,
+			fav.Description,
+			fav.Category,
+			strings.Join(fav.Tags, ";"),
+			fav.Note,
+			fav.AddedAt,
+			strconv.Itoa(fav.UseCount),
+			fav.LastUsedAt,
+			fav.Key,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportMarkdown(w io.Writer, favorites []Favorite) error {
+	var output strings.Builder
+	output.WriteString("| Command | Description | Category | Tags | Note | Added | Uses |\n")
+	output.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, fav := range favorites {
+		cells := []string{
+			"`" + fav.Command + "`",
+			fav.Description,
+			fav.Category,
+			strings.Join(fav.Tags, ", "),
+			fav.Note,
+			fav.AddedAt,
+			strconv.Itoa(fav.UseCount),
+		}
+		for i := range cells {
+			cells[i] = escapeMarkdownCell(cells[i])
+		}
+		output.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+// escapeMarkdownCell escapes characters that would break a table row
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// Parse reads favorites in the given format.
+// A JSON team set is accepted as well; its favorites are tagged "team:<name>".
+func Parse(r io.Reader, format string) ([]Favorite, *TeamSet, error) {
+	format, err := normalizeFormat(format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch format {
+	case FormatCSV:
+		favs, err := parseCSV(r)
+		return favs, nil, err
+	case FormatMarkdown:
+		favs, err := parseMarkdown(r)
+		return favs, nil, err
+	default:
+		return parseJSON(r)
+	}
+}
+
+func parseJSON(r io.Reader) ([]Favorite, *TeamSet, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A team set is a JSON object; a plain export is an array
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var set TeamSet
+		if err := json.Unmarshal(trimmed, &set); err != nil {
+			return nil, nil, fmt.Errorf("팀 즐겨찾기 파일 파싱 오류: %v", err)
+		}
+		if strings.TrimSpace(set.Name) == "" {
+			return nil, nil, fmt.Errorf("팀 즐겨찾기 파일에 name이 없습니다")
+		}
+		teamTag := "team:" + set.Name
+		for i := range set.Favorites {
+			set.Favorites[i].Tags = append(set.Favorites[i].Tags, teamTag)
+		}
+		return set.Favorites, &set, nil
+	}
+
+	favorites, err := parseFavorites(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("JSON 파싱 오류: %v", err)
+	}
+	return favorites, nil, nil
+}
+
+func parseCSV(r io.Reader) ([]Favorite, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 파싱 오류: %v", err)
+	}
+	if len(records) == 0 {
+		return []Favorite{}, nil
+	}
+
+	// Map header names to column indexes so column order does not matter
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["command"]; !ok {
+		return nil, fmt.Errorf("CSV 헤더에 command 열이 없습니다")
+	}
+
+	var favorites []Favorite
+	for line, record := range records[1:] {
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		fav := Favorite{
+			Command:     get("command"),
+			Description: get("description"),
+			Category:    get("category"),
+			Note:        get("note"),
+			AddedAt:     get("added_at"),
+			LastUsedAt:  get("last_used_at"),
+			Key:         get("key"),
+		}
+		if fav.Command == "" {
+			return nil, fmt.Errorf("CSV %d번째 줄: command가 비어 있습니다", line+2)
+		}
+		if tags := get("tags"); tags != "" {
+			fav.Tags = strings.Split(tags, ";")
+		}
+		if uses := get("use_count"); uses != "" {
+			if fav.UseCount, err = strconv.Atoi(uses); err != nil {
+				return nil, fmt.Errorf("CSV %d번째 줄: use_count가 숫자가 아닙니다: %s", line+2, uses)
+			}
+		}
+		favorites = append(favorites, fav)
+	}
+
+	return favorites, nil
+}
+
+func parseMarkdown(r io.Reader) ([]Favorite, error) {
+	var favorites []Favorite
+	var columns map[string]int
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		cells := splitMarkdownRow(line)
+
+		// The first table row is the header, the second the separator
+		if columns == nil {
+			columns = make(map[string]int)
+			for i, name := range cells {
+				columns[strings.ToLower(name)] = i
+			}
+			if _, ok := columns["command"]; !ok {
+				return nil, fmt.Errorf("Markdown 표 헤더에 Command 열이 없습니다")
+			}
+			continue
+		}
+		if strings.Trim(strings.Join(cells, ""), "-: ") == "" {
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(cells) {
+				return cells[i]
+			}
+			return ""
+		}
+
+		fav := Favorite{
+			Command:     unquoteCode(get("command")),
+			Description: get("description"),
+			Category:    get("category"),
+			Note:        get("note"),
+			AddedAt:     get("added"),
+		}
+		if fav.Command == "" {
+			return nil, fmt.Errorf("Markdown %d번째 줄: Command가 비어 있습니다", lineNo)
+		}
+		if tags := get("tags"); tags != "" {
+			fav.Tags = strings.Split(tags, ",")
+		}
+		if uses := get("uses"); uses != "" {
+			fav.UseCount, _ = strconv.Atoi(uses)
+		}
+		favorites = append(favorites, fav)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return favorites, nil
+}
+
+// unquoteCode removes the one pair of backticks exportMarkdown puts around a
+// command, keeping backticks that belong to the command itself (e.g. ``)
+func unquoteCode(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, "`") && strings.HasSuffix(s, "`") {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// splitMarkdownRow splits a table row into trimmed cells, honoring \| escapes
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// Import merges incoming favorites into the favorites file.
+// Under MergeBoth an imported copy of an existing command is tagged with
+// conflictTag and kept under its own key, e.g. "dd@imported", so both stay
+// reachable by name.
+func (fm *FavoritesManager) Import(incoming []Favorite, strategy, conflictTag string) (*ImportResult, error) {
+	switch strategy {
+	case MergeSkip, MergeOverwrite, MergeBoth:
+	default:
+		return nil, fmt.Errorf("알 수 없는 병합 방식입니다: %s (사용 가능: %s)", strategy, strings.Join(MergeStrategies, ", "))
+	}
+	conflictTag = strings.TrimSpace(conflictTag)
+	if conflictTag == "" {
+		conflictTag = DefaultConflictTag
+	}
+
+	result := &ImportResult{}
+	err := fm.update(func(favorites []Favorite) ([]Favorite, error) {
+		index := make(map[string]int)
+		for i, fav := range favorites {
+			index[fav.ID()] = i
+		}
+
+		for _, fav := range incoming {
+			fav.Command = strings.TrimSpace(fav.Command)
+			if fav.Command == "" {
+				continue
+			}
+			fav.Key = strings.TrimSpace(fav.Key)
+			fav.Tags = normalizeTags(fav.Tags)
+			if parseTime(fav.AddedAt).IsZero() {
+				fav.AddedAt = getCurrentTime()
+			}
+
+			i, exists := index[fav.ID()]
+			switch {
+			case !exists:
+				index[fav.ID()] = len(favorites)
+				favorites = append(favorites, fav)
+				result.Added++
+			case strategy == MergeSkip:
+				result.Skipped++
+			case strategy == MergeOverwrite:
+				favorites[i] = fav
+				result.Overwritten++
+			default:
+				fav.Tags = normalizeTags(append(fav.Tags, conflictTag))
+				fav.Key = copyKey(fav.Command+"@"+conflictTag, index)
+				index[fav.Key] = len(favorites)
+				favorites = append(favorites, fav)
+				result.Duplicated++
+			}
+		}
+
+		return favorites, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// copyKey returns key, or key with the first free number appended when it is taken
+func copyKey(key string, taken map[string]int) string {
+	if _, exists := taken[key]; !exists {
+		return key
+	}
+	for n := 2; ; n++ {
+		numbered := fmt.Sprintf("%s%d", key, n)
+		if _, exists := taken[numbered]; !exists {
+			return numbered
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Package hangul reads text typed with a Korean input method in Hangul mode
+// back as the Latin keys that were pressed. Typing hjkl in Hangul mode gives
+// ㅗㅓㅏㅣ, and dd gives ㅇㅇ; this package turns them into hjkl and dd again
+// for the 2-set (dubeolsik) and 3-set (sebeolsik 390) layouts.
+package hangul
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Layouts a Hangul input is read back on
+const (
+	TwoSet   = "2-set" // 두벌식, the standard layout
+	ThreeSet = "3-set" // 세벌식 390
+)
+
+// Layouts lists the layouts in the order they are tried
+var Layouts = []string{TwoSet, ThreeSet}
+
+// Dubeolsik maps the keys of a QWERTY keyboard to the jamo they type in the
+// 2-set layout. Shift only changes q, w, e, r, t, o and p.
+var Dubeolsik = map[string]string{
+	"q": "ㅂ", "w": "ㅈ", "e": "ㄷ", "r": "ㄱ", "t": "ㅅ", "y": "ㅛ", "u": "ㅕ", "i": "ㅑ", "o": "ㅐ", "p": "ㅔ",
+	"a": "ㅁ", "s": "ㄴ", "d": "ㅇ", "f": "ㄹ", "g": "ㅎ", "h": "ㅗ", "j": "ㅓ", "k": "ㅏ", "l": "ㅣ",
+	"z": "ㅋ", "x": "ㅌ", "c": "ㅊ", "v": "ㅍ", "b": "ㅠ", "n": "ㅜ", "m": "ㅡ",
+	"Q": "ㅃ", "W": "ㅉ", "E": "ㄸ", "R": "ㄲ", "T": "ㅆ", "O": "ㅒ", "P": "ㅖ",
+}
+
+// twoSetCompound holds the jamo typed with two keys in the 2-set layout:
+// compound vowels and the consonant clusters of a final
+var twoSetCompound = map[string]string{
+	"ㅘ": "hk", "ㅙ": "ho", "ㅚ": "hl", "ㅝ": "nj", "ㅞ": "np", "ㅟ": "nl", "ㅢ": "ml",
+	"ㄳ": "rt", "ㄵ": "sw", "ㄶ": "sg", "ㄺ": "fr", "ㄻ": "fa", "ㄼ": "fq",
+	"ㄽ": "ft", "ㄾ": "fx", "ㄿ": "fv", "ㅀ": "fg", "ㅄ": "qt",
+}
+
+// twoSetKeys maps every jamo to its keys in the 2-set layout,
+// where the same key types a consonant at the start or the end of a syllable
+var twoSetKeys = func() map[string]string {
+	keys := make(map[string]string)
+	for key, jamo := range Dubeolsik {
+		keys[jamo] = key
+	}
+	for jamo, k := range twoSetCompound {
+		keys[jamo] = k
+	}
+	return keys
+}()
+
+// The 3-set layout has separate keys for initial consonants, vowels and final
+// consonants. Doubled initials are typed by pressing the key twice; finals
+// missing from the table have no key of their own in 390.
+var (
+	threeSetInitial = map[string]string{
+		"ㄱ": "k", "ㄲ": "kk", "ㄴ": "h", "ㄷ": "u", "ㄸ": "uu", "ㄹ": "y", "ㅁ": "i", "ㅂ": ";", "ㅃ": ";;",
+		"ㅅ": "n", "ㅆ": "nn", "ㅇ": "j", "ㅈ": "l", "ㅉ": "ll", "ㅊ": "o", "ㅋ": "0", "ㅌ": "'", "ㅍ": "p", "ㅎ": "m",
+	}
+	threeSetMedial = map[string]string{
+		"ㅏ": "f", "ㅐ": "r", "ㅑ": "6", "ㅒ": "R", "ㅓ": "t", "ㅔ": "c", "ㅕ": "e", "ㅖ": "7", "ㅗ": "v", "ㅘ": "/f",
+		"ㅙ": "/r", "ㅚ": "/d", "ㅛ": "4", "ㅜ": "b", "ㅝ": "9t", "ㅞ": "9c", "ㅟ": "9d", "ㅠ": "5", "ㅡ": "g", "ㅢ": "8", "ㅣ": "d",
+	}
+	threeSetFinal = map[string]string{
+		"ㄱ": "x", "ㄲ": "F", "ㄳ": "V", "ㄴ": "s", "ㄵ": "E", "ㄶ": "S", "ㄷ": "A", "ㄹ": "w", "ㄺ": "D", "ㄻ": "wz",
+		"ㄼ": "w3", "ㄽ": "wq", "ㄾ": "wW", "ㄿ": "wQ", "ㅀ": "w1", "ㅁ": "z", "ㅂ": "3", "ㅄ": "X", "ㅅ": "q",
+		"ㅆ": "2", "ㅇ": "a", "ㅊ": "Z", "ㅋ": "C", "ㅌ": "W", "ㅍ": "Q", "ㅎ": "1",
+	}
+)
+
+// The jamo of a syllable, in Unicode's composition order (compatibility forms)
+var (
+	initials = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+	medials  = []rune("ㅏㅐㅑㅒㅓㅔㅕㅖㅗㅘㅙㅚㅛㅜㅝㅞㅟㅠㅡㅢㅣ")
+	finals   = []rune("ㄱㄲㄳㄴㄵㄶㄷㄹㄺㄻㄼㄽㄾㄿㅀㅁㅂㅄㅅㅆㅇㅈㅊㅋㅌㅍㅎ")
+)
+
+// Positions of a jamo within a syllable
+const (
+	initial = iota
+	medial
+	final
+	consonant // a lone consonant, which the 3-set layout types as an initial or a final
+)
+
+// jamo is one letter of the input with its position
+type jamo struct {
+	letter   string
+	position int
+}
+
+// Contains reports whether s holds Hangul syllables or jamo
+func Contains(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return unicode.Is(unicode.Hangul, r) }) >= 0
+}
+
+// OnlyJamo reports whether s has Hangul and all of it is standalone jamo
+// such as ㅗㅓ, not syllables such as 종료. Keys typed in Hangul mode by
+// mistake are mostly jamo, while syllables are usually a real Korean word.
+func OnlyJamo(s string) bool {
+	if !Contains(s) {
+		return false
+	}
+	for _, r := range s {
+		if r >= 0xAC00 && r <= 0xD7A3 {
+			return false
+		}
+	}
+	return true
+}
+
+// split breaks a Hangul character into its jamo, or returns nil for any other character
+func split(r rune) []jamo {
+	switch {
+	case r >= 0xAC00 && r <= 0xD7A3: // composed syllables
+		code := int(r - 0xAC00)
+		parts := []jamo{
+			{string(initials[code/588]), initial},
+			{string(medials[code%588/28]), medial},
+		}
+		if t := code % 28; t > 0 {
+			parts = append(parts, jamo{string(finals[t-1]), final})
+		}
+		return parts
+	case r >= 0x3131 && r <= 0x314E: // compatibility consonants
+		return []jamo{{string(r), consonant}}
+	case r >= 0x314F && r <= 0x3163: // compatibility vowels
+		return []jamo{{string(r), medial}}
+	case r >= 0x1100 && r < 0x1100+rune(len(initials)): // conjoining initials
+		return []jamo{{string(initials[r-0x1100]), initial}}
+	case r >= 0x1161 && r < 0x1161+rune(len(medials)): // conjoining vowels
+		return []jamo{{string(medials[r-0x1161]), medial}}
+	case r >= 0x11A8 && r < 0x11A8+rune(len(finals)): // conjoining finals
+		return []jamo{{string(finals[r-0x11A8]), final}}
+	}
+	return nil
+}
+
+// ToKeys returns the keys pressed on layout to type s in Hangul mode.
+// Other characters are kept as they are. It fails for Hangul the layout
+// cannot type and for an unknown layout.
+func ToKeys(s, layout string) (string, error) {
+	if layout != TwoSet && layout != ThreeSet {
+		return "", fmt.Errorf("알 수 없는 한글 자판입니다: %s (사용 가능: %s)", layout, strings.Join(Layouts, ", "))
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		parts := split(r)
+		if parts == nil {
+			if unicode.Is(unicode.Hangul, r) {
+				return "", fmt.Errorf("%s 자판으로 입력할 수 없는 글자입니다: %c", layout, r)
+			}
+			b.WriteRune(r)
+			continue
+		}
+		for _, part := range parts {
+			keys, ok := "", false
+			switch {
+			case layout == TwoSet:
+				keys, ok = twoSetKeys[part.letter]
+			case part.position == initial:
+				keys, ok = threeSetInitial[part.letter]
+			case part.position == consonant:
+				if keys, ok = threeSetInitial[part.letter]; !ok {
+					keys, ok = threeSetFinal[part.letter]
+				}
+			case part.position == medial:
+				keys, ok = threeSetMedial[part.letter]
+			default:
+				keys, ok = threeSetFinal[part.letter]
+			}
+			if !ok {
+				return "", fmt.Errorf("%s 자판으로 입력할 수 없는 글자입니다: %s", layout, part.letter)
+			}
+			b.WriteString(keys)
+		}
+	}
+	return b.String(), nil
+}
+
+// Guess is a Hangul input read back as the keys pressed on a layout
+type Guess struct {
+	Input  string `json:"input"`
+	Keys   string `json:"keys"`
+	Layout string `json:"layout"`
+}
+
+// Guesses reads s back on every layout that can type it, 2-set first.
+// It returns nil when s has no Hangul.
+func Guesses(s string) []Guess {
+	if !Contains(s) {
+		return nil
+	}
+	var guesses []Guess
+	for _, layout := range Layouts {
+		keys, err := ToKeys(s, layout)
+		if err != nil {
+			continue
+		}
+		if len(guesses) > 0 && guesses[0].Keys == keys {
+			continue
+		}
+		guesses = append(guesses, Guess{Input: s, Keys: keys, Layout: layout})
+	}
+	return guesses
+}
+
+var layoutNames = map[string][2]string{
+	TwoSet:   {"두벌식", "2-set (dubeolsik)"},
+	ThreeSet: {"세벌식 390", "3-set (sebeolsik 390)"},
+}
+
+// LayoutName returns the localized name of a layout
+func LayoutName(layout, lang string) string {
+	names, ok := layoutNames[layout]
+	if !ok {
+		return layout
+	}
+	if lang == "en" {
+		return names[1]
+	}
+	return names[0]
+}
+
+// Warning tells that g was typed in Hangul mode and what was meant
+func (g Guess) Warning(lang string) string {
+	if lang == "en" {
+		return fmt.Sprintf("⚠ It looks like the input method was in Hangul mode: '%s' is '%s' on the %s layout.\n"+
+			"  Switch to Latin input before typing vi commands.\n", g.Input, g.Keys, LayoutName(g.Layout, lang))
+	}
+	return fmt.Sprintf("⚠ 한글 입력 상태에서 입력한 것 같습니다 (%s 자판: '%s' → '%s').\n"+
+		"  vi 명령을 입력할 때는 영문 입력으로 바꾸세요.\n", LayoutName(g.Layout, lang), g.Input, g.Keys)
+}
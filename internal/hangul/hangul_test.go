@@ -0,0 +1,78 @@
+package hangul
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToKeys(t *testing.T) {
+	tests := []struct {
+		input, layout, want string
+	}{
+		{"ㅗㅓㅏㅣ", TwoSet, "hjkl"},
+		{"ㅇㅇ", TwoSet, "dd"},
+		{"ㅃ", TwoSet, "Q"},
+		{"챶", TwoSet, "ciw"},  // ㅊ ㅑ ㅈ composed into one syllable
+		{"ㅢ", TwoSet, "ml"},   // compound vowel
+		{"갃", TwoSet, "rkrt"}, // final cluster
+		{":ㅈㅂ", TwoSet, ":wq"},
+		{"\u1112", TwoSet, "g"}, // conjoining initial
+		{"ㅗ", ThreeSet, "v"},
+		{"ㄷ", ThreeSet, "u"},   // a lone consonant is typed as an initial
+		{"ㄺ", ThreeSet, "D"},   // unless only a final has it
+		{"앟", ThreeSet, "jf1"}, // initial, vowel and final keys
+		{"까", ThreeSet, "kkf"},
+	}
+	for _, tt := range tests {
+		got, err := ToKeys(tt.input, tt.layout)
+		if err != nil {
+			t.Errorf("ToKeys(%q, %s): %v", tt.input, tt.layout, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToKeys(%q, %s) = %q, want %q", tt.input, tt.layout, got, tt.want)
+		}
+	}
+
+	if _, err := ToKeys("ㅇㅇ", "4-set"); err == nil {
+		t.Error("ToKeys with an unknown layout should fail")
+	}
+	if _, err := ToKeys("ㆆ", TwoSet); err == nil {
+		t.Error("ToKeys with an archaic jamo should fail")
+	}
+}
+
+func TestGuesses(t *testing.T) {
+	if g := Guesses("dd"); g != nil {
+		t.Errorf("Guesses without Hangul = %v, want nil", g)
+	}
+
+	g := Guesses("ㅗㅓㅏㅣ")
+	if len(g) != 2 {
+		t.Fatalf("Guesses(ㅗㅓㅏㅣ) = %v, want a 2-set and a 3-set guess", g)
+	}
+	if g[0].Layout != TwoSet || g[0].Keys != "hjkl" {
+		t.Errorf("first guess = %+v, want hjkl on the 2-set layout", g[0])
+	}
+	if g[1].Layout != ThreeSet || g[1].Keys != "vtfd" {
+		t.Errorf("second guess = %+v, want vtfd on the 3-set layout", g[1])
+	}
+}
+
+func TestOnlyJamo(t *testing.T) {
+	for s, want := range map[string]bool{"ㅗㅓㅏㅣ": true, "ㅇㅇ ㅓ": true, "종료": false, "ㅇ야": false, "dd": false, "": false} {
+		if got := OnlyJamo(s); got != want {
+			t.Errorf("OnlyJamo(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestWarning(t *testing.T) {
+	g := Guess{Input: "ㅇㅇ", Keys: "dd", Layout: TwoSet}
+	for lang, want := range map[string]string{"ko": "두벌식", "en": "2-set"} {
+		w := g.Warning(lang)
+		if !strings.Contains(w, "ㅇㅇ") || !strings.Contains(w, "dd") || !strings.Contains(w, want) {
+			t.Errorf("Warning(%s) = %q, want the input, the keys and %q", lang, w, want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package keyboard maps the keys of a keyboard layout to the catalog
+// commands they start in normal mode, and draws the result as an SVG or
+// as text for the terminal.
+package keyboard
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/hangul"
+)
+
+// Key is one key of a layout. Character keys type Base, or Shift with the
+// Shift key held; modifier keys only have a Label. Keys of Korean layouts
+// also hold the jamo they type in Hangul input mode.
+type Key struct {
+	Base        string
+	Shift       string
+	Hangul      string
+	HangulShift string
+	Label       string
+	Width       float64 // in key units; 0 means 1
+}
+
+// Size returns the width of the key in key units
+func (k Key) Size() float64 {
+	if k.Width == 0 {
+		return 1
+	}
+	return k.Width
+}
+
+// Layout is a named list of key rows, top to bottom
+type Layout struct {
+	Name string
+	Rows [][]Key
+}
+
+// chars returns a key per character of base, shifted by the same character of shift
+func chars(base, shift string) []Key {
+	b, s := []rune(base), []rune(shift)
+	keys := make([]Key, len(b))
+	for i := range b {
+		keys[i] = Key{Base: string(b[i]), Shift: string(s[i])}
+	}
+	return keys
+}
+
+// row joins a leading modifier, character keys and a trailing modifier
+func row(lead Key, keys []Key, trail Key) []Key {
+	var r []Key
+	if lead.Label != "" {
+		r = append(r, lead)
+	}
+	r = append(r, keys...)
+	if trail.Label != "" {
+		r = append(r, trail)
+	}
+	return r
+}
+
+// layout builds a layout from the characters of its four rows, which have
+// the modifier keys of a US keyboard around them
+func layout(name string, rows [4][2]string) Layout {
+	return Layout{
+		Name: name,
+		Rows: [][]Key{
+			row(Key{}, chars(rows[0][0], rows[0][1]), Key{Label: "Backspace", Width: 2}),
+			row(Key{Label: "Tab", Width: 1.5}, chars(rows[1][0], rows[1][1]), Key{}),
+			row(Key{Label: "Caps", Width: 1.75}, chars(rows[2][0], rows[2][1]), Key{Label: "Enter", Width: 2.25}),
+			row(Key{Label: "Shift", Width: 2.25}, chars(rows[3][0], rows[3][1]), Key{Label: "Shift", Width: 2.75}),
+		},
+	}
+}
+
+var qwertyRows = [4][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{`qwertyuiop[]\`, "QWERTYUIOP{}|"},
+	{"asdfghjkl;'", `ASDFGHJKL:"`},
+	{"zxcvbnm,./", "ZXCVBNM<>?"},
+}
+
+// QWERTY is the US QWERTY layout
+var QWERTY = layout("qwerty", qwertyRows)
+
+// Dvorak is the US Dvorak layout
+var Dvorak = layout("dvorak", [4][2]string{
+	{"`1234567890[]", "~!@#$%^&*(){}"},
+	{`',.pyfgcrl/=\`, `"<>PYFGCRL?+|`},
+	{"aoeuidhtns-", "AOEUIDHTNS_"},
+	{";qjkxbmwvz", ":QJKXBMWVZ"},
+})
+
+// Colemak is the Colemak layout
+var Colemak = layout("colemak", [4][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{`qwfpgjluy;[]\`, "QWFPGJLUY:{}|"},
+	{"arstdhneio'", `ARSTDHNEIO"`},
+	{"zxcvbkm,./", "ZXCVBKM<>?"},
+})
+
+// Korean is the Korean 2-set layout: a QWERTY keyboard that types jamo in
+// Hangul input mode, where vi commands do nothing until it is switched off
+var Korean = korean()
+
+func korean() Layout {
+	k := layout("korean", qwertyRows)
+	for _, r := range k.Rows {
+		for i := range r {
+			r[i].Hangul = hangul.Dubeolsik[r[i].Base]
+			r[i].HangulShift = hangul.Dubeolsik[r[i].Shift]
+			if r[i].HangulShift == "" {
+				r[i].HangulShift = r[i].Hangul
+			}
+		}
+	}
+	return k
+}
+
+// Layouts lists the layouts by name
+var Layouts = []Layout{QWERTY, Dvorak, Colemak, Korean}
+
+// LayoutNames returns the names of Layouts
+func LayoutNames() []string {
+	names := make([]string, len(Layouts))
+	for i, l := range Layouts {
+		names[i] = l.Name
+	}
+	return names
+}
+
+// LayoutByName returns the layout with the given name
+func LayoutByName(name string) (Layout, error) {
+	for _, l := range Layouts {
+		if l.Name == strings.ToLower(name) {
+			return l, nil
+		}
+	}
+	return Layout{}, fmt.Errorf("알 수 없는 키보드 배치입니다: %s (사용 가능: %s)", name, strings.Join(LayoutNames(), ", "))
+}
+
+// Bindings maps a key, written as in the catalog ("h", "G", "Ctrl+r"),
+// to the command it starts in normal mode
+type Bindings map[string]catalog.Command
+
+// Bind finds the normal-mode command of every key. A command that is the key
+// itself wins; otherwise the key gets the first command it starts, preferring
+// operators ("d{motion}") and then shorter commands ("gg" for g).
+func Bind(commands []catalog.Command) Bindings {
+	bindings := make(Bindings)
+	rank := make(map[string]int)
+	for _, cmd := range commands {
+		if !normalMode(cmd) {
+			continue
+		}
+		key, r := cmd.Command, 0
+		if !strings.HasPrefix(key, "Ctrl+") && utf8.RuneCountInString(key) > 1 {
+			first, size := utf8.DecodeRuneInString(key)
+			key = string(first)
+			r = 2 + utf8.RuneCountInString(cmd.Command)
+			if cmd.Command[size:] == "{motion}" {
+				r = 1
+			}
+		}
+		if prev, bound := rank[key]; bound && prev <= r {
+			continue
+		}
+		bindings[key] = cmd
+		rank[key] = r
+	}
+	return bindings
+}
+
+// normalMode reports whether a command is typed in normal mode.
+// Commands without modes count unless they are Ex commands.
+func normalMode(cmd catalog.Command) bool {
+	if len(cmd.Modes) == 0 {
+		return !strings.HasPrefix(cmd.Command, ":")
+	}
+	for _, mode := range cmd.Modes {
+		if mode == "normal" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package keyboard
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/testenv"
+)
+
+func TestBind(t *testing.T) {
+	bindings := Bind(testenv.Catalog(t))
+
+	tests := map[string]string{
+		"h":      "h",
+		"G":      "G",
+		"$":      "$",
+		"d":      "d{motion}", // operators win over dd and dw
+		"y":      "y{motion}",
+		"g":      "gg",
+		"/":      "/pattern",
+		"Ctrl+r": "Ctrl+r",
+	}
+	for key, want := range tests {
+		if got := bindings[key].Command; got != want {
+			t.Errorf("key %q = %q, want %q", key, got, want)
+		}
+	}
+
+	// Ex commands and commands of other modes are not normal-mode keys
+	for _, key := range []string{":", "E"} {
+		if cmd, ok := bindings[key]; ok {
+			t.Errorf("key %q bound to %q", key, cmd.Command)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	commands := []catalog.Command{
+		{Keyword: "move", Command: "h", Description: "왼쪽 <이동>", Category: "navigation", Modes: []string{"normal"}},
+		{Keyword: "delete", Command: "d{motion}", Description: "삭제 & 복사", Category: "delete", Modes: []string{"normal"}},
+		{Keyword: "paste", Command: "P", Description: "앞에 붙여넣기", Category: "paste", Modes: []string{"normal"}},
+	}
+	var out strings.Builder
+	if err := WriteSVG(&out, QWERTY, Bind(commands), "en"); err != nil {
+		t.Fatal(err)
+	}
+	svg := out.String()
+
+	// The document is well-formed XML
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+	}
+
+	for _, want := range []string{
+		`<title>h: 왼쪽 &lt;이동&gt;</title>`,
+		`>d{motion}</text>`,
+		`>move</text>`,
+		`fill="` + Color("paste") + `"`,
+		"Normal mode keyboard (qwerty)",
+		"🧭 Navigation",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+	if strings.Contains(svg, "📁") {
+		t.Error("legend lists a category without keys")
+	}
+}
+
+func TestLayouts(t *testing.T) {
+	for _, layout := range Layouts {
+		letters := make(map[string]int)
+		for _, r := range layout.Rows {
+			for _, k := range r {
+				if len(k.Base) == 1 && k.Base[0] >= 'a' && k.Base[0] <= 'z' {
+					letters[k.Base]++
+					if k.Shift != strings.ToUpper(k.Base) {
+						t.Errorf("%s: shifted %s types %q", layout.Name, k.Base, k.Shift)
+					}
+				}
+			}
+		}
+		if len(letters) != 26 {
+			t.Errorf("%s has %d letters", layout.Name, len(letters))
+		}
+		for letter, n := range letters {
+			if n != 1 {
+				t.Errorf("%s has %d %s keys", layout.Name, n, letter)
+			}
+		}
+		if got, err := LayoutByName(strings.ToUpper(layout.Name)); err != nil || got.Name != layout.Name {
+			t.Errorf("LayoutByName(%q) = %v, %v", layout.Name, got.Name, err)
+		}
+	}
+	if _, err := LayoutByName("azerty"); err == nil {
+		t.Error("unknown layout accepted")
+	}
+
+	jamo := make(map[string]string)
+	for _, r := range Korean.Rows {
+		for _, k := range r {
+			jamo[k.Base] = k.Hangul
+			jamo[k.Shift] = k.HangulShift
+		}
+	}
+	for key, want := range map[string]string{"h": "ㅗ", "j": "ㅓ", "k": "ㅏ", "l": "ㅣ", "Q": "ㅃ", "H": "ㅗ", "1": ""} {
+		if jamo[key] != want {
+			t.Errorf("korean %s types %q, want %q", key, jamo[key], want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	bindings := Bind(testenv.Catalog(t))
+
+	lower := Text(QWERTY, bindings, LayerLower, "en", false)
+	for _, want := range []string{"qwerty - Unshifted", "🧭 Navigation: ", "Operators (take a motion): y d"} {
+		if !strings.Contains(lower, want) {
+			t.Errorf("lower layer does not contain %q:\n%s", want, lower)
+		}
+	}
+	if strings.Contains(lower, "\033[") || strings.Contains(lower, "Hangul") {
+		t.Errorf("unexpected escapes or Hangul warning:\n%s", lower)
+	}
+	if !strings.Contains(Text(QWERTY, bindings, LayerLower, "en", true), "\033[34m") {
+		t.Error("navigation keys are not colored")
+	}
+
+	ctrl := Text(QWERTY, bindings, LayerCtrl, "en", false)
+	if !strings.Contains(ctrl, "^r") || !strings.Contains(ctrl, "redo") || strings.Contains(ctrl, "^1") {
+		t.Errorf("ctrl layer:\n%s", ctrl)
+	}
+
+	korean := Text(Korean, bindings, LayerLower, "ko", false)
+	if !strings.Contains(korean, "h ㅗ") || !strings.Contains(korean, "한글 입력 상태") {
+		t.Errorf("korean layer:\n%s", korean)
+	}
+}
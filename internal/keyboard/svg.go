@@ -0,0 +1,197 @@
+package keyboard
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/cheatsheet"
+)
+
+// categoryColors are the key fill colors of the well-known categories
+var categoryColors = map[string]string{
+	"file":       "#f4cccc",
+	"mode":       "#fce5cd",
+	"edit":       "#fff2cc",
+	"copy":       "#d9ead3",
+	"paste":      "#d0e0e3",
+	"delete":     "#ead1dc",
+	"navigation": "#cfe2f3",
+	"search":     "#d9d2e9",
+	"help":       "#eeeeee",
+}
+
+// otherColor fills keys whose command is in any other category
+const otherColor = "#e6e6e6"
+
+// Color returns the fill color of a category
+func Color(category string) string {
+	if c, ok := categoryColors[category]; ok {
+		return c
+	}
+	return otherColor
+}
+
+// Key geometry of the SVG in pixels
+const (
+	unit   = 64 // width and height of a 1-unit key including the gap
+	gap    = 4
+	margin = 16
+	top    = 48 // room for the title
+)
+
+var svgLabels = map[string][2]string{
+	"title": {"노멀 모드 키보드 (%s)", "Normal mode keyboard (%s)"},
+	"shift": {"위: Shift, 아래: 기본", "top: Shift, bottom: unshifted"},
+	"jamo":  {"회색 글자: 한글 입력 상태에서 입력되는 자모", "gray: jamo typed in Hangul input mode"},
+}
+
+func svgLabel(key, lang string) string {
+	if lang == "en" {
+		return svgLabels[key][1]
+	}
+	return svgLabels[key][0]
+}
+
+// WriteSVG draws layout with the command of every key. A key with both a
+// plain and a shifted command is split in two halves, each colored by its
+// command's category; the descriptions show as tooltips.
+func WriteSVG(w io.Writer, layout Layout, bindings Bindings, lang string) error {
+	width := 0.0
+	for _, r := range layout.Rows {
+		rowWidth := 0.0
+		for _, k := range r {
+			rowWidth += k.Size()
+		}
+		if rowWidth > width {
+			width = rowWidth
+		}
+	}
+	keysHeight := len(layout.Rows) * unit
+
+	// The legend lists the categories in cheat sheet order, four per line
+	var entries []cheatsheet.Entry
+	seen := make(map[string]bool)
+	for _, r := range layout.Rows {
+		for _, k := range r {
+			for _, key := range []string{k.Base, k.Shift} {
+				if cmd, ok := bindings[key]; ok && key != "" && !seen[cmd.Category] {
+					seen[cmd.Category] = true
+					entries = append(entries, cheatsheet.Entry{Category: cmd.Category})
+				}
+			}
+		}
+	}
+	legend := cheatsheet.Group("", entries, lang).Sections
+	legendHeight := (len(legend) + 3) / 4 * 24
+
+	svgWidth := int(width*unit) + 2*margin
+	svgHeight := top + keysHeight + 16 + legendHeight + margin
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		svgWidth, svgHeight, svgWidth, svgHeight)
+	b.WriteString(`<style>
+.cap { font: bold 13px monospace; fill: #333; }
+.cmd { font: 10px monospace; fill: #000; }
+.mod { font: 11px sans-serif; fill: #777; }
+.jamo { font: 12px sans-serif; fill: #888; }
+.legend { font: 13px sans-serif; fill: #333; }
+</style>
+`)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#fff"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="28" font-size="18" font-weight="bold">%s</text>`+"\n",
+		margin, esc(fmt.Sprintf(svgLabel("title", lang), layout.Name)))
+	note := svgLabel("shift", lang)
+	if hasHangul(layout) {
+		note += " · " + svgLabel("jamo", lang)
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="28" font-size="12" text-anchor="end" fill="#777">%s</text>`+"\n",
+		svgWidth-margin, esc(note))
+
+	for i, r := range layout.Rows {
+		x := float64(margin)
+		y := top + i*unit
+		for _, k := range r {
+			writeKey(&b, k, x, y, bindings)
+			x += k.Size() * unit
+		}
+	}
+
+	for i, section := range legend {
+		x := margin + (i%4)*(svgWidth-2*margin)/4
+		y := top + keysHeight + 16 + (i/4)*24
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="16" height="16" rx="3" fill="%s" stroke="#999"/>`+"\n",
+			x, y, Color(section.Entries[0].Category))
+		fmt.Fprintf(&b, `<text class="legend" x="%d" y="%d">%s</text>`+"\n", x+22, y+13, esc(section.Title))
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeKey draws one key with its top-left corner at x, y
+func writeKey(b *strings.Builder, k Key, x float64, y int, bindings Bindings) {
+	kw := k.Size()*unit - gap
+	kh := float64(unit - gap)
+
+	if k.Label != "" {
+		fmt.Fprintf(b, `<rect x="%.0f" y="%d" width="%.0f" height="%.0f" rx="5" fill="#f7f7f7" stroke="#bbb"/>`+"\n", x, y, kw, kh)
+		fmt.Fprintf(b, `<text class="mod" x="%.0f" y="%.0f">%s</text>`+"\n", x+6, float64(y)+kh-8, esc(k.Label))
+		return
+	}
+
+	base, hasBase := bindings[k.Base]
+	shift, hasShift := bindings[k.Shift]
+	var tooltip []string
+	if hasShift {
+		tooltip = append(tooltip, shift.Command+": "+shift.Description)
+	}
+	if hasBase {
+		tooltip = append(tooltip, base.Command+": "+base.Description)
+	}
+
+	b.WriteString("<g>")
+	if len(tooltip) > 0 {
+		fmt.Fprintf(b, "<title>%s</title>", esc(strings.Join(tooltip, "\n")))
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(b, `<rect x="%.0f" y="%d" width="%.0f" height="%.0f" rx="5" fill="#fff" stroke="#999"/>`+"\n", x, y, kw, kh)
+	half := kh / 2
+	if hasShift {
+		fmt.Fprintf(b, `<rect x="%.0f" y="%d" width="%.0f" height="%.0f" rx="5" fill="%s"/>`+"\n", x+1, y+1, kw-2, half-1, Color(shift.Category))
+	}
+	if hasBase {
+		fmt.Fprintf(b, `<rect x="%.0f" y="%.0f" width="%.0f" height="%.0f" rx="5" fill="%s"/>`+"\n", x+1, float64(y)+half, kw-2, half-1, Color(base.Category))
+	}
+
+	fmt.Fprintf(b, `<text class="cap" x="%.0f" y="%d">%s</text>`+"\n", x+5, y+14, esc(k.Shift))
+	fmt.Fprintf(b, `<text class="cap" x="%.0f" y="%.0f">%s</text>`+"\n", x+5, float64(y)+half+14, esc(k.Base))
+	if k.Hangul != "" {
+		fmt.Fprintf(b, `<text class="jamo" x="%.0f" y="%d" text-anchor="end">%s</text>`+"\n", x+kw-5, y+14, esc(k.HangulShift))
+		fmt.Fprintf(b, `<text class="jamo" x="%.0f" y="%.0f" text-anchor="end">%s</text>`+"\n", x+kw-5, float64(y)+half+14, esc(k.Hangul))
+	}
+	if hasShift {
+		fmt.Fprintf(b, `<text class="cmd" x="%.0f" y="%.0f">%s</text>`+"\n", x+5, float64(y)+half-4, esc(keyLabel(k.Shift, shift)))
+	}
+	if hasBase {
+		fmt.Fprintf(b, `<text class="cmd" x="%.0f" y="%.0f">%s</text>`+"\n", x+5, float64(y)+kh-5, esc(keyLabel(k.Base, base)))
+	}
+	b.WriteString("</g>\n")
+}
+
+// keyLabel names what a key does: the command it starts ("d{motion}"),
+// or the command's keyword when the key is the whole command
+func keyLabel(key string, cmd catalog.Command) string {
+	if cmd.Command != key || cmd.Keyword == "" {
+		return cmd.Command
+	}
+	return cmd.Keyword
+}
+
+func esc(s string) string {
+	return html.EscapeString(s)
+}
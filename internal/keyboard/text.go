@@ -0,0 +1,235 @@
+package keyboard
+
+import (
+	"strings"
+
+	"golang.org/x/text/width"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/cheatsheet"
+)
+
+// Layers of keys drawn by Text
+const (
+	LayerLower = "lower"
+	LayerShift = "shift"
+	LayerCtrl  = "ctrl"
+)
+
+// Layers lists the layers in drawing order
+var Layers = []string{LayerLower, LayerShift, LayerCtrl}
+
+// ansiColors are the terminal colors of the well-known categories
+var ansiColors = map[string]string{
+	"file":       "91",
+	"mode":       "35",
+	"edit":       "33",
+	"copy":       "32",
+	"paste":      "36",
+	"delete":     "31",
+	"navigation": "34",
+	"search":     "94",
+	"help":       "90",
+}
+
+// cellWidth is the width of a key in the terminal, including the space after it
+const cellWidth = 7
+
+var textLabels = map[string][2]string{
+	LayerLower:  {"기본", "Unshifted"},
+	LayerShift:  {"Shift", "Shift"},
+	LayerCtrl:   {"Ctrl", "Ctrl"},
+	"operators": {"연산자 (뒤에 이동 명령)", "Operators (take a motion)"},
+	"hangul": {
+		"한글 입력 상태에서는 h j k l 대신 ㅗ ㅓ ㅏ ㅣ가 입력되어 노멀 모드 명령이 동작하지 않습니다. Esc를 누를 때 영문 입력으로 바꾸세요.",
+		"In Hangul input mode h j k l type ㅗ ㅓ ㅏ ㅣ and normal mode commands do nothing. Switch to Latin input when you press Esc.",
+	},
+}
+
+func textLabel(key, lang string) string {
+	if lang == "en" {
+		return textLabels[key][1]
+	}
+	return textLabels[key][0]
+}
+
+// KeyIn returns what k types in a layer, written as in the catalog
+// ("h", "H", "Ctrl+h"), or "" when the layer has nothing on the key
+func KeyIn(k Key, layer string) string {
+	switch layer {
+	case LayerShift:
+		return k.Shift
+	case LayerCtrl:
+		if len(k.Base) == 1 && k.Base[0] >= 'a' && k.Base[0] <= 'z' {
+			return "Ctrl+" + k.Base
+		}
+		return ""
+	}
+	return k.Base
+}
+
+// Text draws one layer of layout for the terminal: two lines per row of keys,
+// the key and what it does, followed by a legend of the keys per category.
+// With ansi set, keys are colored by category and operators underlined.
+func Text(layout Layout, bindings Bindings, layer, lang string, ansi bool) string {
+	const bold, underline, reset = "\033[1m", "\033[4m", "\033[0m"
+
+	var b strings.Builder
+	title := layout.Name + " - " + textLabel(layer, lang)
+	if ansi {
+		title = bold + title + reset
+	}
+	b.WriteString(title + "\n\n")
+
+	var entries []cheatsheet.Entry
+	var operators []string
+	for _, r := range layout.Rows {
+		var caps, labels strings.Builder
+		indent := 0
+		for _, k := range r {
+			if k.Label != "" {
+				// Only the leading modifier shows, as the stagger of the row
+				if caps.Len() == 0 {
+					indent = int((k.Size() - 1) * (cellWidth - 1))
+				}
+				continue
+			}
+			key := KeyIn(k, layer)
+			cmd, bound := bindings[key]
+			if key == "" {
+				caps.WriteString(strings.Repeat(" ", cellWidth))
+				labels.WriteString(strings.Repeat(" ", cellWidth))
+				continue
+			}
+
+			face := key
+			if layer == LayerCtrl {
+				face = "^" + k.Base
+			}
+			if jamo := hangulIn(k, layer); jamo != "" {
+				face += " " + jamo
+			}
+			face = fit(face, cellWidth-1)
+			label := ""
+			if bound {
+				label = fit(textKeyLabel(key, cmd), cellWidth-1)
+				entries = append(entries, cheatsheet.Entry{Command: key, Category: cmd.Category})
+				if strings.HasSuffix(cmd.Command, "{motion}") {
+					operators = append(operators, key)
+				}
+			} else {
+				label = strings.Repeat(" ", cellWidth-1)
+			}
+
+			if ansi && bound {
+				color := "\033[" + colorCode(cmd.Category) + "m"
+				if strings.HasSuffix(cmd.Command, "{motion}") {
+					color += underline
+				}
+				face = bold + color + face + reset
+				label = color + label + reset
+			}
+			caps.WriteString(face + " ")
+			labels.WriteString(label + " ")
+		}
+		if strings.TrimSpace(caps.String()) == "" {
+			continue // no key of the row types anything in this layer
+		}
+		pad := strings.Repeat(" ", indent)
+		b.WriteString(strings.TrimRight(pad+caps.String(), " ") + "\n")
+		b.WriteString(strings.TrimRight(pad+labels.String(), " ") + "\n")
+	}
+
+	if len(entries) > 0 {
+		b.WriteString("\n")
+	}
+	for _, section := range cheatsheet.Group("", entries, lang).Sections {
+		keys := make([]string, len(section.Entries))
+		for i, e := range section.Entries {
+			keys[i] = e.Command
+		}
+		title := section.Title
+		if ansi {
+			title = "\033[" + colorCode(section.Entries[0].Category) + "m" + title + reset
+		}
+		b.WriteString("  " + title + ": " + strings.Join(keys, " ") + "\n")
+	}
+	if len(operators) > 0 {
+		b.WriteString("  " + textLabel("operators", lang) + ": " + strings.Join(operators, " ") + "\n")
+	}
+	if hasHangul(layout) && layer != LayerCtrl {
+		b.WriteString("\n⚠ " + textLabel("hangul", lang) + "\n")
+	}
+	return b.String()
+}
+
+// colorCode returns the ANSI color of a category
+func colorCode(category string) string {
+	if c, ok := ansiColors[category]; ok {
+		return c
+	}
+	return "37"
+}
+
+// hangulIn returns the jamo k types in a layer in Hangul input mode
+func hangulIn(k Key, layer string) string {
+	switch layer {
+	case LayerLower:
+		return k.Hangul
+	case LayerShift:
+		return k.HangulShift
+	}
+	return ""
+}
+
+func hasHangul(layout Layout) bool {
+	for _, r := range layout.Rows {
+		for _, k := range r {
+			if k.Hangul != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// textKeyLabel names what a key does in a terminal cell: a short command
+// other than the key itself ("gg"), or else the command's keyword
+func textKeyLabel(key string, cmd catalog.Command) string {
+	if cmd.Command != key && cells(cmd.Command) < cellWidth || cmd.Keyword == "" {
+		return cmd.Command
+	}
+	return cmd.Keyword
+}
+
+// cells returns the number of terminal cells s occupies
+func cells(s string) int {
+	n := 0
+	for _, r := range s {
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// fit truncates or pads s with spaces to exactly w cells
+func fit(s string, w int) string {
+	if n := cells(s); n <= w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		rw := cells(string(r))
+		if n+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		n += rw
+	}
+	return b.String() + "…" + strings.Repeat(" ", w-1-n)
+}
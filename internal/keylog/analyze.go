@@ -0,0 +1,402 @@
+package keylog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/learn"
+)
+
+// Pattern kinds
+const (
+	PatternRepeatedMotion = "repeated-motion" // jjjjjj -> 6j
+	PatternRepeatedDelete = "repeated-delete" // xxxx -> dw or 4x
+	PatternRepeatedLine   = "repeated-line"   // dd dd dd -> 3dd
+	PatternRepeatedChange = "repeated-change" // the same change typed again -> .
+	PatternShorterForm    = "shorter-form"    // $a -> A, d$ -> D
+	PatternArrowKeys      = "arrow-keys"      // cursor keys in Normal mode -> hjkl
+	PatternInsertArrows   = "insert-arrows"   // moving with cursor keys inside Insert mode
+	PatternBackspaceRun   = "backspace-run"   // long <BS> runs -> Ctrl+w
+)
+
+// Thresholds for the pattern detection
+const (
+	minMotionRun    = 4 // jjjj
+	minDeleteRun    = 3 // xxx
+	minLineRun      = 2 // dd dd
+	minBackspaceRun = 5
+	minArrowKeys    = 5
+	minUnusedTokens = 50 // recommend unused commands only for logs this long
+	maxUnused       = 3
+	maxChangeGap    = 4  // motions allowed between a change and its repetition
+	maxRecommend    = 10 // recommendations shown at most
+)
+
+// repeatableMotions are motions whose repetition is better written with a count
+var repeatableMotions = setOf("h", "j", "k", "l", "w", "W", "b", "B", "e", "E", "Up", "Down", "Left", "Right",
+	"{", "}", "(", ")", "Ctrl+e", "Ctrl+y", "Ctrl+d", "Ctrl+u", "Ctrl+f", "Ctrl+b", "n", "N", "+", "-")
+
+// arrowMotions are the cursor keys and the hjkl key that replaces each
+var arrowMotions = map[string]string{"Up": "k", "Down": "j", "Left": "h", "Right": "l"}
+
+// shorterForms are two commands in a row with a single-command equivalent
+var shorterForms = []struct {
+	first, second, better string
+}{
+	{"$", "a", "A"},
+	{"0", "i", "I"},
+	{"^", "i", "I"},
+	{"l", "i", "a"},
+	{"j", "O", "o"},
+	{"k", "o", "O"},
+}
+
+// shorterCommands are operator forms with a shorter synonym
+var shorterCommands = map[string]string{
+	"d$": "D", "c$": "C", "dl": "x", "dh": "X", "cl": "s", "cc": "S",
+}
+
+// Usage is how often one command was used
+type Usage struct {
+	Command     string `json:"command"`
+	Count       int    `json:"count"`
+	Description string `json:"description,omitempty"` // catalog description when the command is in the catalog
+}
+
+// Pattern is an inefficient habit found in the log
+type Pattern struct {
+	Kind        string `json:"kind"`
+	Command     string `json:"command"` // the command that was repeated or could be shortened
+	Example     string `json:"example"` // keys as typed, e.g. "jjjjjj"
+	Better      string `json:"better"`  // what to type instead, e.g. "6j"
+	Occurrences int    `json:"occurrences"`
+	Saved       int    `json:"saved"` // keystrokes saved over all occurrences
+}
+
+// Recommendation suggests a catalog command (and a lesson that teaches it)
+type Recommendation struct {
+	Command     string           `json:"command"`
+	Description string           `json:"description,omitempty"`
+	Reason      string           `json:"reason"`
+	Lesson      *learn.LessonRef `json:"lesson,omitempty"`
+}
+
+// Report is the analysis of one keystroke log
+type Report struct {
+	Path            string           `json:"path"`
+	Keystrokes      int              `json:"keystrokes"`
+	Commands        int              `json:"commands"`
+	Modes           map[string]int   `json:"modes"` // commands per mode
+	Usage           []Usage          `json:"usage"`
+	Patterns        []Pattern        `json:"patterns"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// Analyzer turns tokens into a report using the catalog for names and descriptions
+type Analyzer struct {
+	Commands []catalog.Command
+	Lang     string
+
+	byName map[string]catalog.Command
+}
+
+// NewAnalyzer creates an analyzer; lang selects the language of reasons and lessons
+func NewAnalyzer(commands []catalog.Command, lang string) *Analyzer {
+	an := &Analyzer{Commands: commands, Lang: lang, byName: make(map[string]catalog.Command)}
+	for _, cmd := range commands {
+		if _, exists := an.byName[cmd.Command]; !exists {
+			an.byName[cmd.Command] = cmd
+		}
+	}
+	return an
+}
+
+func (an *Analyzer) pick(ko, en string) string {
+	if an.Lang == "en" {
+		return en
+	}
+	return ko
+}
+
+// Analyze decodes, parses and analyzes a keystroke log
+func (an *Analyzer) Analyze(path string, data []byte) *Report {
+	keys := Decode(data)
+	tokens := Parse(keys)
+
+	report := &Report{Path: path, Keystrokes: len(keys), Commands: len(tokens), Modes: make(map[string]int)}
+	for _, tok := range tokens {
+		report.Modes[tok.Mode]++
+	}
+	report.Usage = an.usage(tokens)
+	report.Patterns = an.patterns(tokens)
+	report.Recommendations = an.recommend(report)
+	return report
+}
+
+// CatalogName maps a parsed command onto the catalog entry it belongs to:
+// "/" is "/pattern", ":%s" is ":%s/old/new/g" and an operator with a
+// motion that has no entry of its own falls back to "d{motion}"
+func (an *Analyzer) CatalogName(tok Token) string {
+	name := tok.Command
+	if _, ok := an.byName[name]; ok {
+		return name
+	}
+
+	switch name {
+	case "/":
+		return "/pattern"
+	case "?":
+		return "?pattern"
+	case ":%s":
+		return ":%s/old/new/g"
+	case ":s":
+		if strings.HasSuffix(tok.Text, "/g") {
+			return ":s/old/new/g"
+		}
+		return ":s/old/new"
+	case ":h", ":help":
+		if strings.Contains(strings.TrimSpace(tok.Text), " ") {
+			return ":help {subject}"
+		}
+		return ":help"
+	}
+
+	if len(name) > 1 && operators[name[:1]] && tok.Mode != ModeCommandLine {
+		if generic := name[:1] + "{motion}"; an.has(generic) {
+			return generic
+		}
+	}
+	return name
+}
+
+func (an *Analyzer) has(name string) bool {
+	_, ok := an.byName[name]
+	return ok
+}
+
+func (an *Analyzer) describe(name string) string {
+	return an.byName[name].Description
+}
+
+// usage counts commands, most used first
+func (an *Analyzer) usage(tokens []Token) []Usage {
+	counts := make(map[string]int)
+	for _, tok := range tokens {
+		counts[an.CatalogName(tok)]++
+	}
+
+	usage := make([]Usage, 0, len(counts))
+	for name, n := range counts {
+		usage = append(usage, Usage{Command: name, Count: n, Description: an.describe(name)})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Command < usage[j].Command
+	})
+	return usage
+}
+
+// patterns finds inefficient habits and groups them by kind and command
+func (an *Analyzer) patterns(tokens []Token) []Pattern {
+	var found []Pattern
+	add := func(p Pattern) {
+		for i := range found {
+			if found[i].Kind == p.Kind && found[i].Command == p.Command {
+				found[i].Occurrences++
+				found[i].Saved += p.Saved
+				// Keep the longest example, it shows the habit best
+				if len(p.Example) > len(found[i].Example) {
+					found[i].Example, found[i].Better = p.Example, p.Better
+				}
+				return
+			}
+		}
+		p.Occurrences = 1
+		found = append(found, p)
+	}
+
+	arrows := 0
+	for i := 0; i < len(tokens); {
+		tok := tokens[i]
+
+		// Runs of the same count-less command
+		j := i + 1
+		for j < len(tokens) && sameBareCommand(tokens[j], tok) {
+			j++
+		}
+		run := j - i
+		runKeys := strings.Repeat(tok.Keys, run)
+
+		if _, ok := arrowMotions[tok.Command]; ok {
+			arrows += run
+		}
+
+		switch {
+		case tok.Count == 0 && repeatableMotions[tok.Command] && run >= minMotionRun:
+			key := tok.Command
+			if hjkl, ok := arrowMotions[key]; ok {
+				key = hjkl
+			}
+			better := strconv.Itoa(run) + tok.Keys
+			if len(tok.Keys) == 1 || arrowMotions[tok.Command] != "" {
+				better = strconv.Itoa(run) + key
+			}
+			add(Pattern{Kind: PatternRepeatedMotion, Command: key, Example: runKeys, Better: better, Saved: run - len(better)})
+		case tok.Count == 0 && (tok.Command == "x" || tok.Command == "X") && run >= minDeleteRun:
+			better := fmt.Sprintf("%d%s", run, tok.Command)
+			if tok.Command == "x" {
+				better = "dw / " + better
+			}
+			add(Pattern{Kind: PatternRepeatedDelete, Command: tok.Command, Example: runKeys, Better: better, Saved: run - len(strconv.Itoa(run)) - 1})
+		case tok.Count == 0 && (tok.Command == "dd" || tok.Command == "yy" || tok.Command == ">>" || tok.Command == "<<") && run >= minLineRun:
+			better := fmt.Sprintf("%d%s", run, tok.Command)
+			add(Pattern{Kind: PatternRepeatedLine, Command: tok.Command, Example: runKeys, Better: better, Saved: len(runKeys) - len(better)})
+		}
+
+		if run > 1 {
+			i = j
+			continue
+		}
+
+		if better, ok := shorterCommands[tok.Command]; ok && tok.Mode == ModeNormal {
+			add(Pattern{Kind: PatternShorterForm, Command: better, Example: tok.Keys, Better: strings.Replace(tok.Keys, tok.Command, better, 1), Saved: len(tok.Command) - len(better)})
+		}
+
+		if i+1 < len(tokens) && tok.Mode == ModeNormal && tok.Count == 0 {
+			next := tokens[i+1]
+			for _, form := range shorterForms {
+				if tok.Command == form.first && next.Command == form.second && next.Count == 0 {
+					example := tok.Keys + next.Keys
+					better := form.better + strings.TrimPrefix(next.Keys, form.second)
+					add(Pattern{Kind: PatternShorterForm, Command: form.better, Example: example, Better: better, Saved: 1})
+				}
+			}
+
+			// The same change typed again could have been repeated with "."
+			if tok.Inserts() && tok.Text != "" {
+				for k := i + 1; k < len(tokens) && k <= i+maxChangeGap+1; k++ {
+					other := tokens[k]
+					if other.Command == tok.Command && other.Text == tok.Text && other.Count == tok.Count {
+						add(Pattern{Kind: PatternRepeatedChange, Command: ".", Example: other.Keys, Better: ".", Saved: len(other.Keys) - 1})
+						break
+					}
+					if !motionCommands[other.Command] {
+						break
+					}
+				}
+			}
+		}
+
+		if tok.Arrows > 0 {
+			add(Pattern{Kind: PatternInsertArrows, Command: "Esc", Example: tok.Keys, Better: an.pick("<Esc> 후 이동 명령", "<Esc> then a motion"), Saved: 0})
+		}
+		if tok.Backspaces >= minBackspaceRun {
+			add(Pattern{Kind: PatternBackspaceRun, Command: "Ctrl+w", Example: strings.Repeat("<BS>", tok.Backspaces), Better: "<C-w>", Saved: tok.Backspaces - 1})
+		}
+		i++
+	}
+
+	if arrows >= minArrowKeys {
+		found = append(found, Pattern{Kind: PatternArrowKeys, Command: "hjkl", Example: "<Up> <Down> <Left> <Right>", Better: "k j h l", Occurrences: arrows})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Saved > found[j].Saved
+	})
+	return found
+}
+
+// sameBareCommand reports whether b repeats a exactly, without a count
+func sameBareCommand(b, a Token) bool {
+	return b.Command == a.Command && b.Keys == a.Keys && b.Count == 0 && a.Count == 0 &&
+		b.Mode == a.Mode && b.Mode != ModeCommandLine && b.Text == "" && a.Text == ""
+}
+
+// patternAdvice lists the catalog commands recommended for each pattern kind
+var patternAdvice = map[string][]string{
+	PatternRepeatedDelete: {"dw", "d{motion}"},
+	PatternRepeatedLine:   {"dd", "d{motion}"},
+	PatternArrowKeys:      {"h", "j", "k", "l"},
+	PatternInsertArrows:   {"Esc", "A", "o"},
+}
+
+// motionAdvice lists faster motions for repeated small motions
+var motionAdvice = map[string][]string{
+	"j": {"/pattern", "G", "gg"},
+	"k": {"?pattern", "gg"},
+	"l": {"w", "$"},
+	"h": {"b", "0"},
+	"w": {"/pattern", "$"},
+	"b": {"?pattern", "0"},
+}
+
+// recommend turns patterns and unused commands into catalog recommendations
+func (an *Analyzer) recommend(report *Report) []Recommendation {
+	var recs []Recommendation
+	seen := make(map[string]bool)
+
+	add := func(name, reason string) {
+		// Only catalog commands can be looked up with explain
+		if seen[name] || len(recs) >= maxRecommend || !an.has(name) {
+			return
+		}
+		seen[name] = true
+		rec := Recommendation{Command: name, Description: an.describe(name), Reason: reason}
+		if ref, ok := learn.FindLesson(name, an.Lang); ok {
+			rec.Lesson = &ref
+		}
+		recs = append(recs, rec)
+	}
+
+	for _, p := range report.Patterns {
+		reason := fmt.Sprintf("%s 대신 %s (%d번, %d타 절약)", p.Example, p.Better, p.Occurrences, p.Saved)
+		if an.Lang == "en" {
+			reason = fmt.Sprintf("%s instead of %s (%d times, %d keys saved)", p.Better, p.Example, p.Occurrences, p.Saved)
+		}
+		switch p.Kind {
+		case PatternRepeatedMotion:
+			add(p.Command, reason)
+			for _, name := range motionAdvice[p.Command] {
+				add(name, fmt.Sprintf(an.pick("%s를 여러 번 누르는 대신 더 멀리 이동", "moves further than repeating %s"), p.Command))
+			}
+		case PatternShorterForm:
+			add(p.Command, reason)
+		case PatternRepeatedChange:
+			add(".", reason)
+		case PatternBackspaceRun:
+			add("Ctrl+w", fmt.Sprintf(an.pick("<BS>를 %d번 연속으로 누르는 대신 단어 단위로 지우기", "deletes a word instead of %d <BS> in a row"), strings.Count(p.Example, KeyBS)))
+		case PatternArrowKeys:
+			for _, name := range patternAdvice[p.Kind] {
+				add(name, fmt.Sprintf(an.pick("화살표 키를 %d번 사용 - 손을 홈 row에 둔 채 이동", "cursor keys used %d times - move without leaving the home row"), p.Occurrences))
+			}
+		default:
+			for _, name := range patternAdvice[p.Kind] {
+				add(name, reason)
+			}
+		}
+	}
+
+	// Useful commands that never appear in a long enough log
+	if report.Commands >= minUnusedTokens {
+		used := make(map[string]bool)
+		for _, u := range report.Usage {
+			used[u.Command] = true
+		}
+		added := 0
+		for _, name := range []string{"w", "b", "/pattern", "A", "o", "Ctrl+r", "D", "gg", "G", "P"} {
+			if added >= maxUnused {
+				break
+			}
+			if an.has(name) && !used[name] && !seen[name] {
+				add(name, an.pick("아직 한 번도 쓰지 않은 유용한 명령어", "a useful command you have not used yet"))
+				added++
+			}
+		}
+	}
+	return recs
+}
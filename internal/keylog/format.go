@@ -0,0 +1,90 @@
+package keylog
+
+import (
+	"fmt"
+	"strings"
+
+	"vi-assistant/internal/style"
+)
+
+// patternTitles are the localized names of pattern kinds
+var patternTitles = map[string][2]string{
+	PatternRepeatedMotion: {"같은 이동 반복", "repeated motion"},
+	PatternRepeatedDelete: {"글자 단위 삭제 반복", "repeated character delete"},
+	PatternRepeatedLine:   {"줄 명령 반복", "repeated line command"},
+	PatternRepeatedChange: {"같은 변경 다시 입력", "same change typed again"},
+	PatternShorterForm:    {"더 짧은 명령어가 있음", "shorter command exists"},
+	PatternArrowKeys:      {"화살표 키 사용", "cursor keys"},
+	PatternInsertArrows:   {"삽입 모드에서 화살표로 이동", "moving in Insert mode"},
+	PatternBackspaceRun:   {"<BS> 연속 입력", "long <BS> runs"},
+}
+
+// FormatReport renders the analysis with the top usage rows
+func FormatReport(report *Report, top int, lang string) string {
+	var output strings.Builder
+	en := lang == "en"
+	pick := func(ko, english string) string {
+		if en {
+			return english
+		}
+		return ko
+	}
+
+	output.WriteString(style.Heading(fmt.Sprintf(pick("키 입력 분석: %s", "Keystroke analysis: %s"), report.Path)) + "\n")
+	output.WriteString(fmt.Sprintf(pick("키 입력 %d개, 명령 %d개", "%d keystrokes, %d commands"), report.Keystrokes, report.Commands))
+	var modes []string
+	for _, mode := range []string{ModeNormal, ModeVisual, ModeCommandLine} {
+		if n := report.Modes[mode]; n > 0 {
+			modes = append(modes, fmt.Sprintf("%s %d", mode, n))
+		}
+	}
+	if len(modes) > 0 {
+		output.WriteString(" (" + strings.Join(modes, ", ") + ")")
+	}
+	output.WriteString("\n")
+
+	if report.Commands == 0 {
+		output.WriteString(pick("\n분석할 명령이 없습니다.\n", "\nNo commands to analyze.\n"))
+		return output.String()
+	}
+
+	usage := report.Usage
+	if top > 0 && len(usage) > top {
+		usage = usage[:top]
+	}
+	output.WriteString("\n" + style.Heading(fmt.Sprintf(pick("자주 쓴 명령어 (상위 %d개)", "Most used commands (top %d)"), len(usage))) + "\n")
+	for i, u := range usage {
+		output.WriteString(fmt.Sprintf("%3d. %s %5d  %s\n", i+1, style.Command(fmt.Sprintf("%-16s", u.Command)), u.Count, u.Description))
+	}
+
+	output.WriteString("\n" + style.Heading(pick("비효율적인 입력 습관", "Inefficient habits")) + "\n")
+	if len(report.Patterns) == 0 {
+		output.WriteString(pick("  발견되지 않았습니다.\n", "  None found.\n"))
+	}
+	for _, p := range report.Patterns {
+		title := patternTitles[p.Kind]
+		output.WriteString(fmt.Sprintf("  [%s] %s → %s", pick(title[0], title[1]), p.Example, style.Command(p.Better)))
+		if p.Saved > 0 {
+			output.WriteString(fmt.Sprintf(pick(" (%d번, %d타 절약)", " (%d times, %d keys saved)"), p.Occurrences, p.Saved))
+		} else {
+			output.WriteString(fmt.Sprintf(pick(" (%d번)", " (%d times)"), p.Occurrences))
+		}
+		output.WriteString("\n")
+	}
+
+	if len(report.Recommendations) > 0 {
+		output.WriteString("\n" + style.Heading(pick("추천", "Recommendations")) + "\n")
+		for _, r := range report.Recommendations {
+			output.WriteString(fmt.Sprintf("  %s", style.Command(r.Command)))
+			if r.Description != "" {
+				output.WriteString(" - " + r.Description)
+			}
+			output.WriteString("\n")
+			output.WriteString(fmt.Sprintf(pick("      이유: %s\n", "      Why: %s\n"), r.Reason))
+			if r.Lesson != nil {
+				output.WriteString(fmt.Sprintf(pick("      강의: %s (vi-assistant learn start %s)\n", "      Lesson: %s (vi-assistant learn start %s)\n"), r.Lesson.Title, r.Lesson.Level))
+			}
+		}
+	}
+	return output.String()
+}
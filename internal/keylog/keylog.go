@@ -0,0 +1,553 @@
+// Package keylog reads the keystroke files Vim writes with "vim -w scriptout"
+// and splits them into commands with the Normal mode grammar
+// ([count]["x]command[motion]). The result feeds usage statistics and the
+// detection of inefficient habits such as "jjjjjj" instead of "6j".
+package keylog
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Special key names produced by Decode
+const (
+	KeyEsc = "<Esc>"
+	KeyCR  = "<CR>"
+	KeyTab = "<Tab>"
+	KeyBS  = "<BS>"
+	KeyDel = "<Del>"
+)
+
+// kSpecial starts a Vim internal key code: 0x80 followed by two bytes
+const kSpecial = 0x80
+
+// Second bytes of internal key codes that are not termcap names
+const (
+	ksModifier = 0xFC // modifier mask for the next key
+	ksExtra    = 0xFD // mouse, focus and timer events
+	ksSpecial  = 0xFE // an escaped 0x80 byte
+)
+
+// termcapKeys names the termcap codes Vim stores after kSpecial
+var termcapKeys = map[string]string{
+	"ku": "<Up>", "kd": "<Down>", "kl": "<Left>", "kr": "<Right>",
+	"kb": KeyBS, "kD": KeyDel, "kI": "<Insert>",
+	"kh": "<Home>", "@7": "<End>", "kP": "<PageUp>", "kN": "<PageDown>",
+	"k1": "<F1>", "k2": "<F2>", "k3": "<F3>", "k4": "<F4>", "k5": "<F5>",
+	"k6": "<F6>", "k7": "<F7>", "k8": "<F8>", "k9": "<F9>", "k;": "<F10>",
+}
+
+// ansiArrows are the terminal escape sequences for the cursor keys, which
+// appear when the keys were captured without Vim's termcode translation
+var ansiArrows = map[byte]string{'A': "<Up>", 'B': "<Down>", 'C': "<Right>", 'D': "<Left>"}
+
+// Decode turns raw scriptout bytes into key names. Printable characters
+// (including multi-byte UTF-8) are returned as they are; control
+// characters and special keys use Vim's notation, e.g. <Esc>, <CR>, <C-r>, <Up>.
+func Decode(data []byte) []string {
+	var keys []string
+
+	for i := 0; i < len(data); {
+		b := data[i]
+		switch {
+		case b == kSpecial && i+2 < len(data):
+			code := data[i+1 : i+3]
+			i += 3
+			switch code[0] {
+			case ksModifier, ksExtra:
+				continue
+			case ksSpecial:
+				keys = append(keys, string(rune(kSpecial)))
+				continue
+			}
+			if name, ok := termcapKeys[string(code)]; ok {
+				keys = append(keys, name)
+			}
+		case b == 0x1b && i+2 < len(data) && (data[i+1] == '[' || data[i+1] == 'O') && ansiArrows[data[i+2]] != "":
+			keys = append(keys, ansiArrows[data[i+2]])
+			i += 3
+		case b == 0x1b:
+			keys = append(keys, KeyEsc)
+			i++
+		case b == '\r' || b == '\n':
+			keys = append(keys, KeyCR)
+			i++
+		case b == '\t':
+			keys = append(keys, KeyTab)
+			i++
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, KeyBS)
+			i++
+		case b < 0x20:
+			keys = append(keys, "<C-"+string(rune('a'+b-1))+">")
+			i++
+		default:
+			r, size := utf8.DecodeRune(data[i:])
+			if r == utf8.RuneError && size <= 1 {
+				i++
+				continue
+			}
+			keys = append(keys, string(r))
+			i += size
+		}
+	}
+	return keys
+}
+
+// notationKeys maps the lowercase names of Vim's key notation to the names Decode produces
+var notationKeys = map[string]string{
+	"esc": KeyEsc, "cr": KeyCR, "enter": KeyCR, "return": KeyCR, "nl": KeyCR,
+	"tab": KeyTab, "bs": KeyBS, "del": KeyDel,
+	"up": "<Up>", "down": "<Down>", "left": "<Left>", "right": "<Right>",
+	"home": "<Home>", "end": "<End>", "pageup": "<PageUp>", "pagedown": "<PageDown>", "insert": "<Insert>",
+	"lt": "<", "space": " ", "bar": "|", "bslash": "\\",
+}
+
+// SplitNotation splits keys written in Vim's notation, as keytrans() returns
+// them (e.g. "3dw<Esc>:w<CR>"), into the key names Decode produces.
+// A "<" that does not start a known key name is the < command itself.
+func SplitNotation(s string) []string {
+	var keys []string
+	for s != "" {
+		if s[0] == '<' {
+			if end := strings.IndexByte(s, '>'); end > 1 {
+				if key, ok := notationKey(s[1:end]); ok {
+					keys = append(keys, key)
+					s = s[end+1:]
+					continue
+				}
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		keys = append(keys, string(r))
+		s = s[size:]
+	}
+	return keys
+}
+
+// notationKey converts the name between < and >: Esc, C-r, F5 and so on
+func notationKey(name string) (string, bool) {
+	lower := strings.ToLower(name)
+	if key, ok := notationKeys[lower]; ok {
+		return key, true
+	}
+	if strings.HasPrefix(lower, "c-") && len(lower) == 3 && lower[2] >= 'a' && lower[2] <= 'z' {
+		return "<C-" + lower[2:] + ">", true
+	}
+	if len(lower) >= 2 && lower[0] == 'f' && strings.Trim(lower[1:], "0123456789") == "" {
+		return "<F" + lower[1:] + ">", true
+	}
+	return "", false
+}
+
+// CatalogKey converts a key name into the catalog's notation: <C-r> becomes
+// Ctrl+r, <Esc> becomes Esc and the cursor keys drop their brackets
+func CatalogKey(key string) string {
+	switch {
+	case strings.HasPrefix(key, "<C-") && len(key) == 5:
+		return "Ctrl+" + key[3:4]
+	case len(key) > 2 && strings.HasPrefix(key, "<") && strings.HasSuffix(key, ">"):
+		return key[1 : len(key)-1]
+	}
+	return key
+}
+
+// Token modes
+const (
+	ModeNormal      = "normal"
+	ModeVisual      = "visual"
+	ModeCommandLine = "command-line"
+)
+
+// Token is one command parsed from the key stream
+type Token struct {
+	Keys       string `json:"keys"`    // keys as typed, e.g. "3dw" or "ciwfoo<Esc>"
+	Command    string `json:"command"` // the command without count and register, e.g. "dw", "j", ":w"
+	Count      int    `json:"count,omitempty"`
+	Register   string `json:"register,omitempty"`
+	Mode       string `json:"mode"`
+	Text       string `json:"text,omitempty"`       // text typed in Insert mode or on the command line
+	Arrows     int    `json:"arrows,omitempty"`     // cursor keys pressed in Insert mode
+	Backspaces int    `json:"backspaces,omitempty"` // longest run of <BS> in Insert mode
+	Offset     int    `json:"offset"`               // index of the first key
+}
+
+// Inserts reports whether the command switched to Insert mode
+func (t Token) Inserts() bool {
+	return insertCommands[t.Command] || strings.HasPrefix(t.Command, "c") && len(t.Command) > 1 && t.Mode != ModeCommandLine
+}
+
+var (
+	// motions are Normal mode commands that only move the cursor
+	motions = setOf("h", "j", "k", "l", "w", "W", "b", "B", "e", "E", "0", "^", "$", "_", "|",
+		"G", "H", "M", "L", "%", "{", "}", "(", ")", ";", ",", "n", "N", "*", "#", "+", "-", " ",
+		"<Up>", "<Down>", "<Left>", "<Right>", "<Home>", "<End>", "<PageUp>", "<PageDown>", KeyBS, KeyCR,
+		"<C-f>", "<C-b>", "<C-d>", "<C-u>", "<C-e>", "<C-y>", "<C-o>", "<C-i>")
+
+	// gMotions follow "g"
+	gMotions = setOf("g", "e", "E", "j", "k", "_", "0", "$", "m", "o", "*", "#", "d", "D")
+
+	// charMotions take one character argument
+	charMotions = setOf("f", "F", "t", "T")
+
+	// markMotions take a mark name
+	markMotions = setOf("`", "'")
+
+	operators  = setOf("d", "c", "y", "<", ">", "=", "!")
+	gOperators = setOf("g~", "gu", "gU", "gq", "gw", "g?")
+
+	// insertCommands enter Insert mode directly
+	insertCommands = setOf("i", "I", "a", "A", "o", "O", "s", "S", "C", "R", "gi", "gI")
+
+	// visualOperators act on the selection and end Visual mode
+	visualOperators = setOf("d", "x", "X", "D", "y", "Y", "c", "s", "C", "S", "R", "<", ">", "=", "!",
+		"~", "u", "U", "J", "p", "P", "r", "I", "A")
+)
+
+// motionCommands are the command names Parse gives to motions
+var motionCommands = func() map[string]bool {
+	names := make(map[string]bool)
+	for k := range motions {
+		names[motionName(k)] = true
+	}
+	for k := range charMotions {
+		names[k+"{char}"] = true
+	}
+	for k := range markMotions {
+		names[k+"{mark}"] = true
+	}
+	for k := range gMotions {
+		names["g"+k] = true
+	}
+	return names
+}()
+
+// motionName is the command name of a motion key
+func motionName(k string) string {
+	if k == " " {
+		return "Space"
+	}
+	return CatalogKey(k)
+}
+
+func setOf(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// parser walks the key stream
+type parser struct {
+	keys      []string
+	pos       int
+	visual    string // the key that started Visual mode, "" in Normal mode
+	recording bool
+}
+
+func (p *parser) next() (string, bool) {
+	if p.pos >= len(p.keys) {
+		return "", false
+	}
+	k := p.keys[p.pos]
+	p.pos++
+	return k, true
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.keys) {
+		return ""
+	}
+	return p.keys[p.pos]
+}
+
+// count reads an optional count; "0" alone is a motion, not a count
+func (p *parser) count() int {
+	n := 0
+	for {
+		k := p.peek()
+		if len(k) != 1 || k[0] < '0' || k[0] > '9' || (k == "0" && n == 0) {
+			return n
+		}
+		n = n*10 + int(k[0]-'0')
+		p.pos++
+	}
+}
+
+// Parse splits decoded keys into commands
+func Parse(keys []string) []Token {
+	p := &parser{keys: keys}
+	var tokens []Token
+
+	for p.pos < len(p.keys) {
+		start := p.pos
+		tok := p.command()
+		tok.Offset = start
+		tok.Keys = strings.Join(p.keys[start:p.pos], "")
+		if tok.Command != "" {
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens
+}
+
+// command reads one Normal or Visual mode command
+func (p *parser) command() Token {
+	tok := Token{Mode: ModeNormal}
+	if p.visual != "" {
+		tok.Mode = ModeVisual
+	}
+
+	tok.Count = p.count()
+	if p.peek() == `"` {
+		p.pos++
+		tok.Register, _ = p.next()
+	}
+
+	k, ok := p.next()
+	if !ok {
+		return tok
+	}
+
+	switch {
+	case p.visual != "" && (k == "i" || k == "a") && p.peek() != "":
+		// Text object selection such as "iw"
+		obj, _ := p.next()
+		tok.Command = k + obj
+	case p.visual != "" && visualOperators[k]:
+		tok.Command = k
+		p.visual = ""
+		if k == "r" {
+			p.next()
+			tok.Command = "r{char}"
+		}
+		if insertCommands[k] || k == "c" {
+			p.insert(&tok)
+		}
+	case motions[k]:
+		tok.Command = motionName(k)
+	case charMotions[k]:
+		p.next()
+		tok.Command = k + "{char}"
+	case markMotions[k]:
+		p.next()
+		tok.Command = k + "{mark}"
+	case k == "g":
+		c, _ := p.next()
+		g := "g" + c
+		switch {
+		case gOperators[g] && p.visual != "":
+			tok.Command = g
+			p.visual = ""
+		case gOperators[g]:
+			p.operator(&tok, g)
+		case insertCommands[g]:
+			tok.Command = g
+			p.insert(&tok)
+		default:
+			tok.Command = g
+		}
+	case operators[k]:
+		p.operator(&tok, k)
+	case insertCommands[k]:
+		tok.Command = k
+		p.insert(&tok)
+	case k == "r":
+		p.next()
+		tok.Command = "r{char}"
+	case k == ":" || k == "/" || k == "?":
+		p.commandLine(&tok, k)
+	case k == "v" || k == "V" || k == "<C-v>":
+		tok.Command = CatalogKey(k)
+		if p.visual == k {
+			p.visual = ""
+		} else {
+			p.visual = k
+		}
+	case k == KeyEsc:
+		tok.Command = "Esc"
+		p.visual = ""
+	case k == "q":
+		if p.recording {
+			p.recording = false
+			tok.Command = "q"
+		} else {
+			p.next()
+			p.recording = true
+			tok.Command = "q{register}"
+		}
+	case k == "@":
+		p.next()
+		tok.Command = "@{register}"
+	case k == "m":
+		p.next()
+		tok.Command = "m{mark}"
+	case k == "z" || k == "Z" || k == "[" || k == "]" || k == "<C-w>":
+		c, _ := p.next()
+		tok.Command = CatalogKey(k) + c
+	default:
+		tok.Command = CatalogKey(k)
+	}
+	return tok
+}
+
+// operator reads the motion or text object after an operator
+func (p *parser) operator(tok *Token, op string) {
+	n := p.count()
+	if n > 0 {
+		if tok.Count == 0 {
+			tok.Count = 1
+		}
+		tok.Count *= n
+	}
+
+	k, ok := p.next()
+	if !ok {
+		tok.Command = op
+		return
+	}
+
+	last := op[len(op)-1:]
+	switch {
+	case k == last:
+		// dd, cc, yy, >>, gUU
+		tok.Command = op + k
+	case strings.HasPrefix(op, "g") && k == "g" && p.peek() == last:
+		// gUgU is the same as gUU
+		p.next()
+		tok.Command = op + last
+	case k == "i" || k == "a":
+		obj, _ := p.next()
+		tok.Command = op + k + obj
+	case charMotions[k]:
+		p.next()
+		tok.Command = op + k + "{char}"
+	case markMotions[k]:
+		p.next()
+		tok.Command = op + k + "{mark}"
+	case k == "g":
+		c, _ := p.next()
+		tok.Command = op + "g" + c
+	case k == "/" || k == "?":
+		var search Token
+		p.commandLine(&search, k)
+		tok.Command = op + k + "{pattern}"
+		tok.Text = search.Text
+	case k == KeyEsc:
+		tok.Command = "Esc"
+		return
+	default:
+		tok.Command = op + CatalogKey(k)
+	}
+
+	if op == "c" {
+		p.insert(tok)
+	}
+}
+
+// insert reads Insert mode keys until <Esc> or <C-c>
+func (p *parser) insert(tok *Token) {
+	var text []string
+	run := 0
+
+	for {
+		k, ok := p.next()
+		if !ok || k == KeyEsc || k == "<C-c>" {
+			break
+		}
+		if k == KeyBS {
+			run++
+			if run > tok.Backspaces {
+				tok.Backspaces = run
+			}
+			if len(text) > 0 {
+				text = text[:len(text)-1]
+			}
+			continue
+		}
+		run = 0
+
+		switch {
+		case k == KeyCR:
+			text = append(text, "\n")
+		case k == KeyTab:
+			text = append(text, "\t")
+		case k == "<Up>" || k == "<Down>" || k == "<Left>" || k == "<Right>" || k == "<Home>" || k == "<End>":
+			tok.Arrows++
+		case strings.HasPrefix(k, "<") && len(k) > 1:
+			// Insert mode commands such as <C-w> are not text
+		default:
+			text = append(text, k)
+		}
+	}
+	tok.Text = strings.Join(text, "")
+}
+
+// commandLine reads an Ex command or search pattern up to <CR>.
+// Commands abandoned with <Esc> keep the prefix alone as their command.
+func (p *parser) commandLine(tok *Token, prefix string) {
+	tok.Mode = ModeCommandLine
+	p.visual = ""
+	var text []string
+
+	for {
+		k, ok := p.next()
+		if !ok || k == KeyEsc || k == "<C-c>" {
+			tok.Command = prefix
+			tok.Text = strings.Join(text, "")
+			return
+		}
+		if k == KeyCR {
+			break
+		}
+		switch {
+		case k == KeyBS:
+			if len(text) > 0 {
+				text = text[:len(text)-1]
+			}
+		case k == KeyTab, strings.HasPrefix(k, "<") && len(k) > 1:
+			// Completion and editing keys are not part of the command
+		default:
+			text = append(text, k)
+		}
+	}
+
+	tok.Text = strings.Join(text, "")
+	if prefix != ":" {
+		tok.Command = prefix
+		return
+	}
+	tok.Command = ":" + ExName(tok.Text)
+}
+
+// ExName returns the command name of an Ex command line without its range
+// and arguments: "%s/a/b/g" -> "%s", "wq" -> "wq", "q!" -> "q!", "12" -> "{number}"
+func ExName(line string) string {
+	line = strings.TrimSpace(line)
+	i := 0
+	for i < len(line) && strings.ContainsRune("%.,$0123456789'<>;+-", rune(line[i])) {
+		i++
+	}
+	rangePart := line[:i]
+
+	j := i
+	for j < len(line) && (line[j] >= 'a' && line[j] <= 'z' || line[j] >= 'A' && line[j] <= 'Z') {
+		j++
+	}
+	if j < len(line) && line[j] == '!' {
+		j++
+	}
+	name := line[i:j]
+	if name == "" {
+		if rangePart != "" {
+			return "{number}"
+		}
+		return ""
+	}
+	// A whole-file range is part of how the catalog writes :%s
+	if rangePart == "%" {
+		return "%" + name
+	}
+	return name
+}
@@ -0,0 +1,157 @@
+package keylog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+)
+
+func TestDecode(t *testing.T) {
+	data := []byte("a\x1b\r\x12\x80ku\x80\xfd\x35\x1b[B한")
+	got := Decode(data)
+	want := []string{"a", KeyEsc, KeyCR, "<C-r>", "<Up>", "<Down>", "한"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Decode = %q, want %q", got, want)
+	}
+}
+
+func TestSplitNotation(t *testing.T) {
+	got := SplitNotation("3dw<Esc>:w<CR><C-R>x<lt>< <F5><Nope>")
+	want := []string{"3", "d", "w", KeyEsc, ":", "w", KeyCR, "<C-r>", "x", "<", "<", " ", "<F5>", "<", "N", "o", "p", "e", ">"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("SplitNotation = %q, want %q", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	keys := Decode([]byte("3dw\"ayyciwfoo\x1bgUiwdd10G:wq\r/needle\x1bvjd0x"))
+	tokens := Parse(keys)
+
+	want := []struct {
+		keys, command string
+		count         int
+		mode, text    string
+	}{
+		{"3dw", "dw", 3, ModeNormal, ""},
+		{`"ayy`, "yy", 0, ModeNormal, ""},
+		{"ciwfoo<Esc>", "ciw", 0, ModeNormal, "foo"},
+		{"gUiw", "gUiw", 0, ModeNormal, ""},
+		{"dd", "dd", 0, ModeNormal, ""},
+		{"10G", "G", 10, ModeNormal, ""},
+		{":wq<CR>", ":wq", 0, ModeCommandLine, "wq"},
+		{"/needle<Esc>", "/", 0, ModeCommandLine, "needle"},
+		{"v", "v", 0, ModeNormal, ""},
+		{"j", "j", 0, ModeVisual, ""},
+		{"d", "d", 0, ModeVisual, ""},
+		{"0", "0", 0, ModeNormal, ""},
+		{"x", "x", 0, ModeNormal, ""},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("Parse = %+v", tokens)
+	}
+	for i, w := range want {
+		tok := tokens[i]
+		if tok.Keys != w.keys || tok.Command != w.command || tok.Count != w.count || tok.Mode != w.mode || tok.Text != w.text {
+			t.Errorf("token %d = %+v, want %+v", i, tok, w)
+		}
+	}
+	if tokens[1].Register != "a" {
+		t.Errorf("register = %q", tokens[1].Register)
+	}
+}
+
+func TestExName(t *testing.T) {
+	tests := map[string]string{
+		"w":          "w",
+		"q!":         "q!",
+		"%s/a/b/g":   "%s",
+		"'<,'>s/a/b": "s",
+		"12":         "{number}",
+		"help dd":    "help",
+	}
+	for line, want := range tests {
+		if got := ExName(line); got != want {
+			t.Errorf("ExName(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/scriptout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands := []catalog.Command{
+		{Command: "j", Description: "down"},
+		{Command: "k", Description: "up"},
+		{Command: "x", Description: "delete a character"},
+		{Command: "dw", Description: "delete a word"},
+		{Command: "d{motion}", Description: "delete over a motion"},
+		{Command: "dd", Description: "delete a line"},
+		{Command: "A", Description: "append at the end of the line"},
+		{Command: "/pattern", Description: "search forward"},
+		{Command: ":%s/old/new/g", Description: "substitute in the file"},
+	}
+
+	report := NewAnalyzer(commands, "en").Analyze("scriptout", data)
+
+	patterns := make(map[string]Pattern)
+	for _, p := range report.Patterns {
+		patterns[p.Kind+" "+p.Command] = p
+	}
+	checks := []struct {
+		key, example, better string
+		occurrences          int
+	}{
+		{PatternRepeatedMotion + " j", "jjjjjjj", "7j", 1},
+		{PatternRepeatedMotion + " k", "kkkk", "4k", 2},
+		{PatternRepeatedDelete + " x", "xxxxx", "dw / 5x", 1},
+		{PatternRepeatedLine + " dd", "dddddd", "3dd", 1},
+		{PatternShorterForm + " A", "$aend<Esc>", "Aend<Esc>", 1},
+		{PatternShorterForm + " D", "d$", "D", 1},
+		{PatternRepeatedChange + " .", "ifoo <Esc>", ".", 1},
+		{PatternBackspaceRun + " Ctrl+w", "<BS><BS><BS><BS><BS><BS>", "<C-w>", 1},
+	}
+	for _, c := range checks {
+		p, ok := patterns[c.key]
+		if !ok {
+			t.Errorf("pattern %s not found in %+v", c.key, report.Patterns)
+			continue
+		}
+		if p.Example != c.example || p.Better != c.better || p.Occurrences != c.occurrences {
+			t.Errorf("pattern %s = %+v", c.key, p)
+		}
+	}
+	if p, ok := patterns[PatternArrowKeys+" hjkl"]; !ok || p.Occurrences != 6 {
+		t.Errorf("arrow keys = %+v", p)
+	}
+
+	usage := make(map[string]int)
+	for _, u := range report.Usage {
+		usage[u.Command] = u.Count
+	}
+	if usage["/pattern"] != 1 || usage[":%s/old/new/g"] != 1 || usage["j"] != 10 {
+		t.Errorf("usage = %v", usage)
+	}
+
+	recommended := make(map[string]Recommendation)
+	for _, r := range report.Recommendations {
+		recommended[r.Command] = r
+	}
+	for _, name := range []string{"j", "dw", "dd", "A"} {
+		if _, ok := recommended[name]; !ok {
+			t.Errorf("%s not recommended: %+v", name, report.Recommendations)
+		}
+	}
+	// Ctrl+w and . are not in this catalog, so explain could not show them
+	for _, name := range []string{"Ctrl+w", "."} {
+		if _, ok := recommended[name]; ok {
+			t.Errorf("%s recommended without a catalog entry", name)
+		}
+	}
+	if lesson := recommended["j"].Lesson; lesson == nil || lesson.Level != "beginner" || !strings.Contains(lesson.Title, "h, j, k, l") {
+		t.Errorf("lesson for j = %+v, want the hjkl lesson", lesson)
+	}
+}
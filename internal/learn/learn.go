@@ -7,18 +7,18 @@ import (
 
 // Lesson represents a learning lesson
 type Lesson struct {
-	Title       string
-	Description string
-	Commands    []LessonCommand
-	Tips        []string
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Commands    []LessonCommand `json:"commands"`
+	Tips        []string        `json:"tips"`
 }
 
 // LessonCommand represents a command in a lesson
 type LessonCommand struct {
-	Command     string
-	Description string
-	Example     string
-	Practice    string
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Example     string `json:"example"`
+	Practice    string `json:"practice"`
 }
 
 // GetBeginnerLessons returns beginner level lessons
@@ -42,7 +42,7 @@ func getBeginnerLessonsKO() []Lesson {
 	return []Lesson{
 		{
 			Title:       "1. vi 시작하기 - 기본 모드 이해",
-			Description: "vi의 두 가지 주요 모드와 기본 이동 명령어를 배워봅시다.",
+			Description: "vi의 두 가지 주요 모드와 모드를 오가는 방법을 배워봅시다.",
 			Commands: []LessonCommand{
 				{
 					Command:     "vi filename",
@@ -62,22 +62,50 @@ func getBeginnerLessonsKO() []Lesson {
 					Example:     "Esc를 누르면 -- INSERT -- 표시가 사라짐",
 					Practice:    "텍스트 입력 후 Esc를 눌러 명령 모드로 전환",
 				},
-				{
-					Command:     "h, j, k, l",
-					Description: "기본 커서 이동 (왼쪽, 아래, 위, 오른쪽)",
-					Example:     "h: 왼쪽, j: 아래, k: 위, l: 오른쪽",
-					Practice:    "명령 모드에서 h, j, k, l로 커서를 움직여보세요",
-				},
 			},
 			Tips: []string{
 				"💡 vi는 항상 명령 모드에서 시작합니다",
 				"💡 텍스트를 입력하려면 반드시 'i'로 삽입 모드로 전환해야 합니다",
 				"💡 명령 모드에서는 모든 키가 명령어로 인식됩니다",
-				"💡 h, j, k, l은 키보드의 왼쪽에 있어서 한 손으로 조작하기 편합니다",
 			},
 		},
 		{
-			Title:       "2. 파일 저장과 종료",
+			Title:       "2. 기본 커서 이동 - h, j, k, l",
+			Description: "명령 모드에서 손을 홈 row에 둔 채 커서를 움직이는 네 가지 키를 배워봅시다.",
+			Commands: []LessonCommand{
+				{
+					Command:     "h",
+					Description: "커서를 왼쪽으로 한 칸 이동",
+					Example:     "h를 누르면 커서가 왼쪽 글자로 이동",
+					Practice:    "명령 모드에서 h를 여러 번 눌러보세요",
+				},
+				{
+					Command:     "j",
+					Description: "커서를 아래 줄로 이동",
+					Example:     "j를 누르면 커서가 다음 줄로 이동",
+					Practice:    "j로 파일 아래쪽으로 내려가 보세요",
+				},
+				{
+					Command:     "k",
+					Description: "커서를 위 줄로 이동",
+					Example:     "k를 누르면 커서가 이전 줄로 이동",
+					Practice:    "k로 다시 위쪽으로 올라가 보세요",
+				},
+				{
+					Command:     "l",
+					Description: "커서를 오른쪽으로 한 칸 이동",
+					Example:     "l을 누르면 커서가 오른쪽 글자로 이동",
+					Practice:    "l로 줄 끝까지 이동해보세요",
+				},
+			},
+			Tips: []string{
+				"💡 h, j, k, l은 오른손이 놓이는 자리에 있어서 화살표 키로 손을 옮길 필요가 없습니다",
+				"💡 j는 아래로 내려가는 갈고리 모양이라고 기억하면 쉽습니다",
+				"💡 숫자를 앞에 붙이면 여러 칸을 한 번에 이동합니다 (5j: 다섯 줄 아래)",
+			},
+		},
+		{
+			Title:       "3. 파일 저장과 종료",
 			Description: "작업한 내용을 저장하고 vi를 종료하는 방법을 배워봅시다.",
 			Commands: []LessonCommand{
 				{
@@ -113,7 +141,7 @@ func getBeginnerLessonsKO() []Lesson {
 			},
 		},
 		{
-			Title:       "3. 텍스트 편집 기본",
+			Title:       "4. 텍스트 편집 기본",
 			Description: "텍스트를 삭제하고 복사하는 기본적인 편집 명령어를 배워봅시다.",
 			Commands: []LessonCommand{
 				{
@@ -156,7 +184,7 @@ func getBeginnerLessonsEN() []Lesson {
 	return []Lesson{
 		{
 			Title:       "1. Getting Started with vi - Understanding Basic Modes",
-			Description: "Learn about vi's two main modes and basic movement commands.",
+			Description: "Learn about vi's two main modes and how to switch between them.",
 			Commands: []LessonCommand{
 				{
 					Command:     "vi filename",
@@ -176,22 +204,50 @@ func getBeginnerLessonsEN() []Lesson {
 					Example:     "Press Esc to remove -- INSERT --",
 					Practice:    "After typing text, press Esc to switch to command mode",
 				},
-				{
-					Command:     "h, j, k, l",
-					Description: "Basic cursor movement (left, down, up, right)",
-					Example:     "h: left, j: down, k: up, l: right",
-					Practice:    "In command mode, move cursor with h, j, k, l",
-				},
 			},
 			Tips: []string{
 				"💡 vi always starts in command mode",
 				"💡 You must press 'i' to switch to insert mode to type text",
 				"💡 In command mode, every key is treated as a command",
-				"💡 h, j, k, l are on the left side of keyboard for easy one-hand operation",
 			},
 		},
 		{
-			Title:       "2. Saving Files and Exiting",
+			Title:       "2. Basic Cursor Movement - h, j, k, l",
+			Description: "Learn the four keys that move the cursor in command mode without leaving the home row.",
+			Commands: []LessonCommand{
+				{
+					Command:     "h",
+					Description: "Move the cursor one character left",
+					Example:     "Press h to move to the character on the left",
+					Practice:    "In command mode, press h a few times",
+				},
+				{
+					Command:     "j",
+					Description: "Move the cursor down one line",
+					Example:     "Press j to move to the next line",
+					Practice:    "Move down through the file with j",
+				},
+				{
+					Command:     "k",
+					Description: "Move the cursor up one line",
+					Example:     "Press k to move to the previous line",
+					Practice:    "Move back up with k",
+				},
+				{
+					Command:     "l",
+					Description: "Move the cursor one character right",
+					Example:     "Press l to move to the character on the right",
+					Practice:    "Move to the end of the line with l",
+				},
+			},
+			Tips: []string{
+				"💡 h, j, k, l sit under your right hand, so there is no need to reach for the arrow keys",
+				"💡 Think of j as a hook pointing down",
+				"💡 A count moves several times at once (5j: five lines down)",
+			},
+		},
+		{
+			Title:       "3. Saving Files and Exiting",
 			Description: "Learn how to save your work and exit vi.",
 			Commands: []LessonCommand{
 				{
@@ -412,13 +468,48 @@ func FormatLessonList(lessons []Lesson, level string, lang string) string {
 	}
 	output.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 
-	for i, lesson := range lessons {
-		if lang == "en" {
-			output.WriteString(fmt.Sprintf("%d. %s\n", i+1, lesson.Title))
-		} else {
-			output.WriteString(fmt.Sprintf("%d. %s\n", i+1, lesson.Title))
-		}
+	// 강의 제목에 이미 번호가 포함되어 있으므로 그대로 출력합니다
+	for _, lesson := range lessons {
+		output.WriteString(fmt.Sprintf("%s\n", lesson.Title))
 	}
 
 	return output.String()
-} 
\ No newline at end of file
+}
+
+// Levels lists the lesson levels in teaching order
+var Levels = []string{"beginner", "intermediate"}
+
+// GetLessons returns the lessons of a level, or nil for an unknown level
+func GetLessons(level, lang string) []Lesson {
+	switch level {
+	case "beginner":
+		return GetBeginnerLessons(lang)
+	case "intermediate":
+		return GetIntermediateLessons(lang)
+	}
+	return nil
+}
+
+// LessonRef points at a lesson that teaches a command
+type LessonRef struct {
+	Level  string `json:"level"`
+	Number int    `json:"number"` // 1-based position within the level
+	Title  string `json:"title"`
+}
+
+// FindLesson returns the first lesson that teaches command.
+// Lesson entries that list several keys ("h, j, k, l") match each of them.
+func FindLesson(command, lang string) (LessonRef, bool) {
+	for _, level := range Levels {
+		for i, lesson := range GetLessons(level, lang) {
+			for _, cmd := range lesson.Commands {
+				for _, name := range strings.Split(cmd.Command, ", ") {
+					if name == command {
+						return LessonRef{Level: level, Number: i + 1, Title: lesson.Title}, true
+					}
+				}
+			}
+		}
+	}
+	return LessonRef{}, false
+}
@@ -0,0 +1,109 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"vi-assistant/internal/style"
+)
+
+// Result is the answer to an "option" lookup
+type Result struct {
+	Query       string      `json:"query"`
+	Found       bool        `json:"found"`
+	Option      *Option     `json:"option,omitempty"`
+	Assignment  *Assignment `json:"assignment,omitempty"` // set when the query is a :set form like "noet" or "sw+=2"
+	Suggestions []Option    `json:"suggestions,omitempty"`
+}
+
+// Explain looks up an option by name, abbreviation or ":set" argument.
+// A leading ":set " and 'quotes' are accepted, so ":set noet", "noet"
+// and "'expandtab'" all find expandtab.
+func (db *DB) Explain(query string) *Result {
+	arg := strings.TrimSpace(query)
+	for _, prefix := range []string{":setlocal ", ":setglobal ", ":set ", "setlocal ", "setglobal ", "set ", ":se ", "se "} {
+		arg = strings.TrimSpace(strings.TrimPrefix(arg, prefix))
+	}
+	arg = strings.Trim(arg, "'")
+
+	result := &Result{Query: query}
+	a := db.Parse(arg)
+	if a.Option == nil {
+		if a.Name == "" {
+			a.Name = arg
+		}
+		result.Suggestions = db.Suggest(a.Name)
+		return result
+	}
+
+	result.Found = true
+	result.Option = a.Option
+	if a.Operator != OpShow || (a.Name != a.Option.Name && a.Name != a.Option.Abbreviation) {
+		result.Assignment = &a
+	}
+	return result
+}
+
+// FormatResult renders an option lookup for the terminal
+func FormatResult(result *Result, lang string) string {
+	var output strings.Builder
+	en := lang == "en"
+
+	if !result.Found {
+		output.WriteString(fmt.Sprintf(pick(en, "옵션을 찾을 수 없습니다: %s\n", "Option not found: %s\n"), result.Query))
+		if len(result.Suggestions) > 0 {
+			output.WriteString(pick(en, "\n비슷한 옵션:\n", "\nSimilar options:\n"))
+			for _, o := range result.Suggestions {
+				output.WriteString(fmt.Sprintf("  %s - %s\n", style.Command(o.Name), o.Describe(lang)))
+			}
+		}
+		return output.String()
+	}
+
+	o := result.Option
+	title := o.Name
+	if o.Abbreviation != "" {
+		title = fmt.Sprintf("%s (%s)", o.Name, o.Abbreviation)
+	}
+	output.WriteString(style.Heading(fmt.Sprintf(pick(en, "옵션: %s", "Option: %s"), title)) + "\n")
+	output.WriteString(fmt.Sprintf(pick(en, "설명: %s\n", "Description: %s\n"), o.Describe(lang)))
+	output.WriteString(fmt.Sprintf(pick(en, "종류: %s\n", "Type: %s\n"), TypeTitle(o.Type, lang)))
+	output.WriteString(fmt.Sprintf(pick(en, "기본값: %s\n", "Default: %s\n"), o.FormatDefault()))
+	output.WriteString(fmt.Sprintf(pick(en, "범위: %s\n", "Scope: %s\n"), ScopeTitle(o.Scope, lang)))
+
+	if a := result.Assignment; a != nil {
+		output.WriteString(fmt.Sprintf(pick(en, "\n:set %s → %s\n", "\n:set %s → %s\n"), a.Raw, a.Explain(lang)))
+		if (a.Operator == OpAssign || a.Operator == OpAppend || a.Operator == OpRemove || a.Operator == OpPrepend) && o.Type == TypeBoolean {
+			output.WriteString(fmt.Sprintf(pick(en, "⚠ %s는 켬/끔 옵션이라 값을 지정할 수 없습니다\n", "⚠ %s is a boolean option and takes no value\n"), o.Name))
+		}
+		if a.Error != "" {
+			output.WriteString(fmt.Sprintf(pick(en, "⚠ %s는 숫자 옵션이라 정수 값이 필요합니다 (%s)\n", "⚠ %s is a number option and takes an integer (%s)\n"), o.Name, a.Error))
+		}
+	}
+
+	output.WriteString(pick(en, "\n사용 예시:\n", "\nExamples:\n"))
+	for _, example := range o.Examples() {
+		output.WriteString(fmt.Sprintf("  %s\n", style.Command(example)))
+	}
+
+	output.WriteString("\n")
+	if len(o.SeeAlso) > 0 {
+		output.WriteString(fmt.Sprintf(pick(en, "함께 보기: %s\n", "See also: %s\n"), strings.Join(o.SeeAlso, ", ")))
+	}
+	output.WriteString(fmt.Sprintf(pick(en, "Vim 도움말: :help '%s'\n", "Vim help: :help '%s'\n"), o.Name))
+	return output.String()
+}
+
+// TypeTitle returns the localized type name
+func TypeTitle(typ, lang string) string {
+	titles := map[string][2]string{
+		TypeBoolean: {"켬/끔 (boolean)", "on/off (boolean)"},
+		TypeNumber:  {"숫자 (number)", "number"},
+		TypeString:  {"문자열 (string)", "string"},
+	}
+	title, ok := titles[typ]
+	if !ok {
+		return typ
+	}
+	return pick(lang == "en", title[0], title[1])
+}
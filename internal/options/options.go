@@ -0,0 +1,438 @@
+// Package options is the database of Vim ":set" options (data/options.json)
+// and the parser for ":set" arguments such as "noexpandtab" or "path+=**".
+package options
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vi-assistant/internal/paths"
+)
+
+// Option types
+const (
+	TypeBoolean = "boolean"
+	TypeNumber  = "number"
+	TypeString  = "string"
+)
+
+// Option scopes
+const (
+	ScopeGlobal      = "global"
+	ScopeBuffer      = "buffer"       // local to buffer
+	ScopeWindow      = "window"       // local to window
+	ScopeGlobalLocal = "global-local" // global with a local value
+)
+
+// Option describes a single Vim option
+type Option struct {
+	Name         string            `json:"name"`
+	Abbreviation string            `json:"abbreviation,omitempty"`
+	Type         string            `json:"type"`
+	Default      interface{}       `json:"default"`
+	Scope        string            `json:"scope"`
+	Description  map[string]string `json:"description"` // 언어별 설명 (ko, en)
+	SeeAlso      []string          `json:"see_also,omitempty"`
+}
+
+// Describe returns the description in lang, falling back to Korean
+func (o Option) Describe(lang string) string {
+	if d, ok := o.Description[lang]; ok && d != "" {
+		return d
+	}
+	return o.Description["ko"]
+}
+
+// FormatDefault renders the default value as :set would show it
+func (o Option) FormatDefault() string {
+	switch v := o.Default.(type) {
+	case bool:
+		if v {
+			return o.Name
+		}
+		return "no" + o.Name
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	}
+	return fmt.Sprint(o.Default)
+}
+
+// ScopeTitle returns the localized scope name
+func ScopeTitle(scope, lang string) string {
+	titles := map[string][2]string{
+		ScopeGlobal:      {"전역", "global"},
+		ScopeBuffer:      {"버퍼마다", "local to buffer"},
+		ScopeWindow:      {"창마다", "local to window"},
+		ScopeGlobalLocal: {"전역 (버퍼/창별 값 가능)", "global or local"},
+	}
+	title, ok := titles[scope]
+	if !ok {
+		return scope
+	}
+	if lang == "en" {
+		return title[1]
+	}
+	return title[0]
+}
+
+// DefaultSource is the bundled options file, relative to the working
+// directory or to the directory of the executable
+var DefaultSource = filepath.Join("data", "options.json")
+
+var source = DefaultSource
+
+// SetSource replaces the options file. An empty path restores DefaultSource.
+func SetSource(path string) {
+	if path == "" {
+		path = DefaultSource
+	}
+	source = path
+}
+
+// DB is a loaded options database
+type DB struct {
+	Options []Option
+	byName  map[string]int // name and abbreviation -> index
+}
+
+// document is the options file layout
+type document struct {
+	SchemaVersion int      `json:"schema_version"`
+	Options       []Option `json:"options"`
+}
+
+// Load reads the options database
+func Load() (*DB, error) {
+	path := paths.Bundled(source)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s 파일을 읽을 수 없습니다: %v", filepath.Base(path), err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s JSON 파싱 오류: %v", filepath.Base(path), err)
+	}
+	if doc.SchemaVersion != 1 {
+		return nil, fmt.Errorf("%s: 지원하지 않는 schema_version입니다: %d", filepath.Base(path), doc.SchemaVersion)
+	}
+	return NewDB(doc.Options), nil
+}
+
+// NewDB indexes options by name and abbreviation
+func NewDB(opts []Option) *DB {
+	db := &DB{Options: opts, byName: make(map[string]int)}
+	for i, o := range opts {
+		db.byName[o.Name] = i
+		if o.Abbreviation != "" {
+			db.byName[o.Abbreviation] = i
+		}
+	}
+	return db
+}
+
+// Lookup finds an option by full name or abbreviation
+func (db *DB) Lookup(name string) (*Option, bool) {
+	i, ok := db.byName[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+	return &db.Options[i], true
+}
+
+// Names returns every option name in alphabetical order
+func (db *DB) Names() []string {
+	names := make([]string, 0, len(db.Options))
+	for _, o := range db.Options {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Operators of a :set argument
+const (
+	OpShow    = ""   // "set ts" shows a value; "set et" switches a boolean on
+	OpOff     = "no" // "set noet"
+	OpToggle  = "inv"
+	OpDefault = "&"
+	OpQuery   = "?"
+	OpAssign  = "="
+	OpAppend  = "+="
+	OpRemove  = "-="
+	OpPrepend = "^="
+)
+
+// Assignment is one parsed :set argument
+type Assignment struct {
+	Raw      string  `json:"raw"`
+	Name     string  `json:"name"` // option name as written, without no/inv prefix
+	Operator string  `json:"operator"`
+	Value    string  `json:"value,omitempty"`
+	Option   *Option `json:"option,omitempty"` // nil when the option is unknown
+	Error    string  `json:"error,omitempty"`  // the error Vim gives for the value, e.g. E521
+}
+
+// Parse reads one :set argument: "et", "noet", "invhls", "hls!", "ts&",
+// "ts?", "ts=4", "sw:4", "path+=**", "cc-=80" or "cpo^=x".
+// The "no" and "inv" prefixes only count when the rest names a boolean
+// option, so an option that merely starts with "no" is not misread.
+func (db *DB) Parse(arg string) Assignment {
+	a := Assignment{Raw: arg}
+
+	nameEnd := 0
+	for nameEnd < len(arg) && isNameChar(arg[nameEnd]) {
+		nameEnd++
+	}
+	a.Name = arg[:nameEnd]
+	rest := arg[nameEnd:]
+
+	switch {
+	case strings.HasPrefix(rest, "+="):
+		a.Operator, a.Value = OpAppend, rest[2:]
+	case strings.HasPrefix(rest, "-="):
+		a.Operator, a.Value = OpRemove, rest[2:]
+	case strings.HasPrefix(rest, "^="):
+		a.Operator, a.Value = OpPrepend, rest[2:]
+	case strings.HasPrefix(rest, "="), strings.HasPrefix(rest, ":"):
+		a.Operator, a.Value = OpAssign, rest[1:]
+	case strings.HasPrefix(rest, "!"):
+		a.Operator = OpToggle
+	case strings.HasPrefix(rest, "&"):
+		a.Operator = OpDefault
+	case strings.HasPrefix(rest, "?"):
+		a.Operator = OpQuery
+	}
+	a.Value = strings.ReplaceAll(a.Value, `\ `, " ")
+
+	if opt, ok := db.Lookup(a.Name); ok {
+		a.Option = opt
+		a.Error = a.check()
+		return a
+	}
+
+	if a.Operator == OpShow {
+		for _, prefix := range []string{OpToggle, OpOff} {
+			if !strings.HasPrefix(a.Name, prefix) {
+				continue
+			}
+			if opt, ok := db.Lookup(strings.TrimPrefix(a.Name, prefix)); ok && opt.Type == TypeBoolean {
+				a.Name = strings.TrimPrefix(a.Name, prefix)
+				a.Operator = prefix
+				a.Option = opt
+				return a
+			}
+		}
+	}
+	return a
+}
+
+// check returns the error Vim gives for a value the option does not accept.
+// Number options take a decimal, 0x hexadecimal or 0 octal integer.
+func (a Assignment) check() string {
+	if a.Option.Type != TypeNumber {
+		return ""
+	}
+	switch a.Operator {
+	case OpAssign, OpAppend, OpRemove, OpPrepend:
+		if _, err := strconv.ParseInt(a.Value, 0, 64); err != nil || strings.Contains(a.Value, "_") {
+			return "E521: Number required after =: " + a.Raw
+		}
+	}
+	return ""
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+// Explain describes what the assignment does, e.g. "켬" or "값을 4로 설정"
+func (a Assignment) Explain(lang string) string {
+	en := lang == "en"
+	isBool := a.Option != nil && a.Option.Type == TypeBoolean
+
+	switch a.Operator {
+	case OpShow:
+		if isBool {
+			return pick(en, "켬", "on")
+		}
+		return pick(en, "현재 값 표시", "show the current value")
+	case OpOff:
+		return pick(en, "끔", "off")
+	case OpToggle:
+		return pick(en, "켬/끔 전환", "toggle")
+	case OpDefault:
+		return pick(en, "기본값으로 되돌림", "reset to the default")
+	case OpQuery:
+		return pick(en, "현재 값 표시", "show the current value")
+	case OpAssign:
+		return fmt.Sprintf(pick(en, "값을 %s(으)로 설정", "set to %s"), quoteEmpty(a.Value))
+	case OpAppend:
+		if a.Option != nil && a.Option.Type == TypeNumber {
+			return fmt.Sprintf(pick(en, "값에 %s 더하기", "add %s"), a.Value)
+		}
+		return fmt.Sprintf(pick(en, "목록에 %s 추가", "append %s"), a.Value)
+	case OpRemove:
+		if a.Option != nil && a.Option.Type == TypeNumber {
+			return fmt.Sprintf(pick(en, "값에서 %s 빼기", "subtract %s"), a.Value)
+		}
+		return fmt.Sprintf(pick(en, "목록에서 %s 제거", "remove %s"), a.Value)
+	case OpPrepend:
+		if a.Option != nil && a.Option.Type == TypeNumber {
+			return fmt.Sprintf(pick(en, "값에 %s 곱하기", "multiply by %s"), a.Value)
+		}
+		return fmt.Sprintf(pick(en, "목록 앞에 %s 추가", "prepend %s"), a.Value)
+	}
+	return a.Raw
+}
+
+func pick(en bool, ko, english string) string {
+	if en {
+		return english
+	}
+	return ko
+}
+
+func quoteEmpty(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return s
+}
+
+// SplitArgs splits the argument list of a :set command at unescaped
+// whitespace and stops at a trailing comment ("set et " comment")
+func SplitArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			current.WriteByte(c)
+			current.WriteByte(s[i+1])
+			i++
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				args = append(args, current.String())
+				current.Reset()
+			}
+		case c == '"' && current.Len() == 0:
+			return args
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// Search finds options whose name, abbreviation or description contains
+// text. Exact names, abbreviations and ":set" forms such as "noet" come first.
+func (db *DB) Search(text string) []Option {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+
+	var exact, partial []Option
+	seen := make(map[string]bool)
+
+	if a := db.Parse(strings.Trim(text, "'")); a.Option != nil {
+		exact = append(exact, *a.Option)
+		seen[a.Option.Name] = true
+	}
+
+	for _, o := range db.Options {
+		if seen[o.Name] {
+			continue
+		}
+		if strings.Contains(o.Name, text) || (o.Abbreviation != "" && strings.Contains(o.Abbreviation, text)) {
+			partial = append(partial, o)
+			seen[o.Name] = true
+			continue
+		}
+		for _, d := range o.Description {
+			if strings.Contains(strings.ToLower(d), text) {
+				partial = append(partial, o)
+				seen[o.Name] = true
+				break
+			}
+		}
+	}
+	return append(exact, partial...)
+}
+
+// Suggest returns options whose name or abbreviation is close to name:
+// sharing a prefix or within two edits
+func (db *DB) Suggest(name string) []Option {
+	name = strings.ToLower(name)
+	var suggestions []Option
+	for _, o := range db.Options {
+		if len(name) >= 2 && (strings.HasPrefix(o.Name, name) || strings.HasPrefix(name, o.Name)) ||
+			editDistance(o.Name, name) <= 2 ||
+			o.Abbreviation != "" && len(name) <= 4 && editDistance(o.Abbreviation, name) <= 1 {
+			suggestions = append(suggestions, o)
+		}
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between two ASCII strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// Examples returns typical :set forms for the option
+func (o Option) Examples() []string {
+	name := o.Name
+	if o.Abbreviation != "" {
+		name = o.Abbreviation
+	}
+	switch o.Type {
+	case TypeBoolean:
+		return []string{":set " + name, ":set no" + name, ":set " + name + "!", ":set " + name + "?"}
+	case TypeNumber:
+		return []string{":set " + name + "=" + o.FormatDefault(), ":set " + name + "+=1", ":set " + name + "&"}
+	}
+	return []string{":set " + name + "?", ":set " + name + "+=…", ":set " + name + "-=…", ":set " + name + "&"}
+}
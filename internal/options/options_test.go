@@ -0,0 +1,153 @@
+package options
+
+import "testing"
+
+func testDB() *DB {
+	return NewDB([]Option{
+		{Name: "expandtab", Abbreviation: "et", Type: TypeBoolean, Default: false},
+		{Name: "number", Abbreviation: "nu", Type: TypeBoolean, Default: false},
+		{Name: "shiftwidth", Abbreviation: "sw", Type: TypeNumber, Default: float64(8)},
+		{Name: "path", Abbreviation: "pa", Type: TypeString, Default: ".,,"},
+		{Name: "nrformats", Abbreviation: "nf", Type: TypeString, Default: "bin,octal,hex"},
+	})
+}
+
+func TestParse(t *testing.T) {
+	db := testDB()
+	tests := []struct {
+		arg, name, op, value string
+	}{
+		{"et", "et", OpShow, ""},
+		{"noet", "et", OpOff, ""},
+		{"noexpandtab", "expandtab", OpOff, ""},
+		{"invnu", "nu", OpToggle, ""},
+		{"nu!", "nu", OpToggle, ""},
+		{"sw&", "sw", OpDefault, ""},
+		{"sw?", "sw", OpQuery, ""},
+		{"sw=4", "sw", OpAssign, "4"},
+		{"sw:4", "sw", OpAssign, "4"},
+		{"sw+=2", "sw", OpAppend, "2"},
+		{"path+=**", "path", OpAppend, "**"},
+		{"nf-=octal", "nf", OpRemove, "octal"},
+		{"path^=src", "path", OpPrepend, "src"},
+		{`path=a\ b`, "path", OpAssign, "a b"},
+		// "nrformats" starts with "no"-like letters but is not a boolean
+		{"nrformats=hex", "nrformats", OpAssign, "hex"},
+	}
+
+	for _, tt := range tests {
+		a := db.Parse(tt.arg)
+		if a.Name != tt.name || a.Operator != tt.op || a.Value != tt.value || a.Option == nil {
+			t.Errorf("Parse(%q) = %q %q %q (option %v), want %q %q %q", tt.arg, a.Name, a.Operator, a.Value, a.Option != nil, tt.name, tt.op, tt.value)
+		}
+	}
+
+	// "no" only strips from boolean options
+	if a := db.Parse("nopath"); a.Option != nil {
+		t.Errorf("Parse(nopath) found %s", a.Option.Name)
+	}
+	if a := db.Parse("nosuch"); a.Option != nil || a.Name != "nosuch" {
+		t.Errorf("Parse(nosuch) = %+v", a)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	got := SplitArgs(`et  sw=4 path=a\ b " comment`)
+	want := []string{"et", "sw=4", `path=a\ b`}
+	if len(got) != len(want) {
+		t.Fatalf("SplitArgs = %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("arg %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBundledDatabase(t *testing.T) {
+	SetSource("../../data/options.json")
+	defer SetSource("")
+
+	db, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range db.Options {
+		if o.Description["ko"] == "" || o.Description["en"] == "" {
+			t.Errorf("%s: missing ko/en description", o.Name)
+		}
+		switch o.Type {
+		case TypeBoolean, TypeNumber, TypeString:
+		default:
+			t.Errorf("%s: unknown type %q", o.Name, o.Type)
+		}
+	}
+	if _, ok := db.Lookup("ts"); !ok {
+		t.Error("Lookup(ts) failed")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	db := testDB()
+
+	got := db.Search("noet")
+	if len(got) == 0 || got[0].Name != "expandtab" {
+		t.Errorf("Search(noet) = %v", got)
+	}
+
+	got = db.Search("nu")
+	if len(got) != 1 || got[0].Name != "number" {
+		t.Errorf("Search(nu) = %v", got)
+	}
+	if got := db.Search(""); got != nil {
+		t.Errorf("Search(\"\") = %v", got)
+	}
+}
+
+func TestParseNumberValue(t *testing.T) {
+	db := testDB()
+	tests := map[string]bool{ // argument -> Vim rejects it
+		"sw=4":    false,
+		"sw+=2":   false,
+		"sw=0x10": false,
+		"sw=abc":  true,
+		"sw=4x":   true,
+		"sw=":     true,
+		"sw-=x":   true,
+		"sw?":     false,
+		"path=ab": false, // string options take any value
+	}
+	for arg, rejected := range tests {
+		a := db.Parse(arg)
+		if (a.Error != "") != rejected {
+			t.Errorf("Parse(%q).Error = %q, want rejected %v", arg, a.Error, rejected)
+		}
+	}
+	if a := db.Parse("sw=abc"); a.Error != "E521: Number required after =: sw=abc" {
+		t.Errorf("Parse(sw=abc).Error = %q, want E521", a.Error)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	db := testDB()
+
+	r := db.Explain(":set noet")
+	if !r.Found || r.Option.Name != "expandtab" || r.Assignment == nil || r.Assignment.Operator != OpOff {
+		t.Errorf("Explain(:set noet) = %+v", r)
+	}
+
+	// A plain name is a lookup, not an assignment
+	if r = db.Explain("'shiftwidth'"); !r.Found || r.Assignment != nil {
+		t.Errorf("Explain('shiftwidth') = %+v", r)
+	}
+
+	r = db.Explain("sw+=2")
+	if r.Assignment == nil || r.Assignment.Explain("en") != "add 2" {
+		t.Errorf("Explain(sw+=2) = %+v", r)
+	}
+
+	r = db.Explain("expandtabs")
+	if r.Found || len(r.Suggestions) == 0 || r.Suggestions[0].Name != "expandtab" {
+		t.Errorf("Explain(expandtabs) = %+v", r)
+	}
+}
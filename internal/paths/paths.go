@@ -0,0 +1,236 @@
+// Package paths resolves where vi-assistant keeps its config, data and state
+// following the XDG base directory specification.
+package paths
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// AppName is the directory name used under each base directory
+const AppName = "vi-assistant"
+
+// Paths holds the resolved base directories for the application
+type Paths struct {
+	ConfigDir string // settings ($XDG_CONFIG_HOME/vi-assistant)
+	DataDir   string // user data such as favorites ($XDG_DATA_HOME/vi-assistant)
+	StateDir  string // backups and other state ($XDG_STATE_HOME/vi-assistant)
+}
+
+// ConfigFile returns the default config file path
+func (p Paths) ConfigFile() string {
+	return filepath.Join(p.ConfigDir, "config.yaml")
+}
+
+// FavoritesFile returns the favorites file path
+func (p Paths) FavoritesFile() string {
+	return filepath.Join(p.DataDir, "favorites.json")
+}
+
+// Resolve returns the XDG locations, replacing any directory that is set in overrides.
+// A leading "~" in an override is expanded to the home directory.
+func Resolve(overrides Paths) (Paths, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, fmt.Errorf("홈 디렉토리를 찾을 수 없습니다: %v", err)
+	}
+
+	p := Paths{
+		ConfigDir: filepath.Join(baseDir("XDG_CONFIG_HOME", home, ".config", "APPDATA"), AppName),
+		DataDir:   filepath.Join(baseDir("XDG_DATA_HOME", home, filepath.Join(".local", "share"), "APPDATA"), AppName),
+		StateDir:  filepath.Join(baseDir("XDG_STATE_HOME", home, filepath.Join(".local", "state"), "LOCALAPPDATA"), AppName),
+	}
+
+	if overrides.ConfigDir != "" {
+		p.ConfigDir = ExpandHome(overrides.ConfigDir, home)
+	}
+	if overrides.DataDir != "" {
+		p.DataDir = ExpandHome(overrides.DataDir, home)
+	}
+	if overrides.StateDir != "" {
+		p.StateDir = ExpandHome(overrides.StateDir, home)
+	}
+
+	return p, nil
+}
+
+// baseDir picks the XDG environment variable, the platform default on Windows,
+// or the XDG fallback under the home directory, in that order.
+// The spec requires XDG variables to be absolute; relative values are ignored.
+func baseDir(xdgVar, home, fallback, windowsVar string) string {
+	if dir := os.Getenv(xdgVar); dir != "" && filepath.IsAbs(dir) {
+		return dir
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv(windowsVar); dir != "" {
+			return dir
+		}
+	}
+	return filepath.Join(home, fallback)
+}
+
+// ExpandHome expands a leading "~" to the given home directory
+func ExpandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if len(path) > 1 && path[0] == '~' && (path[1] == '/' || path[1] == filepath.Separator) {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+// LegacyDir returns the pre-XDG data directory (~/.vi-assistant)
+func LegacyDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".vi-assistant"), nil
+}
+
+// LegacyConfigFile returns the pre-XDG config file (~/.vi-assistant.yaml)
+func LegacyConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".vi-assistant.yaml"), nil
+}
+
+// migratedMarker is created in the state directory once the legacy files have been moved
+const migratedMarker = ".migrated"
+
+// Migrated reports whether the legacy migration has already run for p
+func Migrated(p Paths) bool {
+	_, err := os.Stat(filepath.Join(p.StateDir, migratedMarker))
+	return err == nil
+}
+
+// MarkMigrated records that the legacy migration has run, so later runs skip it
+func MarkMigrated(p Paths) error {
+	if err := os.MkdirAll(p.StateDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(p.StateDir, migratedMarker), []byte(time.Now().Format(time.RFC3339)+"\n"), 0644)
+}
+
+// Move records a file moved by a migration
+type Move struct {
+	From string
+	To   string
+}
+
+// MigrateConfig moves ~/.vi-assistant.yaml to the XDG config file
+// unless a config file already exists there.
+func MigrateConfig(p Paths) ([]Move, error) {
+	legacy, err := LegacyConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return migrate([]Move{{From: legacy, To: p.ConfigFile()}})
+}
+
+// MigrateData moves files from ~/.vi-assistant into the data and state directories.
+// Files that already exist at the destination are left alone, and the legacy
+// directory is removed once nothing but lock files remain in it.
+func MigrateData(p Paths) ([]Move, error) {
+	legacy, err := LegacyDir()
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	moves, err := migrate([]Move{
+		{From: filepath.Join(legacy, "favorites.json"), To: p.FavoritesFile()},
+		{From: filepath.Join(legacy, "collections.json"), To: filepath.Join(p.DataDir, "collections.json")},
+		{From: filepath.Join(legacy, "favorites.json.bak"), To: filepath.Join(p.StateDir, "favorites.json.bak")},
+	})
+	if err != nil {
+		return moves, err
+	}
+
+	// Lock files carry no data; drop them so the empty directory can go
+	os.Remove(filepath.Join(legacy, "favorites.json.lock"))
+	os.Remove(filepath.Join(legacy, "collections.json.lock"))
+	os.Remove(legacy) // fails harmlessly if anything else is left
+
+	return moves, nil
+}
+
+// migrate performs the moves whose source exists and destination does not
+func migrate(candidates []Move) ([]Move, error) {
+	var done []Move
+	for _, m := range candidates {
+		if _, err := os.Stat(m.From); err != nil {
+			continue
+		}
+		if _, err := os.Stat(m.To); err == nil {
+			continue
+		}
+		if err := moveFile(m.From, m.To); err != nil {
+			return done, fmt.Errorf("%s 파일을 %s(으)로 옮길 수 없습니다: %v", m.From, m.To, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// moveFile renames src to dst, copying when the rename crosses file systems
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
+
+// Bundled finds a data file shipped with the program (e.g. data/commands.json).
+// A relative path is looked up in the working directory first, then next to
+// the executable so the binary also works from other directories.
+// The path is returned unchanged when neither exists.
+func Bundled(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	if exe, err := os.Executable(); err == nil {
+		candidate := filepath.Join(filepath.Dir(exe), path)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return path
+}
@@ -0,0 +1,50 @@
+package paths
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarkMigrated(t *testing.T) {
+	p := Paths{StateDir: filepath.Join(t.TempDir(), "state")}
+	if Migrated(p) {
+		t.Fatal("Migrated before MarkMigrated")
+	}
+	if err := MarkMigrated(p); err != nil {
+		t.Fatal(err)
+	}
+	if !Migrated(p) {
+		t.Error("Migrated = false after MarkMigrated")
+	}
+}
+
+func TestMigrateData(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	legacy := filepath.Join(home, ".vi-assistant")
+	os.MkdirAll(legacy, 0755)
+	ioutil.WriteFile(filepath.Join(legacy, "favorites.json"), []byte("[]"), 0644)
+	ioutil.WriteFile(filepath.Join(legacy, "favorites.json.lock"), nil, 0644)
+
+	p := Paths{DataDir: filepath.Join(home, "data"), StateDir: filepath.Join(home, "state")}
+	// An existing destination is never overwritten
+	os.MkdirAll(p.DataDir, 0755)
+	ioutil.WriteFile(filepath.Join(p.DataDir, "collections.json"), []byte("mine"), 0644)
+	ioutil.WriteFile(filepath.Join(legacy, "collections.json"), []byte("old"), 0644)
+
+	moves, err := MigrateData(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moves) != 1 || moves[0].To != p.FavoritesFile() {
+		t.Errorf("moves = %v, want only favorites.json", moves)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(p.DataDir, "collections.json")); string(data) != "mine" {
+		t.Errorf("collections.json = %q, want the existing file", data)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "favorites.json.lock")); !os.IsNotExist(err) {
+		t.Error("legacy lock file was not removed")
+	}
+}
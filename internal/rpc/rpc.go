@@ -0,0 +1,403 @@
+// Package rpc answers line-delimited JSON-RPC 2.0 requests over a pair of
+// streams, normally stdin and stdout, so an editor plugin can keep one
+// process running and ask it to explain commands, search the catalog,
+// parse typed keys, look up options and manage favorites.
+//
+// Every request and response is one JSON object on one line. Requests
+// without an "id" are notifications and get no response.
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/explain"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/keylog"
+	"vi-assistant/internal/options"
+	"vi-assistant/internal/search"
+)
+
+// ProtocolVersion is raised when a method or its parameters change incompatibly
+const ProtocolVersion = 1
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeNotFound       = -32001 // the command, option or favorite does not exist
+)
+
+// Request is a JSON-RPC request
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response; exactly one of Result and Error is set
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(code int, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Options configures the server
+type Options struct {
+	SearchLimit int  // default search limit (0 means no limit)
+	ReadOnly    bool // reject favorites changes
+
+	// Favorites opens the favorites store; nil disables the favorites method
+	Favorites func() (*favorites.FavoritesManager, error)
+}
+
+// Server handles requests
+type Server struct {
+	opts    Options
+	methods map[string]func(json.RawMessage) (interface{}, error)
+}
+
+// New creates a server
+func New(opts Options) *Server {
+	s := &Server{opts: opts}
+	s.methods = map[string]func(json.RawMessage) (interface{}, error){
+		"version":    s.version,
+		"explain":    s.explain,
+		"search":     s.search,
+		"parse-keys": s.parseKeys,
+		"options":    s.options,
+		"favorites":  s.favorites,
+	}
+	return s
+}
+
+// Methods returns the method names in alphabetical order
+func (s *Server) Methods() []string {
+	names := make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Serve answers requests read from r, one per line, until the end of input.
+// Blank lines are ignored.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	out := json.NewEncoder(w) // Encode ends every response with a newline
+	out.SetEscapeHTML(false)
+	for {
+		line, err := in.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if resp := s.Handle(line); resp != nil {
+				if err := out.Encode(resp); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Handle answers a single request line; it returns nil for notifications
+func (s *Server) Handle(line []byte) *Response {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("[")) {
+			return failure(nil, errorf(CodeInvalidRequest, "일괄 요청은 지원하지 않습니다"))
+		}
+		return failure(nil, errorf(CodeParseError, "JSON 형식이 잘못되었습니다: %v", err))
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return failure(req.ID, errorf(CodeInvalidRequest, "jsonrpc \"2.0\"과 method가 필요합니다"))
+	}
+
+	method, ok := s.methods[req.Method]
+	if !ok {
+		if req.ID == nil {
+			return nil
+		}
+		return failure(req.ID, errorf(CodeMethodNotFound, "알 수 없는 메서드입니다: %s (사용 가능: %s)", req.Method, strings.Join(s.Methods(), ", ")))
+	}
+
+	result, err := method(req.Params)
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		rpcErr, ok := err.(*Error)
+		if !ok {
+			rpcErr = errorf(CodeInternalError, "%v", err)
+		}
+		return failure(req.ID, rpcErr)
+	}
+	return &Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func failure(id json.RawMessage, err *Error) *Response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// decode reads named parameters into v; missing params leave v unchanged
+func decode(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return errorf(CodeInvalidParams, "매개변수가 잘못되었습니다: %v", err)
+	}
+	return nil
+}
+
+// version reports the protocol version and methods, for plugins to check on start
+func (s *Server) version(params json.RawMessage) (interface{}, error) {
+	return map[string]interface{}{"protocol": ProtocolVersion, "methods": s.Methods()}, nil
+}
+
+// explain: {"command": "dd"} -> the explain result; unknown commands have
+// "found": false and suggestions rather than an error
+func (s *Server) explain(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Command string `json:"command"`
+	}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Command == "" {
+		return nil, errorf(CodeInvalidParams, "command가 필요합니다")
+	}
+	return explain.Explain(p.Command)
+}
+
+// search: {"query": "delete mode:visual", "limit": 5}
+func (s *Server) search(params json.RawMessage) (interface{}, error) {
+	p := struct {
+		Query string `json:"query"`
+		Limit *int   `json:"limit"`
+	}{}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(p.Query) == "" {
+		return nil, errorf(CodeInvalidParams, "query가 필요합니다")
+	}
+	limit := s.opts.SearchLimit
+	if p.Limit != nil {
+		if *p.Limit < 0 {
+			return nil, errorf(CodeInvalidParams, "limit 값이 잘못되었습니다: %d", *p.Limit)
+		}
+		limit = *p.Limit
+	}
+
+	results, err := search.Search(p.Query)
+	if err != nil {
+		return nil, errorf(CodeInvalidParams, "%v", err)
+	}
+	results.Limit(limit)
+	return results, nil
+}
+
+// KeyCommand is a command found in typed keys, with its catalog entry when there is one
+type KeyCommand struct {
+	keylog.Token
+	CatalogCommand string `json:"catalog_command,omitempty"`
+	Description    string `json:"description,omitempty"`
+}
+
+// parseKeys: {"keys": "3dw<Esc>"} -> the commands in the keys, in order.
+// Keys are in Vim's notation (keytrans()); with "raw": true they are the typed bytes.
+func (s *Server) parseKeys(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Keys string `json:"keys"`
+		Raw  bool   `json:"raw"`
+	}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Keys == "" {
+		return nil, errorf(CodeInvalidParams, "keys가 필요합니다")
+	}
+
+	commands, err := catalog.Load()
+	if err != nil {
+		return nil, err
+	}
+	an := keylog.NewAnalyzer(commands, "")
+	byName := make(map[string]catalog.Command)
+	for _, cmd := range commands {
+		if _, exists := byName[cmd.Command]; !exists {
+			byName[cmd.Command] = cmd
+		}
+	}
+
+	keys := keylog.SplitNotation(p.Keys)
+	if p.Raw {
+		keys = keylog.Decode([]byte(p.Keys))
+	}
+	result := []KeyCommand{}
+	for _, tok := range keylog.Parse(keys) {
+		kc := KeyCommand{Token: tok}
+		if cmd, ok := byName[an.CatalogName(tok)]; ok {
+			kc.CatalogCommand, kc.Description = cmd.Command, cmd.Description
+		}
+		result = append(result, kc)
+	}
+	return map[string]interface{}{"commands": result}, nil
+}
+
+// options: {"name": "sw+=2"} explains one option or :set argument,
+// {"search": "case"} finds options, and no parameters list every option
+func (s *Server) options(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name   string `json:"name"`
+		Search string `json:"search"`
+	}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	db, err := options.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.Name != "":
+		return db.Explain(p.Name), nil
+	case p.Search != "":
+		found := db.Search(p.Search)
+		if found == nil {
+			found = []options.Option{}
+		}
+		return map[string]interface{}{"options": found}, nil
+	}
+	var all []options.Option
+	for _, name := range db.Names() {
+		if o, ok := db.Lookup(name); ok {
+			all = append(all, *o)
+		}
+	}
+	return map[string]interface{}{"options": all}, nil
+}
+
+// favorites: {"action": "list", "tag": "git", "sort": "usage"} (the default action),
+// {"action": "add", "command": "dd", "tags": [...], "note": "..."} answering the new
+// favorite, or {"action": "remove", "command": "dd"}
+func (s *Server) favorites(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Action  string   `json:"action"`
+		Command string   `json:"command"`
+		Tags    []string `json:"tags"`
+		Note    string   `json:"note"`
+		Tag     string   `json:"tag"`
+		Sort    string   `json:"sort"`
+	}
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if s.opts.Favorites == nil {
+		return nil, errorf(CodeNotFound, "즐겨찾기를 사용할 수 없습니다")
+	}
+	switch p.Action {
+	case "", "list", "add", "remove":
+	default:
+		return nil, errorf(CodeInvalidParams, "알 수 없는 action입니다: %s (list, add, remove)", p.Action)
+	}
+	if p.Action == "add" || p.Action == "remove" {
+		if s.opts.ReadOnly {
+			return nil, errorf(CodeInvalidRequest, "읽기 전용입니다")
+		}
+		if p.Command == "" {
+			return nil, errorf(CodeInvalidParams, "command가 필요합니다")
+		}
+	}
+
+	fm, err := s.opts.Favorites()
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.Action {
+	case "add":
+		result, err := explain.Explain(p.Command)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Found {
+			return nil, errorf(CodeNotFound, "명령어를 찾을 수 없습니다: %s", p.Command)
+		}
+		fav := favorites.Favorite{
+			Command:     result.Command.Command,
+			Description: result.Command.Description,
+			Category:    result.Command.Category,
+			Tags:        p.Tags,
+			Note:        p.Note,
+		}
+		if err := fm.Add(fav); err != nil {
+			return nil, errorf(CodeInvalidParams, "%v", err)
+		}
+		// Answer with the stored entry, which has AddedAt and normalized tags
+		if list, err := fm.List(); err == nil {
+			for _, stored := range list {
+				if stored.Command == fav.Command {
+					fav = stored
+				}
+			}
+		}
+		return fav, nil
+
+	case "remove":
+		if err := fm.Remove(p.Command); err != nil {
+			return nil, errorf(CodeNotFound, "%v", err)
+		}
+		return map[string]interface{}{"removed": p.Command}, nil
+	}
+
+	list, err := fm.List()
+	if err != nil {
+		return nil, err
+	}
+	list = favorites.FilterByTag(list, p.Tag)
+	if err := favorites.SortFavorites(list, p.Sort); err != nil {
+		return nil, errorf(CodeInvalidParams, "%v", err)
+	}
+	if list == nil {
+		list = []favorites.Favorite{}
+	}
+	return map[string]interface{}{"favorites": list, "count": len(list)}, nil
+}
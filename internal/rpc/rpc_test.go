@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/testenv"
+)
+
+// newTestServer answers requests from the bundled data with empty favorites
+func newTestServer(t *testing.T, opts Options) *Server {
+	t.Helper()
+	testenv.UseBundledData(t)
+	opts.Favorites = testenv.Favorites(t)
+	return New(opts)
+}
+
+// session sends request lines and returns the decoded response lines
+func session(t *testing.T, s *Server, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Serve(strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+	var responses []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func result(t *testing.T, resp map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	if resp["error"] != nil {
+		t.Fatalf("error response: %v", resp["error"])
+	}
+	r, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("result = %v", resp["result"])
+	}
+	return r
+}
+
+func errorCode(resp map[string]interface{}) int {
+	e, ok := resp["error"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	return int(e["code"].(float64))
+}
+
+func TestVersionAndExplain(t *testing.T) {
+	s := newTestServer(t, Options{})
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"version"}`,
+		`{"jsonrpc":"2.0","id":"a","method":"explain","params":{"command":"dd"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"explain","params":{"command":"ddd"}}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses", len(responses))
+	}
+
+	version := result(t, responses[0])
+	if version["protocol"].(float64) != ProtocolVersion || len(version["methods"].([]interface{})) != 6 {
+		t.Errorf("version = %v", version)
+	}
+
+	if responses[1]["id"] != "a" {
+		t.Errorf("id = %v", responses[1]["id"])
+	}
+	dd := result(t, responses[1])
+	if dd["found"] != true || dd["command"].(map[string]interface{})["command"] != "dd" {
+		t.Errorf("explain dd = %v", dd)
+	}
+	if missing := result(t, responses[2]); missing["found"] != false {
+		t.Errorf("explain ddd = %v", missing)
+	}
+}
+
+func TestSearchAndOptions(t *testing.T) {
+	s := newTestServer(t, Options{})
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"search","params":{"query":"delete mode:normal","limit":2}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"search","params":{"query":"mode:bogus"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"options","params":{"name":"sw+=2"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"options","params":{"search":"case"}}`,
+	)
+
+	found := result(t, responses[0])
+	if commands := found["commands"].([]interface{}); len(commands) != 2 {
+		t.Errorf("search returned %d commands, want the limit 2", len(commands))
+	}
+	if errorCode(responses[1]) != CodeInvalidParams {
+		t.Errorf("bad filter = %v", responses[1])
+	}
+
+	sw := result(t, responses[2])
+	if sw["found"] != true || sw["option"].(map[string]interface{})["name"] != "shiftwidth" || sw["assignment"] == nil {
+		t.Errorf("options sw+=2 = %v", sw)
+	}
+	if opts := result(t, responses[3])["options"].([]interface{}); len(opts) == 0 {
+		t.Error("options search for case found nothing")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	s := newTestServer(t, Options{})
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"parse-keys","params":{"keys":"3dwyy:wq<CR>"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"parse-keys","params":{"keys":"dd\u001bu","raw":true}}`,
+	)
+
+	commands := result(t, responses[0])["commands"].([]interface{})
+	var got []string
+	for _, c := range commands {
+		kc := c.(map[string]interface{})
+		got = append(got, kc["keys"].(string)+"="+kc["catalog_command"].(string))
+	}
+	if strings.Join(got, " ") != "3dw=dw yy=yy :wq<CR>=:wq" {
+		t.Errorf("parse-keys = %v", got)
+	}
+
+	raw := result(t, responses[1])["commands"].([]interface{})
+	if len(raw) != 3 || raw[1].(map[string]interface{})["command"] != "Esc" || raw[2].(map[string]interface{})["command"] != "u" {
+		t.Errorf("raw parse-keys = %v", raw)
+	}
+}
+
+func TestFavorites(t *testing.T) {
+	s := newTestServer(t, Options{})
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"favorites","params":{"action":"add","command":"dd","tags":["edit"]}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"favorites","params":{"action":"add","command":"nope"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"favorites","params":{"tag":"edit"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"favorites","params":{"action":"remove","command":"dd"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"favorites"}`,
+	)
+
+	if added := result(t, responses[0]); added["command"] != "dd" || added["added_at"] == "" {
+		t.Errorf("add = %v", added)
+	}
+	if errorCode(responses[1]) != CodeNotFound {
+		t.Errorf("add nope = %v", responses[1])
+	}
+	if list := result(t, responses[2]); list["count"].(float64) != 1 {
+		t.Errorf("list = %v", list)
+	}
+	if removed := result(t, responses[3]); removed["removed"] != "dd" {
+		t.Errorf("remove = %v", removed)
+	}
+	if list := result(t, responses[4]); list["count"].(float64) != 0 || list["favorites"] == nil {
+		t.Errorf("list after remove = %v", list)
+	}
+
+	readOnly := newTestServer(t, Options{ReadOnly: true})
+	denied := session(t, readOnly, `{"jsonrpc":"2.0","id":1,"method":"favorites","params":{"action":"add","command":"dd"}}`)
+	if errorCode(denied[0]) != CodeInvalidRequest {
+		t.Errorf("read-only add = %v", denied[0])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newTestServer(t, Options{})
+	responses := session(t, s,
+		`not json`,
+		`[{"jsonrpc":"2.0","id":1,"method":"version"}]`,
+		`{"id":2,"method":"version"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"nope"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"explain","params":{"cmd":"dd"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"explain"}`,
+		``,
+		`{"jsonrpc":"2.0","method":"explain","params":{"command":"dd"}}`,
+		`{"jsonrpc":"2.0","method":"nope"}`,
+	)
+
+	want := []int{CodeParseError, CodeInvalidRequest, CodeInvalidRequest, CodeMethodNotFound, CodeInvalidParams, CodeInvalidParams}
+	if len(responses) != len(want) {
+		t.Fatalf("got %d responses, want %d (notifications are not answered)", len(responses), len(want))
+	}
+	for i, code := range want {
+		if got := errorCode(responses[i]); got != code {
+			t.Errorf("response %d: code %d, want %d (%v)", i, got, code, responses[i])
+		}
+		if _, ok := responses[i]["result"]; ok {
+			t.Errorf("response %d has both result and error", i)
+		}
+	}
+	if responses[0]["id"] != nil || responses[3]["id"].(float64) != 3 {
+		t.Errorf("ids = %v, %v", responses[0]["id"], responses[3]["id"])
+	}
+}
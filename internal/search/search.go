@@ -3,54 +3,228 @@
 package search
 
 import (
-	"encoding/json"  // JSON 데이터 처리를 위한 패키지
 	"fmt"            // 표준 출력/입력 포맷팅을 위한 패키지
-	"io/ioutil"      // 파일 읽기/쓰기를 위한 패키지
-	"os"             // 운영체제 인터페이스를 위한 패키지
-	"path/filepath"  // 파일 경로 조작을 위한 패키지
 	"strings"        // 문자열 조작을 위한 패키지
+
+	"vi-assistant/internal/catalog"  // 명령어 카탈로그 로드를 위한 내부 패키지
+	"vi-assistant/internal/hangul"   // 한글 입력 상태로 친 검색어를 키로 되돌리기 위한 내부 패키지
+	"vi-assistant/internal/options"  // :set 옵션 검색을 위한 내부 패키지
+	"vi-assistant/internal/style"    // 출력 강조를 위한 내부 패키지
 )
 
 // Command 구조체는 vi 명령어의 정보를 담는 데이터 구조입니다
-// JSON 파일에서 로드되는 각 명령어의 구조를 정의합니다
-type Command struct {
-	Keyword     string `json:"keyword"`     // 검색 키워드 (복수 가능)
-	Command     string `json:"command"`     // 실제 vi 명령어 (예: :wq, yy)
-	Description string `json:"description"` // 명령어에 대한 설명
-	Example     string `json:"example"`     // 사용 예제
-	Category    string `json:"category"`    // 명령어 카테고리 (file, edit, navigation 등)
-}
+// 카탈로그 패키지의 Command와 같은 타입입니다
+type Command = catalog.Command
 
 // SearchResult 구조체는 검색 결과를 담는 데이터 구조입니다
 // 검색된 명령어 목록과 개수 정보를 포함합니다
 type SearchResult struct {
-	Commands []Command  // 검색된 명령어들의 슬라이스
-	Count    int        // 검색된 명령어의 총 개수
+	Commands []Command `json:"commands"`  // 검색된 명령어들의 슬라이스
+	Count    int       `json:"count"`     // 검색된 명령어의 총 개수
+	Options  []options.Option `json:"options,omitempty"` // 검색어와 일치하는 :set 옵션들
+	IME      *hangul.Guess    `json:"ime,omitempty"`     // 한글 입력 상태로 친 검색어를 키로 되돌려 찾은 경우
+}
+
+// Limit 메서드는 표시할 명령어를 최대 n개로 줄입니다
+// Count는 전체 검색 결과 개수로 유지되며, n이 0 이하이면 아무것도 하지 않습니다
+func (r *SearchResult) Limit(n int) {
+	if n > 0 && len(r.Commands) > n {
+		r.Commands = r.Commands[:n]
+	}
+	if n > 0 && len(r.Options) > n {
+		r.Options = r.Options[:n]
+	}
+}
+
+// Query 구조체는 검색어를 해석한 결과입니다
+// 일반 텍스트와 "필드:값" 형태의 필터로 구성됩니다
+type Query struct {
+	Text     string            `json:"text"`               // 키워드/명령어/설명/카테고리/별칭에서 찾을 텍스트
+	Category string            `json:"category,omitempty"` // category:값 - 카테고리가 정확히 일치
+	Mode     string            `json:"mode,omitempty"`     // mode:값 - 해당 모드에서 쓰는 명령어
+	Source   string            `json:"source,omitempty"`   // source:값 - 출처(built-in 또는 팩 이름)가 일치
+	Editors  map[string]string `json:"editors,omitempty"`  // posix:/vim:/nvim: 값 - 편집기 지원 여부 또는 버전
+}
+
+// 편집기 필터에 쓰는 값
+const (
+	availableYes = "yes" // 지원하는 명령어
+	availableNo  = "no"  // 지원하지 않는 명령어
+)
+
+// FilterKeys 변수는 검색어에서 사용할 수 있는 필터 이름 목록입니다
+var FilterKeys = []string{"category", "mode", "source", "posix", "vim", "nvim"}
+
+// FilterAliases 변수는 필터 이름의 다른 이름과 그 정식 이름입니다
+var FilterAliases = map[string]string{"cat": "category", "vi": "posix", "neovim": "nvim"}
+
+// FilterKey 함수는 필터 이름이나 다른 이름을 정식 이름으로 바꿉니다
+func FilterKey(name string) string {
+	if key, ok := FilterAliases[name]; ok {
+		return key
+	}
+	return name
+}
+
+// ParseQuery 함수는 검색어를 텍스트와 필터로 나눕니다
+// 예: "delete mode:visual vim:8.0" → Text "delete", Mode "visual", Editors {vim: 8.0}
+// 알 수 없는 필드 이름은 일반 텍스트로 취급하므로 ":s/old/new" 같은 명령어도 그대로 검색됩니다
+func ParseQuery(input string) (Query, error) {
+	var query Query
+	var text []string
+
+	for _, token := range strings.Fields(input) {
+		key, value := "", ""
+		if i := strings.Index(token, ":"); i > 0 {
+			key, value = FilterKey(strings.ToLower(token[:i])), token[i+1:]
+		}
+
+		switch key {
+		case "category":
+			query.Category = strings.ToLower(value)
+		case "mode":
+			mode := catalog.NormalizeMode(value)
+			if mode == "" {
+				return query, fmt.Errorf("알 수 없는 모드입니다: %s (사용 가능: %s)", value, strings.Join(catalog.Modes, ", "))
+			}
+			query.Mode = mode
+		case "source":
+			query.Source = strings.ToLower(value)
+		case "posix", "vim", "nvim":
+			editor := map[string]string{"posix": catalog.EditorPOSIX, "vim": catalog.EditorVim, "nvim": catalog.EditorNeovim}[key]
+			version, err := parseAvailability(editor, value)
+			if err != nil {
+				return query, err
+			}
+			if query.Editors == nil {
+				query.Editors = make(map[string]string)
+			}
+			query.Editors[editor] = version
+		default:
+			text = append(text, token)
+		}
+	}
+
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// parseAvailability 함수는 편집기 필터 값을 yes, no 또는 버전 번호로 정리합니다
+func parseAvailability(editor, value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "", "yes", "y", "true":
+		return availableYes, nil
+	case "no", "n", "false":
+		return availableNo, nil
+	}
+	if editor != catalog.EditorPOSIX && value != catalog.AllVersions && catalog.ValidVersion(value) {
+		return value, nil
+	}
+	return "", fmt.Errorf("%s 필터 값이 잘못되었습니다: %s (yes, no 또는 버전 번호)", editor, value)
+}
+
+// Matches 메서드는 명령어가 검색 조건을 모두 만족하는지 확인합니다
+func (q Query) Matches(cmd Command) bool {
+	if q.Category != "" && strings.ToLower(cmd.Category) != q.Category {
+		return false
+	}
+	if q.Mode != "" && !cmd.HasMode(q.Mode) {
+		return false
+	}
+	if q.Source != "" && strings.ToLower(cmd.Source) != q.Source {
+		return false
+	}
+	for editor, version := range q.Editors {
+		switch version {
+		case availableYes:
+			if !cmd.AvailableIn(editor, "") {
+				return false
+			}
+		case availableNo:
+			// 지원 정보가 없는 명령어는 "없음"으로 보지 않습니다
+			if cmd.Availability == nil || cmd.AvailableIn(editor, "") {
+				return false
+			}
+		default:
+			if !cmd.AvailableIn(editor, version) {
+				return false
+			}
+		}
+	}
+
+	if q.Text == "" {
+		return true
+	}
+	keyword := strings.ToLower(q.Text)
+
+	// 다음 항목들에서 키워드 검색:
+	// - 명령어 키워드
+	// - 실제 명령어
+	// - 설명
+	// - 카테고리
+	// - 별칭
+	// - 출처 (팩 이름과 정확히 일치할 때)
+	if strings.Contains(strings.ToLower(cmd.Keyword), keyword) ||
+		strings.Contains(strings.ToLower(cmd.Command), keyword) ||
+		strings.Contains(strings.ToLower(cmd.Description), keyword) ||
+		strings.Contains(strings.ToLower(cmd.Category), keyword) ||
+		strings.ToLower(cmd.Source) == keyword {
+		return true
+	}
+	for _, alias := range cmd.Aliases {
+		if strings.Contains(strings.ToLower(alias), keyword) {
+			return true
+		}
+	}
+	return false
 }
 
 // Search 함수는 키워드를 사용하여 vi 명령어를 검색합니다
-// 키워드와 일치하는 모든 명령어를 찾아서 결과를 반환합니다
+// 키워드에는 ParseQuery가 이해하는 필터를 함께 쓸 수 있습니다
 func Search(keyword string) (*SearchResult, error) {
+	query, err := ParseQuery(keyword)
+	if err != nil {
+		return nil, err
+	}
+	return SearchQuery(query)
+}
+
+// SearchQuery 함수는 해석된 검색 조건과 일치하는 모든 명령어를 찾아서 반환합니다
+func SearchQuery(query Query) (*SearchResult, error) {
 	// JSON 파일에서 모든 명령어 데이터를 로드합니다
 	commands, err := loadCommands()
 	if err != nil {
 		return nil, fmt.Errorf("명령어 데이터를 로드할 수 없습니다: %v", err)
 	}
 
+	result := matchQuery(commands, query)
+
+	// 아무것도 찾지 못했고 검색어에 한글이 있으면 한글 입력 상태에서 친 키로 보고 다시 찾습니다 (ㅇㅇ → dd)
+	if result.Count == 0 && len(result.Options) == 0 {
+		guesses := hangul.Guesses(query.Text)
+		for i := range guesses {
+			retry := query
+			retry.Text = guesses[i].Keys
+			if typed := matchQuery(commands, retry); typed.Count > 0 || len(typed.Options) > 0 {
+				typed.IME = &guesses[i]
+				return typed, nil
+			}
+		}
+		// 낱자모만 있으면 찾은 것이 없어도 한글 입력 상태였다고 알려 줍니다 (ㅗㅓㅏㅣ → hjkl)
+		if len(guesses) > 0 && hangul.OnlyJamo(query.Text) {
+			result.IME = &guesses[0]
+		}
+	}
+	return result, nil
+}
+
+// matchQuery 함수는 검색 조건과 일치하는 명령어와 :set 옵션을 찾습니다
+func matchQuery(commands []Command, query Query) *SearchResult {
 	var results []Command  // 검색 결과를 저장할 슬라이스
-	keyword = strings.ToLower(keyword)  // 키워드를 소문자로 변환하여 대소문자 구분 없이 검색
 
-	// 모든 명령어를 순회하면서 키워드와 일치하는 항목을 찾습니다
+	// 모든 명령어를 순회하면서 조건과 일치하는 항목을 찾습니다
 	for _, cmd := range commands {
-		// 다음 항목들에서 키워드 검색:
-		// - 명령어 키워드
-		// - 실제 명령어
-		// - 설명
-		// - 카테고리
-		if strings.Contains(strings.ToLower(cmd.Keyword), keyword) ||
-			strings.Contains(strings.ToLower(cmd.Command), keyword) ||
-			strings.Contains(strings.ToLower(cmd.Description), keyword) ||
-			strings.Contains(strings.ToLower(cmd.Category), keyword) {
+		if query.Matches(cmd) {
 			results = append(results, cmd)  // 일치하는 명령어를 결과에 추가
 		}
 	}
@@ -59,7 +233,26 @@ func Search(keyword string) (*SearchResult, error) {
 	return &SearchResult{
 		Commands: results,  // 검색된 명령어들
 		Count:    len(results),  // 검색된 명령어의 개수
-	}, nil
+		Options:  searchOptions(query),  // 일치하는 :set 옵션들
+	}
+}
+
+// HasFilters 메서드는 명령어 전용 필터(category, mode, source, 편집기)가 있는지 확인합니다
+func (q Query) HasFilters() bool {
+	return q.Category != "" || q.Mode != "" || q.Source != "" || len(q.Editors) > 0
+}
+
+// searchOptions 함수는 검색어와 일치하는 :set 옵션을 찾습니다
+// 명령어 전용 필터가 있으면 옵션은 찾지 않고, 옵션 파일이 없어도 명령어 검색은 계속합니다
+func searchOptions(query Query) []options.Option {
+	if query.Text == "" || query.HasFilters() {
+		return nil
+	}
+	db, err := options.Load()
+	if err != nil {
+		return nil
+	}
+	return db.Search(query.Text)
 }
 
 // SearchByCategory 함수는 카테고리별로 vi 명령어를 검색합니다
@@ -112,68 +305,92 @@ func GetCategories() ([]string, error) {
 	return categories, nil
 }
 
-// loadCommands 함수는 JSON 파일에서 vi 명령어 데이터를 로드합니다
+// loadCommands 함수는 설정된 카탈로그 파일에서 vi 명령어 데이터를 로드합니다
 // 내부적으로 사용되는 헬퍼 함수로, 모든 검색 함수에서 공통으로 사용됩니다
 func loadCommands() ([]Command, error) {
-	// 현재 작업 디렉토리를 가져옵니다
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	// commands.json 파일의 경로를 구성합니다
-	dataPath := filepath.Join(wd, "data", "commands.json")
-
-	// JSON 파일을 읽어들입니다
-	data, err := ioutil.ReadFile(dataPath)
-	if err != nil {
-		return nil, fmt.Errorf("commands.json 파일을 읽을 수 없습니다: %v", err)
-	}
-
-	// JSON 데이터를 Command 구조체 슬라이스로 파싱합니다
-	var commands []Command
-	if err := json.Unmarshal(data, &commands); err != nil {
-		return nil, fmt.Errorf("JSON 파싱 오류: %v", err)
-	}
-
-	return commands, nil
+	return catalog.Load()
 }
 
 // FormatSearchResults 함수는 검색 결과를 사용자에게 보여주기 위한 형태로 포맷팅합니다
 // 언어 설정에 따라 한국어 또는 영어로 결과를 표시합니다
 func FormatSearchResults(results *SearchResult, lang string) string {
 	// 검색 결과가 없는 경우 처리
-	if results.Count == 0 {
+	if results.Count == 0 && len(results.Options) == 0 {
+		warning := ""
+		if results.IME != nil {
+			warning = results.IME.Warning(lang) + "\n"
+		}
 		if lang == "en" {
-			return "No commands found matching your search criteria."
+			return warning + "No commands found matching your search criteria."
 		}
-		return "검색 조건에 맞는 명령어를 찾을 수 없습니다."
+		return warning + "검색 조건에 맞는 명령어를 찾을 수 없습니다."
 	}
 
 	// 결과를 효율적으로 구성하기 위해 strings.Builder를 사용합니다
 	var output strings.Builder
-	
-	// 검색 결과 개수를 표시합니다
-	if lang == "en" {
-		output.WriteString(fmt.Sprintf("Found %d command(s):\n\n", results.Count))
-	} else {
-		output.WriteString(fmt.Sprintf("%d개의 명령어를 찾았습니다:\n\n", results.Count))
+
+	// 한글 입력 상태로 친 검색어였다면 먼저 경고합니다
+	if results.IME != nil {
+		output.WriteString(results.IME.Warning(lang) + "\n")
+	}
+
+	// 검색 결과 개수를 표시합니다 (옵션만 찾은 경우는 생략)
+	if results.Count > 0 {
+		if lang == "en" {
+			output.WriteString(fmt.Sprintf("Found %d command(s):\n\n", results.Count))
+		} else {
+			output.WriteString(fmt.Sprintf("%d개의 명령어를 찾았습니다:\n\n", results.Count))
+		}
 	}
 
 	// 각 검색 결과를 순회하면서 포맷팅합니다
 	for i, cmd := range results.Commands {
-		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, cmd.Command))  // 명령어 번호와 실제 명령어
+		output.WriteString(fmt.Sprintf("%d. %s\n", i+1, style.Command(cmd.Command)))  // 명령어 번호와 실제 명령어
 		if lang == "en" {  // 영어 버전
 			output.WriteString(fmt.Sprintf("   Category: %s\n", cmd.Category))
+			if len(cmd.Modes) > 0 {
+				output.WriteString(fmt.Sprintf("   Modes: %s\n", strings.Join(cmd.Modes, ", ")))
+			}
 			output.WriteString(fmt.Sprintf("   Description: %s\n", cmd.Description))
 			output.WriteString(fmt.Sprintf("   Example: %s\n", cmd.Example))
+			output.WriteString(fmt.Sprintf("   Source: %s\n", cmd.Source))
 		} else {  // 한국어 버전
 			output.WriteString(fmt.Sprintf("   카테고리: %s\n", cmd.Category))
+			if len(cmd.Modes) > 0 {
+				output.WriteString(fmt.Sprintf("   모드: %s\n", strings.Join(cmd.Modes, ", ")))
+			}
 			output.WriteString(fmt.Sprintf("   설명: %s\n", cmd.Description))
 			output.WriteString(fmt.Sprintf("   예제: %s\n", cmd.Example))
+			output.WriteString(fmt.Sprintf("   출처: %s\n", cmd.Source))
 		}
 		output.WriteString("\n")  // 각 명령어 사이에 빈 줄 추가
 	}
 
+	// 일치하는 :set 옵션을 명령어 뒤에 보여줍니다
+	if len(results.Options) > 0 {
+		if lang == "en" {
+			output.WriteString(fmt.Sprintf("Found %d option(s) (see 'option <name>'):\n", len(results.Options)))
+		} else {
+			output.WriteString(fmt.Sprintf("%d개의 옵션을 찾았습니다 ('option 이름'으로 자세히 보기):\n", len(results.Options)))
+		}
+		for _, o := range results.Options {
+			name := style.Command("'" + o.Name + "'")
+			if o.Abbreviation != "" {
+				name = fmt.Sprintf("%s (%s)", name, o.Abbreviation)
+			}
+			output.WriteString(fmt.Sprintf("   %s - %s\n", name, o.Describe(lang)))
+		}
+		output.WriteString("\n")
+	}
+
+	// 결과 개수 제한으로 일부만 표시한 경우 알려줍니다
+	if hidden := results.Count - len(results.Commands); hidden > 0 {
+		if lang == "en" {
+			output.WriteString(fmt.Sprintf("... and %d more (see the search.limit setting)\n", hidden))
+		} else {
+			output.WriteString(fmt.Sprintf("... 외 %d개 더 있음 (search.limit 설정 참고)\n", hidden))
+		}
+	}
+
 	return output.String()  // 포맷팅된 문자열 반환
 } 
\ No newline at end of file
@@ -0,0 +1,426 @@
+// Package server exposes the catalog, favorites and lessons as a local
+// HTTP/JSON API for editor integrations and documentation portals.
+// It uses the same packages as the CLI, so results match the commands
+// "search", "explain", "fav list" and "learn list".
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"vi-assistant/internal/explain"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/learn"
+	"vi-assistant/internal/search"
+)
+
+// APIVersion is the path prefix of every endpoint
+const APIVersion = "v1"
+
+// ShutdownTimeout bounds how long in-flight requests may take after a shutdown request
+const ShutdownTimeout = 5 * time.Second
+
+// Options configures the server
+type Options struct {
+	Addr         string
+	AllowOrigins []string // CORS origins; "*" allows any origin, empty disables CORS headers
+	Lang         string   // default language, overridden by the "lang" query parameter
+	SearchLimit  int      // default search limit (0 means no limit)
+	ReadOnly     bool     // reject favorites changes
+	Logger       *log.Logger
+
+	// Favorites opens the favorites store; nil disables the favorites endpoints
+	Favorites func() (*favorites.FavoritesManager, error)
+}
+
+// Server serves the JSON API
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New creates a server and registers its endpoints
+func New(opts Options) *Server {
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	prefix := "/api/" + APIVersion
+
+	s.mux.HandleFunc(prefix+"/health", s.handleHealth)
+	s.mux.HandleFunc(prefix+"/search", s.handleSearch)
+	s.mux.HandleFunc(prefix+"/explain", s.handleExplain)
+	s.mux.HandleFunc(prefix+"/categories", s.handleCategories)
+	s.mux.HandleFunc(prefix+"/favorites", s.handleFavorites)
+	s.mux.HandleFunc(prefix+"/lessons", s.handleLessons)
+	return s
+}
+
+// Handler returns the API with host checks, CORS handling and request logging
+func (s *Server) Handler() http.Handler {
+	return s.logRequests(s.checkHost(s.cors(s.mux)))
+}
+
+// ListenAndServe serves until ctx is cancelled, then shuts down gracefully,
+// letting in-flight requests finish within ShutdownTimeout
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.opts.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("서버 종료 오류: %v", err)
+	}
+	return nil
+}
+
+// statusRecorder remembers the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests writes "METHOD /path?query status duration" for every request
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	if s.opts.Logger == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.opts.Logger.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Microsecond))
+	})
+}
+
+// checkHost rejects requests whose Host header names neither the listen
+// address nor a loopback name. A web page that rebinds its own domain to
+// 127.0.0.1 (DNS rebinding) still sends that domain as the Host.
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			writeError(w, http.StatusForbidden, "허용되지 않은 Host입니다: %s", r.Host)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed reports whether host (with an optional port) is an IP address,
+// localhost or the host part of the listen address
+func (s *Server) hostAllowed(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	if host == "" {
+		return false
+	}
+	// An IP address cannot be rebound, so it names this machine directly
+	if net.ParseIP(host) != nil || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	listen, _, err := net.SplitHostPort(s.opts.Addr)
+	return err == nil && strings.EqualFold(listen, host)
+}
+
+// cors adds CORS headers for allowed origins and answers preflight requests
+func (s *Server) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && s.originAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.Header().Set("Access-Control-Max-Age", "600")
+			}
+		}
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) originAllowed(origin string) bool {
+	for _, allowed := range s.opts.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResponse is the body of every error
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, errorResponse{Error: fmt.Sprintf(format, args...)})
+}
+
+// allowMethods rejects requests whose method is not listed
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "지원하지 않는 메서드입니다: %s", r.Method)
+	return false
+}
+
+// lang returns the "lang" query parameter or the server default
+func (s *Server) lang(r *http.Request) string {
+	switch lang := r.URL.Query().Get("lang"); lang {
+	case "ko", "en":
+		return lang
+	}
+	if s.opts.Lang != "" {
+		return s.opts.Lang
+	}
+	return "ko"
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": APIVersion})
+}
+
+// handleSearch serves GET /search?q=...&limit=N; q accepts the filters of the search command
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeError(w, http.StatusBadRequest, "검색어(q)가 필요합니다")
+		return
+	}
+
+	limit := s.opts.SearchLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "limit 값이 잘못되었습니다: %s", raw)
+			return
+		}
+		limit = n
+	}
+
+	results, err := search.Search(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	results.Limit(limit)
+	writeJSON(w, http.StatusOK, results)
+}
+
+// handleExplain serves GET /explain?command=dd; unknown commands are a 404 with suggestions.
+// Keys typed in Hangul input mode that make up several commands (ㅗㅓㅏㅣ) are found as a sequence.
+func (s *Server) handleExplain(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	command := r.URL.Query().Get("command")
+	if command == "" {
+		writeError(w, http.StatusBadRequest, "명령어(command)가 필요합니다")
+		return
+	}
+
+	result, err := explain.Explain(command)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	status := http.StatusOK
+	if !result.Found && len(result.Sequence) == 0 {
+		status = http.StatusNotFound
+	}
+	writeJSON(w, status, result)
+}
+
+// handleCategories serves GET /categories in alphabetical order
+func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	categories, err := search.GetCategories()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	sort.Strings(categories)
+	writeJSON(w, http.StatusOK, map[string][]string{"categories": categories})
+}
+
+// favoriteRequest is the body of POST /favorites
+type favoriteRequest struct {
+	Command string   `json:"command"`
+	Tags    []string `json:"tags,omitempty"`
+	Note    string   `json:"note,omitempty"`
+}
+
+// handleFavorites serves GET (list, ?tag= and ?sort=), POST (add) and DELETE (?command=)
+func (s *Server) handleFavorites(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost, http.MethodDelete) {
+		return
+	}
+	if s.opts.Favorites == nil {
+		writeError(w, http.StatusNotFound, "즐겨찾기를 사용할 수 없습니다")
+		return
+	}
+	if r.Method != http.MethodGet && s.opts.ReadOnly {
+		writeError(w, http.StatusForbidden, "읽기 전용 서버입니다")
+		return
+	}
+
+	fm, err := s.opts.Favorites()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		list, err := fm.List()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		list = favorites.FilterByTag(list, r.URL.Query().Get("tag"))
+		if err := favorites.SortFavorites(list, r.URL.Query().Get("sort")); err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		if list == nil {
+			list = []favorites.Favorite{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"favorites": list, "count": len(list)})
+
+	case http.MethodPost:
+		// A form on another site can POST text/plain without a preflight;
+		// requiring JSON makes the browser ask first, which CORS then refuses
+		if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, "Content-Type은 application/json이어야 합니다")
+			return
+		}
+		var req favoriteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Command == "" {
+			writeError(w, http.StatusBadRequest, "요청 본문은 {\"command\": \"...\"} 형식이어야 합니다")
+			return
+		}
+		result, err := explain.Explain(req.Command)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if !result.Found {
+			writeError(w, http.StatusNotFound, "명령어를 찾을 수 없습니다: %s", req.Command)
+			return
+		}
+		fav := favorites.Favorite{
+			Command:     result.Command.Command,
+			Description: result.Command.Description,
+			Category:    result.Command.Category,
+			Tags:        req.Tags,
+			Note:        req.Note,
+		}
+		if err := fm.Add(fav); err != nil {
+			writeError(w, http.StatusConflict, "%v", err)
+			return
+		}
+		// Answer with the stored entry, which has AddedAt and normalized tags
+		if list, err := fm.List(); err == nil {
+			for _, stored := range list {
+				if stored.Command == fav.Command {
+					fav = stored
+				}
+			}
+		}
+		writeJSON(w, http.StatusCreated, fav)
+
+	case http.MethodDelete:
+		command := r.URL.Query().Get("command")
+		if command == "" {
+			writeError(w, http.StatusBadRequest, "명령어(command)가 필요합니다")
+			return
+		}
+		if err := fm.Remove(command); err != nil {
+			writeError(w, http.StatusNotFound, "%v", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// lessonLevel is one level in the GET /lessons response
+type lessonLevel struct {
+	Level   string         `json:"level"`
+	Lessons []learn.Lesson `json:"lessons"`
+}
+
+// handleLessons serves GET /lessons, optionally for one ?level=
+func (s *Server) handleLessons(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	lang := s.lang(r)
+
+	levels := learn.Levels
+	if level := r.URL.Query().Get("level"); level != "" {
+		if learn.GetLessons(level, lang) == nil {
+			writeError(w, http.StatusNotFound, "알 수 없는 레벨입니다: %s (beginner, intermediate)", level)
+			return
+		}
+		levels = []string{level}
+	}
+
+	var result []lessonLevel
+	for _, level := range levels {
+		result = append(result, lessonLevel{Level: level, Lessons: learn.GetLessons(level, lang)})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"levels": result})
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/testenv"
+)
+
+// newTestServer serves the bundled catalog with favorites in a temporary directory
+func newTestServer(t *testing.T, opts Options) (*httptest.Server, *bytes.Buffer) {
+	t.Helper()
+	testenv.UseBundledData(t)
+	opts.Favorites = testenv.Favorites(t)
+
+	logs := &bytes.Buffer{}
+	opts.Logger = log.New(logs, "", 0)
+
+	ts := httptest.NewServer(New(opts).Handler())
+	t.Cleanup(ts.Close)
+	return ts, logs
+}
+
+func do(t *testing.T, method, url, body string, header map[string]string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		if k == "Host" {
+			req.Host = v // the client ignores a Host header
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, data
+}
+
+func TestSearchAndExplain(t *testing.T) {
+	ts, logs := newTestServer(t, Options{})
+	api := ts.URL + "/api/v1"
+
+	resp, body := do(t, "GET", api+"/search?q=delete+mode:normal&limit=2", "", nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatalf("search: %d %s", resp.StatusCode, body)
+	}
+	var results struct {
+		Commands []catalog.Command `json:"commands"`
+		Count    int               `json:"count"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Commands) != 2 || results.Count < 2 {
+		t.Errorf("search returned %d of %d", len(results.Commands), results.Count)
+	}
+
+	if resp, body = do(t, "GET", api+"/search", "", nil); resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), "error") {
+		t.Errorf("search without q: %d %s", resp.StatusCode, body)
+	}
+	if resp, _ = do(t, "GET", api+"/search?q=x+mode:bogus", "", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad filter: %d", resp.StatusCode)
+	}
+
+	resp, body = do(t, "GET", api+"/explain?command=dd", "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"found": true`) {
+		t.Errorf("explain dd: %d %s", resp.StatusCode, body)
+	}
+	if resp, _ = do(t, "GET", api+"/explain?command=nosuchcommand", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("explain unknown: %d", resp.StatusCode)
+	}
+	resp, body = do(t, "GET", api+"/explain?command="+url.QueryEscape("ㅇㅇ"), "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"keys": "dd"`) {
+		t.Errorf("explain in Hangul mode: %d %s", resp.StatusCode, body)
+	}
+	resp, body = do(t, "GET", api+"/explain?command="+url.QueryEscape("ㅗㅓㅏㅣ"), "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"sequence"`) {
+		t.Errorf("explain a sequence in Hangul mode: %d %s", resp.StatusCode, body)
+	}
+	if resp, _ = do(t, "POST", api+"/explain?command=dd", "", nil); resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != "GET" {
+		t.Errorf("POST explain: %d", resp.StatusCode)
+	}
+
+	if !strings.Contains(logs.String(), "GET /api/v1/explain?command=dd 200") {
+		t.Errorf("request log = %q", logs.String())
+	}
+}
+
+func TestCategoriesAndLessons(t *testing.T) {
+	ts, _ := newTestServer(t, Options{})
+	api := ts.URL + "/api/v1"
+
+	resp, body := do(t, "GET", api+"/categories", "", nil)
+	var cats struct {
+		Categories []string `json:"categories"`
+	}
+	if err := json.Unmarshal(body, &cats); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("categories: %d %s", resp.StatusCode, body)
+	}
+	if len(cats.Categories) == 0 || cats.Categories[0] != "copy" {
+		t.Errorf("categories = %v", cats.Categories)
+	}
+
+	resp, body = do(t, "GET", api+"/lessons?level=beginner&lang=en", "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "Getting Started") {
+		t.Errorf("lessons: %d %s", resp.StatusCode, body)
+	}
+	if resp, _ = do(t, "GET", api+"/lessons?level=expert", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown level: %d", resp.StatusCode)
+	}
+}
+
+var jsonHeader = map[string]string{"Content-Type": "application/json"}
+
+func TestFavorites(t *testing.T) {
+	ts, _ := newTestServer(t, Options{})
+	api := ts.URL + "/api/v1/favorites"
+
+	resp, body := do(t, "POST", api, `{"command":"dd","tags":["Edit"]}`, jsonHeader)
+	if resp.StatusCode != http.StatusCreated || !strings.Contains(string(body), `"edit"`) || strings.Contains(string(body), `"added_at": ""`) {
+		t.Fatalf("add: %d %s", resp.StatusCode, body)
+	}
+	if resp, _ = do(t, "POST", api, `{"command":"dd"}`, jsonHeader); resp.StatusCode != http.StatusConflict {
+		t.Errorf("duplicate add: %d", resp.StatusCode)
+	}
+	if resp, _ = do(t, "POST", api, `{"command":"nosuch"}`, jsonHeader); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown add: %d", resp.StatusCode)
+	}
+	if resp, _ = do(t, "POST", api, `not json`, jsonHeader); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad body: %d", resp.StatusCode)
+	}
+
+	resp, body = do(t, "GET", api+"?tag=edit", "", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"count": 1`) {
+		t.Errorf("list: %d %s", resp.StatusCode, body)
+	}
+
+	if resp, _ = do(t, "DELETE", api+"?command=dd", "", nil); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("delete: %d", resp.StatusCode)
+	}
+	if resp, _ = do(t, "DELETE", api+"?command=dd", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("delete again: %d", resp.StatusCode)
+	}
+	if _, body = do(t, "GET", api, "", nil); !strings.Contains(string(body), `"favorites": []`) {
+		t.Errorf("empty list = %s", body)
+	}
+}
+
+// A cross-site form can only send text/plain or form bodies
+func TestFavoritesRequireJSON(t *testing.T) {
+	ts, _ := newTestServer(t, Options{})
+	api := ts.URL + "/api/v1/favorites"
+
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded", "multipart/form-data; boundary=x"} {
+		resp, _ := do(t, "POST", api, `{"command":"dd"}`, map[string]string{"Content-Type": contentType})
+		if resp.StatusCode != http.StatusUnsupportedMediaType {
+			t.Errorf("POST with Content-Type %q: %d", contentType, resp.StatusCode)
+		}
+	}
+	if _, body := do(t, "GET", api, "", nil); !strings.Contains(string(body), `"count": 0`) {
+		t.Errorf("rejected requests added favorites: %s", body)
+	}
+
+	resp, _ := do(t, "POST", api, `{"command":"dd"}`, map[string]string{"Content-Type": "application/json; charset=utf-8"})
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("POST with a charset: %d", resp.StatusCode)
+	}
+}
+
+// A DNS rebinding page reaches 127.0.0.1 but sends its own domain as the Host
+func TestHostCheck(t *testing.T) {
+	ts, _ := newTestServer(t, Options{Addr: "devbox.internal:8420"})
+	api := ts.URL + "/api/v1/favorites"
+
+	for _, host := range []string{"evil.example", "evil.example:8420", "localhost.evil.example"} {
+		resp, _ := do(t, "POST", api, `{"command":"dd"}`, map[string]string{"Host": host, "Content-Type": "application/json"})
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("POST with Host %s: %d", host, resp.StatusCode)
+		}
+		if resp, _ = do(t, "GET", api, "", map[string]string{"Host": host}); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("GET with Host %s: %d", host, resp.StatusCode)
+		}
+	}
+	for _, host := range []string{"localhost:8420", "127.0.0.1", "[::1]:8420", "app.localhost", "DEVBOX.internal:8420", "192.168.0.10:8420"} {
+		if resp, _ := do(t, "GET", api, "", map[string]string{"Host": host}); resp.StatusCode != http.StatusOK {
+			t.Errorf("GET with Host %s: %d", host, resp.StatusCode)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	ts, _ := newTestServer(t, Options{ReadOnly: true})
+	if resp, _ := do(t, "POST", ts.URL+"/api/v1/favorites", `{"command":"dd"}`, jsonHeader); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("read-only add: %d", resp.StatusCode)
+	}
+	if resp, _ := do(t, "GET", ts.URL+"/api/v1/favorites", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("read-only list: %d", resp.StatusCode)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	ts, _ := newTestServer(t, Options{AllowOrigins: []string{"http://docs.example"}})
+	api := ts.URL + "/api/v1/categories"
+
+	resp, _ := do(t, "OPTIONS", api, "", map[string]string{"Origin": "http://docs.example", "Access-Control-Request-Method": "GET"})
+	if resp.StatusCode != http.StatusNoContent || resp.Header.Get("Access-Control-Allow-Origin") != "http://docs.example" ||
+		!strings.Contains(resp.Header.Get("Access-Control-Allow-Methods"), "DELETE") {
+		t.Errorf("preflight: %d %v", resp.StatusCode, resp.Header)
+	}
+
+	resp, _ = do(t, "GET", api, "", map[string]string{"Origin": "http://docs.example"})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "http://docs.example" {
+		t.Errorf("allowed origin headers: %v", resp.Header)
+	}
+	resp, _ = do(t, "GET", api, "", map[string]string{"Origin": "http://evil.example"})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("other origin got CORS headers: %v", resp.Header)
+	}
+
+	// Without configured origins no CORS headers are sent
+	plain, _ := newTestServer(t, Options{})
+	resp, _ = do(t, "GET", plain.URL+"/api/v1/categories", "", map[string]string{"Origin": "http://docs.example"})
+	if resp.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("CORS headers without origins: %v", resp.Header)
+	}
+}
@@ -0,0 +1,86 @@
+package shell
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Node describes what may follow a word on a command line.
+// Words are fixed words such as subcommands; Values supplies the other
+// candidates, such as catalog commands. Args limits the number of value
+// arguments (0 means any number); fixed words are only offered before the first one.
+type Node struct {
+	Words  map[string]*Node
+	Values func() []string
+	Args   int
+}
+
+// Complete returns the sorted candidates starting with word that may follow args
+func (n *Node) Complete(args []string, word string) []string {
+	node, values := n, 0
+	for _, arg := range args {
+		if next, ok := node.Words[arg]; ok && values == 0 {
+			node = next
+			continue
+		}
+		if node.Values == nil {
+			return nil
+		}
+		values++
+	}
+	if node.Args > 0 && values >= node.Args {
+		return nil
+	}
+
+	var candidates []string
+	if values == 0 {
+		for w := range node.Words {
+			candidates = append(candidates, w)
+		}
+	}
+	if node.Values != nil {
+		candidates = append(candidates, node.Values()...)
+	}
+
+	seen := make(map[string]bool)
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) && !seen[c] {
+			seen[c] = true
+			matches = append(matches, c)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// FilterValues returns the search filter candidates: "key:" for every filter
+// and "key:value" for the known values of a filter, e.g. category:copy
+func FilterValues(filters map[string][]string) func() []string {
+	return func() []string {
+		var values []string
+		for key, known := range filters {
+			values = append(values, key+":")
+			for _, v := range known {
+				values = append(values, key+":"+v)
+			}
+		}
+		return values
+	}
+}
+
+// commonPrefix returns the longest prefix shared by all words
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
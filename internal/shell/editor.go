@@ -0,0 +1,257 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"vi-assistant/internal/ui"
+)
+
+// ErrInterrupt is returned by ReadLine when the line is cancelled with Ctrl+C
+var ErrInterrupt = errors.New("interrupted")
+
+// Editor reads lines with editing, history and tab completion.
+// The terminal must be in raw mode while ReadLine runs (see ui.OpenTTY);
+// with Plain set, lines are read as they are, for input from a pipe or file.
+type Editor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history *History
+
+	Complete *Node // completion tree for the line being read
+	Plain    bool
+	Width    int // terminal width used to lay out completion lists
+
+	// state of the line being edited
+	buf     []rune
+	pos     int
+	prompt  string
+	histPos int    // index into history entries; len(entries) is the line being typed
+	draft   string // the line being typed while browsing history
+	lastTab bool
+}
+
+// NewEditor creates an editor; history and complete may be nil
+func NewEditor(in io.Reader, out io.Writer, history *History, complete *Node) *Editor {
+	if history == nil {
+		history, _ = LoadHistory("", DefaultHistorySize)
+	}
+	if complete == nil {
+		complete = &Node{}
+	}
+	return &Editor{in: bufio.NewReader(in), out: out, history: history, Complete: complete, Width: 80}
+}
+
+// History returns the editor's history
+func (e *Editor) History() *History {
+	return e.history
+}
+
+// ReadLine shows prompt and returns the entered line without the newline.
+// It returns io.EOF at the end of input or on Ctrl+D in an empty line,
+// and ErrInterrupt when the line is cancelled with Ctrl+C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	if e.Plain {
+		return e.readPlain()
+	}
+
+	e.buf, e.pos, e.prompt = nil, 0, prompt
+	e.histPos, e.draft, e.lastTab = len(e.history.Entries()), "", false
+	e.redraw()
+
+	for {
+		key, err := ui.ReadKey(e.in)
+		if err != nil {
+			if err == io.EOF && len(e.buf) > 0 {
+				e.write("\r\n")
+				return string(e.buf), nil
+			}
+			return "", err
+		}
+
+		tab := key.Code == ui.KeyTab
+		switch {
+		case key.Code == ui.KeyEnter:
+			e.write("\r\n")
+			return string(e.buf), nil
+		case key.IsCtrl('c'):
+			e.write("^C\r\n")
+			return "", ErrInterrupt
+		case key.IsCtrl('d'):
+			if len(e.buf) == 0 {
+				e.write("\r\n")
+				return "", io.EOF
+			}
+			e.deleteRange(e.pos, e.pos+1)
+		case tab:
+			e.completeWord()
+		case key.Code == ui.KeyRune:
+			e.insert(key.Rune)
+		case key.Code == ui.KeyBackspace, key.IsCtrl('h'):
+			e.deleteRange(e.pos-1, e.pos)
+		case key.Code == ui.KeyDelete:
+			e.deleteRange(e.pos, e.pos+1)
+		case key.Code == ui.KeyLeft, key.IsCtrl('b'):
+			e.pos = max(e.pos-1, 0)
+		case key.Code == ui.KeyRight, key.IsCtrl('f'):
+			e.pos = min(e.pos+1, len(e.buf))
+		case key.Code == ui.KeyHome, key.IsCtrl('a'):
+			e.pos = 0
+		case key.Code == ui.KeyEnd, key.IsCtrl('e'):
+			e.pos = len(e.buf)
+		case key.IsCtrl('u'):
+			e.deleteRange(0, e.pos)
+		case key.IsCtrl('k'):
+			e.deleteRange(e.pos, len(e.buf))
+		case key.IsCtrl('w'):
+			start := e.pos
+			for start > 0 && e.buf[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && e.buf[start-1] != ' ' {
+				start--
+			}
+			e.deleteRange(start, e.pos)
+		case key.Code == ui.KeyUp, key.IsCtrl('p'):
+			e.browseHistory(-1)
+		case key.Code == ui.KeyDown, key.IsCtrl('n'):
+			e.browseHistory(1)
+		case key.IsCtrl('l'):
+			e.write("\033[H\033[2J")
+		}
+		e.lastTab = tab
+		e.redraw()
+	}
+}
+
+// readPlain reads one line without echo or editing
+func (e *Editor) readPlain() (string, error) {
+	line, err := e.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func (e *Editor) write(s string) {
+	io.WriteString(e.out, s)
+}
+
+// redraw rewrites the prompt and line and puts the cursor at pos
+func (e *Editor) redraw() {
+	var b strings.Builder
+	b.WriteString("\r" + e.prompt + string(e.buf) + "\033[K")
+	if tail := ui.TextWidth(string(e.buf[e.pos:])); tail > 0 {
+		fmt.Fprintf(&b, "\033[%dD", tail)
+	}
+	e.write(b.String())
+}
+
+func (e *Editor) insert(r rune) {
+	e.buf = append(e.buf[:e.pos], append([]rune{r}, e.buf[e.pos:]...)...)
+	e.pos++
+}
+
+// deleteRange removes buf[from:to], clamped to the line
+func (e *Editor) deleteRange(from, to int) {
+	from, to = max(from, 0), min(to, len(e.buf))
+	if from >= to {
+		return
+	}
+	e.buf = append(e.buf[:from], e.buf[to:]...)
+	if e.pos > to {
+		e.pos -= to - from
+	} else if e.pos > from {
+		e.pos = from
+	}
+}
+
+// browseHistory moves through history; the typed line comes back after the newest entry
+func (e *Editor) browseHistory(step int) {
+	entries := e.history.Entries()
+	next := e.histPos + step
+	if next < 0 || next > len(entries) {
+		return
+	}
+	if e.histPos == len(entries) {
+		e.draft = string(e.buf)
+	}
+	e.histPos = next
+	if next == len(entries) {
+		e.buf = []rune(e.draft)
+	} else {
+		e.buf = []rune(entries[next])
+	}
+	e.pos = len(e.buf)
+}
+
+// completeWord completes the word before the cursor. A single candidate is
+// inserted with a following space; several candidates are completed to their
+// common prefix, and a second Tab lists them.
+func (e *Editor) completeWord() {
+	before := string(e.buf[:e.pos])
+	tokens, _, trailingSpace := tokenize(before)
+
+	word, start := "", len(before)
+	if !trailingSpace && len(tokens) > 0 {
+		last := tokens[len(tokens)-1]
+		word, start = last.text, last.start
+		tokens = tokens[:len(tokens)-1]
+	}
+	args := make([]string, len(tokens))
+	for i, t := range tokens {
+		args[i] = t.text
+	}
+
+	candidates := e.Complete.Complete(args, word)
+	var replacement string
+	switch {
+	case len(candidates) == 0:
+		e.write("\a")
+		return
+	case len(candidates) == 1:
+		replacement = Quote(candidates[0])
+		if !strings.HasSuffix(candidates[0], ":") {
+			replacement += " "
+		}
+	default:
+		prefix := commonPrefix(candidates)
+		if prefix == word {
+			if e.lastTab {
+				e.list(candidates)
+			} else {
+				e.write("\a")
+			}
+			return
+		}
+		replacement = quotePrefix(prefix)
+	}
+
+	rest := e.buf[e.pos:]
+	e.buf = append([]rune(before[:start]+replacement), rest...)
+	e.pos = len([]rune(before[:start] + replacement))
+}
+
+// list prints candidates in columns below the line
+func (e *Editor) list(candidates []string) {
+	width := 0
+	for _, c := range candidates {
+		width = max(width, ui.TextWidth(c)+2)
+	}
+	columns := max(e.Width/width, 1)
+
+	var b strings.Builder
+	b.WriteString("\r\n")
+	for i, c := range candidates {
+		b.WriteString(c)
+		if (i+1)%columns == 0 || i == len(candidates)-1 {
+			b.WriteString("\r\n")
+		} else {
+			b.WriteString(strings.Repeat(" ", width-ui.TextWidth(c)))
+		}
+	}
+	e.write(b.String())
+}
@@ -0,0 +1,90 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultHistorySize is the number of lines kept in the history file
+const DefaultHistorySize = 500
+
+// History is the list of entered lines, oldest first, saved to a file
+// so it survives between sessions
+type History struct {
+	path    string
+	max     int
+	entries []string
+}
+
+// LoadHistory reads the history file at path, keeping the last max lines.
+// A missing file gives an empty history; an empty path keeps history in memory only.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{path: path, max: max}
+	if path == "" {
+		return h, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("히스토리 파일을 읽을 수 없습니다: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("히스토리 파일을 읽을 수 없습니다: %v", err)
+	}
+
+	// Rewrite an overgrown file so it does not grow without bound
+	if len(h.entries) > max {
+		h.entries = h.entries[len(h.entries)-max:]
+		if err := ioutil.WriteFile(path, []byte(strings.Join(h.entries, "\n")+"\n"), 0600); err != nil {
+			return nil, fmt.Errorf("히스토리 파일을 저장할 수 없습니다: %v", err)
+		}
+	}
+	return h, nil
+}
+
+// Entries returns the lines, oldest first
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Add records a line unless it is blank or repeats the previous line,
+// and appends it to the history file
+func (h *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+	h.entries = append(h.entries, line)
+	if h.max > 0 && len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+	if h.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("히스토리 파일을 저장할 수 없습니다: %v", err)
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("히스토리 파일을 저장할 수 없습니다: %v", err)
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, line)
+	return err
+}
@@ -0,0 +1,185 @@
+package shell
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"search  copy mode:visual", []string{"search", "copy", "mode:visual"}},
+		{`fav note dd "my note"`, []string{"fav", "note", "dd", "my note"}},
+		{`explain ':help {subject}'`, []string{"explain", ":help {subject}"}},
+		{`search a\ b "say \"hi\""`, []string{"search", "a b", `say "hi"`}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := Split(tt.line)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%q) = %q, %v; want %q", tt.line, got, err, tt.want)
+		}
+	}
+	if _, err := Split(`fav note dd "open`); err == nil {
+		t.Error("unterminated quote accepted")
+	}
+
+	for _, word := range []string{"dd", ":help {subject}", `a"b`, ""} {
+		if got, _ := Split(Quote(word)); len(got) != 1 || got[0] != word {
+			t.Errorf("Split(Quote(%q)) = %q", word, got)
+		}
+	}
+}
+
+func testTree() *Node {
+	commands := func() []string { return []string{"dd", "d{motion}", "yy", ":w", ":wq", ":help {subject}"} }
+	return &Node{Words: map[string]*Node{
+		"explain": {Values: commands, Args: 1},
+		"search":  {Values: FilterValues(map[string][]string{"category": {"copy", "delete"}, "mode": nil})},
+		"fav": {Words: map[string]*Node{
+			"add":  {Values: commands, Args: 1},
+			"list": {},
+		}},
+		"exit": {Args: 1},
+	}}
+}
+
+func TestComplete(t *testing.T) {
+	root := testTree()
+	tests := []struct {
+		args []string
+		word string
+		want []string
+	}{
+		{nil, "e", []string{"exit", "explain"}},
+		{[]string{"explain"}, ":w", []string{":w", ":wq"}},
+		{[]string{"explain", "dd"}, "", nil},
+		{[]string{"search"}, "category:", []string{"category:", "category:copy", "category:delete"}},
+		{[]string{"search", "yank"}, "m", []string{"mode:"}},
+		{[]string{"fav"}, "", []string{"add", "list"}},
+		{[]string{"fav", "add"}, "y", []string{"yy"}},
+		{[]string{"fav", "list"}, "", nil},
+		{[]string{"unknown"}, "", nil},
+	}
+	for _, tt := range tests {
+		if got := root.Complete(tt.args, tt.word); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q, %q) = %q, want %q", tt.args, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "shell_history")
+	h, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"search copy", "search copy", "  ", "explain dd", "fav list", "learn list"} {
+		if err := h.Add(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"explain dd", "fav list", "learn list"}
+	if !reflect.DeepEqual(h.Entries(), want) {
+		t.Errorf("entries = %q", h.Entries())
+	}
+
+	// The file keeps every line until the next load trims it
+	reloaded, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reloaded.Entries(), want) {
+		t.Errorf("reloaded entries = %q", reloaded.Entries())
+	}
+	data, _ := ioutil.ReadFile(path)
+	if strings.Count(string(data), "\n") != 3 {
+		t.Errorf("history file not trimmed:\n%s", data)
+	}
+}
+
+// readLines types input into an editor and returns the lines read until EOF
+func readLines(t *testing.T, e *Editor) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := e.ReadLine("> ")
+		if err == io.EOF {
+			return lines
+		}
+		if err == ErrInterrupt {
+			lines = append(lines, "^C")
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestEditorEditing(t *testing.T) {
+	input := strings.Join([]string{
+		"explain dd\r",
+		"serch\x1b[D\x1b[D\x1b[Da\x05 yy\r", // insert in the middle, Ctrl+e to the end
+		"fav add dd\x17yy\r",                // Ctrl+w deletes the previous word
+		"junk\x15learn list\r",              // Ctrl+u deletes the line
+		"abc\x02\x02\x04\r",                 // Ctrl+b twice, Ctrl+d deletes under the cursor
+		"typo\x03",                          // Ctrl+c cancels
+		"한글\x7f\r",
+		"\x04",
+	}, "")
+	e := NewEditor(strings.NewReader(input), &bytes.Buffer{}, nil, nil)
+	want := []string{"explain dd", "search yy", "fav add yy", "learn list", "ac", "^C", "한"}
+	if got := readLines(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestEditorHistory(t *testing.T) {
+	h, _ := LoadHistory("", 10)
+	h.Add("search copy")
+	h.Add("explain dd")
+
+	// Up twice recalls the older line; Down past the newest restores the typed text
+	input := "\x1b[A\x1b[A\r" + "draft\x1b[A\x1b[B\r"
+	e := NewEditor(strings.NewReader(input), &bytes.Buffer{}, h, nil)
+	want := []string{"search copy", "draft"}
+	if got := readLines(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestEditorCompletion(t *testing.T) {
+	out := &bytes.Buffer{}
+	input := "exp\t:w\t\r" + "explain :w\t\t\r" + "explain :he\t\r" + "search cat\tco\t\r" + "fav add zz\t\r"
+	e := NewEditor(strings.NewReader(input), out, nil, testTree())
+	want := []string{"explain :w", "explain :w", `explain ":help {subject}" `, "search category:copy ", "fav add zz"}
+	if got := readLines(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+
+	// The second Tab lists the candidates
+	if !strings.Contains(out.String(), ":w   :wq") {
+		t.Errorf("candidates not listed:\n%q", out.String())
+	}
+	if !strings.Contains(out.String(), "\a") {
+		t.Error("no bell for a word without candidates")
+	}
+}
+
+func TestEditorPlain(t *testing.T) {
+	e := NewEditor(strings.NewReader("search copy\r\nexplain dd"), &bytes.Buffer{}, nil, nil)
+	e.Plain = true
+	want := []string{"search copy", "explain dd"}
+	if got := readLines(t, e); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+// Package shell provides the line editing behind the "shell" command:
+// word splitting with quotes, history kept across sessions, and tab
+// completion driven by a tree of commands and their arguments.
+package shell
+
+import (
+	"errors"
+	"strings"
+)
+
+// errUnterminated is returned by Split for a line with an open quote
+var errUnterminated = errors.New("따옴표가 닫히지 않았습니다")
+
+// token is one word of a line and where it starts
+type token struct {
+	text  string
+	start int
+}
+
+// tokenize splits line into words. Double and single quotes group words and
+// a backslash escapes the next character outside single quotes. quote is the
+// open quote character when the line ends inside a quoted word.
+func tokenize(line string) (tokens []token, quote byte, trailingSpace bool) {
+	var current strings.Builder
+	inWord, start := false, 0
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			switch {
+			case c == quote:
+				quote = 0
+			case c == '\\' && quote == '"' && i+1 < len(line) && (line[i+1] == '"' || line[i+1] == '\\'):
+				i++
+				current.WriteByte(line[i])
+			default:
+				current.WriteByte(c)
+			}
+		case c == ' ' || c == '\t':
+			if inWord {
+				tokens = append(tokens, token{text: current.String(), start: start})
+				current.Reset()
+				inWord = false
+			}
+		default:
+			if !inWord {
+				inWord, start = true, i
+			}
+			switch {
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '\\' && i+1 < len(line):
+				i++
+				current.WriteByte(line[i])
+			default:
+				current.WriteByte(c)
+			}
+		}
+	}
+	if inWord {
+		tokens = append(tokens, token{text: current.String(), start: start})
+	}
+	trailingSpace = !inWord && len(line) > 0
+	return tokens, quote, trailingSpace
+}
+
+// Split breaks a shell line into words, e.g. `fav note dd "my note"` gives
+// fav, note, dd and "my note"
+func Split(line string) ([]string, error) {
+	tokens, quote, _ := tokenize(line)
+	if quote != 0 {
+		return nil, errUnterminated
+	}
+	var words []string
+	for _, t := range tokens {
+		words = append(words, t.text)
+	}
+	return words, nil
+}
+
+// Quote returns s as a single word for Split, adding quotes only when needed
+func Quote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// quotePrefix is like Quote but leaves the quote open, for completing part of a word
+func quotePrefix(s string) string {
+	q := Quote(s)
+	if strings.HasPrefix(q, `"`) {
+		return q[:len(q)-1]
+	}
+	return q
+}
@@ -0,0 +1,284 @@
+// Package site renders the catalog as a static HTML reference: one
+// self-contained page per language, grouped by category, with a search box
+// that works offline. The pages need no server and no network access.
+package site
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/cheatsheet"
+)
+
+// Langs are the languages a site is written in, each to its own directory
+var Langs = []string{"ko", "en"}
+
+// siteLabels holds the localized words of the pages
+var siteLabels = map[string][2]string{
+	"title":     {"vi 명령어 레퍼런스", "vi command reference"},
+	"search":    {"명령어, 설명, 키워드 검색", "Search commands, descriptions, keywords"},
+	"contents":  {"카테고리", "Categories"},
+	"count":     {"명령어 %d개", "%d commands"},
+	"noresults": {"일치하는 명령어가 없습니다", "No matching commands"},
+	"example":   {"예제", "Example"},
+	"modes":     {"모드", "Modes"},
+	"available": {"지원", "Available"},
+	"aliases":   {"별칭", "Aliases"},
+	"source":    {"출처", "Source"},
+	"vimhelp":   {"Vim 도움말", "Vim help"},
+	"generated": {"vi-assistant로 생성", "Generated by vi-assistant"},
+	"langname":  {"한국어", "English"},
+}
+
+func label(key, lang string) string {
+	if lang == "en" {
+		return siteLabels[key][1]
+	}
+	return siteLabels[key][0]
+}
+
+// Page is the data of one language's page
+type Page struct {
+	Lang     string
+	Title    string
+	Count    int
+	Sections []Section
+	Langs    []string
+}
+
+// Section is a category of the page
+type Section struct {
+	ID      string
+	Title   string
+	Entries []Entry
+}
+
+// Entry is a command with its relations resolved to anchors on the page
+type Entry struct {
+	catalog.Command
+	ID        string
+	Available string
+	Related   []Link
+}
+
+// Link points at another command on the page
+type Link struct {
+	Kind    string
+	Command string
+	ID      string
+}
+
+// anchor returns the element id of a command. Characters other than letters,
+// digits, _ and - are written as .xx hex bytes so that the id and the
+// fragment linking to it stay the same after URL escaping.
+func anchor(command string) string {
+	var b strings.Builder
+	b.WriteString("cmd-")
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, ".%02x", c)
+		}
+	}
+	return b.String()
+}
+
+// NewPage groups the catalog by category in the cheat sheet order and
+// resolves relations to links within the page
+func NewPage(commands []catalog.Command, lang string) Page {
+	byCommand := make(map[string]catalog.Command)
+	var entries []cheatsheet.Entry
+	for _, cmd := range commands {
+		if _, exists := byCommand[cmd.Command]; exists {
+			continue
+		}
+		byCommand[cmd.Command] = cmd
+		entries = append(entries, cheatsheet.Entry{Command: cmd.Command, Category: cmd.Category})
+	}
+	graph := catalog.NewGraph(commands)
+
+	page := Page{Lang: lang, Title: label("title", lang), Count: len(byCommand), Langs: Langs}
+	for _, group := range cheatsheet.Group("", entries, lang).Sections {
+		section := Section{ID: "category-" + group.Entries[0].Category, Title: group.Title}
+		for _, e := range group.Entries {
+			cmd := byCommand[e.Command]
+			entry := Entry{Command: cmd, ID: anchor(cmd.Command)}
+			if cmd.Availability != nil {
+				entry.Available = catalog.FormatAvailability(cmd.Availability, lang)
+			}
+			for _, n := range graph.Walk(cmd.Command, 1) {
+				if n.Kind == catalog.RelationCategory {
+					continue
+				}
+				entry.Related = append(entry.Related, Link{
+					Kind:    catalog.RelationTitle(n.Kind, lang),
+					Command: n.Command.Command,
+					ID:      anchor(n.Command.Command),
+				})
+			}
+			section.Entries = append(section.Entries, entry)
+		}
+		page.Sections = append(page.Sections, section)
+	}
+	return page
+}
+
+// WritePage renders one language's page
+func WritePage(w io.Writer, page Page) error {
+	return pageTemplate.Execute(w, page)
+}
+
+// Write writes the whole site to dir: dir/<lang>/index.html for every
+// language in Langs, and dir/index.html linking to them
+func Write(dir string, commands []catalog.Command) error {
+	for _, lang := range Langs {
+		langDir := filepath.Join(dir, lang)
+		if err := os.MkdirAll(langDir, 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(filepath.Join(langDir, "index.html"))
+		if err != nil {
+			return err
+		}
+		err = WritePage(f, NewPage(commands, lang))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("%s 페이지를 쓸 수 없습니다: %v", lang, err)
+		}
+	}
+
+	var index strings.Builder
+	if err := indexTemplate.Execute(&index, Langs); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte(index.String()), 0644)
+}
+
+var funcs = template.FuncMap{
+	"label": label,
+	"count": func(n int, lang string) string { return fmt.Sprintf(label("count", lang), n) },
+	"join":  strings.Join,
+}
+
+var pageTemplate = template.Must(template.New("page").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 0; color: #222; }
+header { position: sticky; top: 0; background: #fff; border-bottom: 1px solid #ccc; padding: 0.6em 1.2em; }
+header h1 { display: inline; font-size: 1.2em; margin-right: 1em; }
+header input { font-size: 1em; padding: 0.3em 0.5em; width: min(28em, 60vw); }
+header .langs { float: right; }
+header .langs a { margin-left: 0.6em; }
+.layout { display: flex; }
+nav { flex: 0 0 14em; padding: 1em 1.2em; position: sticky; top: 3.5em; align-self: flex-start; }
+nav ul { list-style: none; padding: 0; margin: 0.4em 0 0; }
+nav li { margin: 0.2em 0; }
+nav .n { color: #888; font-size: 0.85em; }
+main { flex: 1; padding: 0 1.2em 2em; max-width: 60em; }
+h2 { border-bottom: 1px solid #999; margin-top: 1.4em; }
+.cmd { border-left: 3px solid #ddd; margin: 0.8em 0; padding: 0.2em 0.8em; }
+.cmd:target { border-left-color: #2a7ae2; background: #f3f8ff; }
+.cmd code.name { font-size: 1.1em; font-weight: bold; }
+.cmd .desc { margin: 0.2em 0; }
+.cmd dl { display: grid; grid-template-columns: max-content 1fr; gap: 0.1em 0.8em; margin: 0.3em 0; font-size: 0.9em; }
+.cmd dt { color: #666; }
+.cmd dd { margin: 0; }
+#empty { display: none; color: #888; margin-top: 2em; }
+footer { color: #888; font-size: 0.85em; padding: 1em 1.2em; }
+@media (max-width: 50em) { nav { display: none; } }
+@media print { header, nav { display: none; } .cmd { break-inside: avoid; } }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<input id="q" type="search" placeholder="{{label "search" .Lang}}" autofocus>
+<span class="langs">{{$lang := .Lang}}{{range .Langs}}{{if ne . $lang}}<a href="../{{.}}/index.html" hreflang="{{.}}">{{label "langname" .}}</a>{{end}}{{end}}</span>
+</header>
+<div class="layout">
+<nav>
+<strong>{{label "contents" .Lang}}</strong> <span class="n">({{count .Count .Lang}})</span>
+<ul>
+{{range .Sections}}<li><a href="#{{.ID}}">{{.Title}}</a> <span class="n">{{len .Entries}}</span></li>
+{{end}}</ul>
+</nav>
+<main>
+{{range .Sections}}<section id="{{.ID}}">
+<h2>{{.Title}}</h2>
+{{range .Entries}}<div class="cmd" id="{{.ID}}" data-search="{{.Command.Command}} {{.Keyword}} {{.Description}} {{.Category}} {{join .Aliases " "}}">
+<code class="name">{{.Command.Command}}</code>
+<p class="desc">{{.Description}}</p>
+<dl>
+{{if .Example}}<dt>{{label "example" $lang}}</dt><dd>{{.Example}}</dd>
+{{end}}{{if .Modes}}<dt>{{label "modes" $lang}}</dt><dd>{{join .Modes ", "}}</dd>
+{{end}}{{if .Available}}<dt>{{label "available" $lang}}</dt><dd>{{.Available}}</dd>
+{{end}}{{if .Aliases}}<dt>{{label "aliases" $lang}}</dt><dd>{{range $i, $a := .Aliases}}{{if $i}}, {{end}}<code>{{$a}}</code>{{end}}</dd>
+{{end}}{{if and .Source (ne .Source "built-in")}}<dt>{{label "source" $lang}}</dt><dd>{{.Source}}</dd>
+{{end}}{{if .HelpTag}}<dt>{{label "vimhelp" $lang}}</dt><dd><code>:help {{.HelpTag}}</code></dd>
+{{end}}{{range .Related}}<dt>{{.Kind}}</dt><dd><a href="#{{.ID}}"><code>{{.Command}}</code></a></dd>
+{{end}}</dl>
+</div>
+{{end}}</section>
+{{end}}<p id="empty">{{label "noresults" .Lang}}</p>
+</main>
+</div>
+<footer>{{label "generated" .Lang}}</footer>
+<script>
+(function () {
+  var q = document.getElementById("q");
+  var entries = document.querySelectorAll(".cmd");
+  var sections = document.querySelectorAll("main section");
+  function filter() {
+    var words = q.value.toLowerCase().split(/\s+/).filter(Boolean);
+    var shown = 0;
+    entries.forEach(function (e) {
+      var text = e.getAttribute("data-search").toLowerCase();
+      var match = words.every(function (w) { return text.indexOf(w) >= 0; });
+      e.style.display = match ? "" : "none";
+      if (match) shown++;
+    });
+    sections.forEach(function (s) {
+      s.style.display = s.querySelector(".cmd:not([style*='none'])") ? "" : "none";
+    });
+    document.getElementById("empty").style.display = shown ? "none" : "block";
+  }
+  q.addEventListener("input", filter);
+  q.addEventListener("keydown", function (ev) {
+    if (ev.key === "Escape") { q.value = ""; filter(); }
+  });
+})();
+</script>
+</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.New("index").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>vi-assistant</title>
+<style>body { font-family: sans-serif; margin: 3em; } li { margin: 0.4em 0; font-size: 1.2em; }</style>
+</head>
+<body>
+<h1>vi-assistant</h1>
+<ul>
+{{range .}}<li><a href="{{.}}/index.html" hreflang="{{.}}">{{label "langname" .}}</a> - {{label "title" .}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
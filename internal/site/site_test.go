@@ -0,0 +1,137 @@
+package site
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/testenv"
+)
+
+func TestAnchor(t *testing.T) {
+	tests := map[string]string{
+		"dd":        "cmd-dd",
+		":wq":       "cmd-.3awq",
+		":help {x}": "cmd-.3ahelp.20.7bx.7d",
+	}
+	for command, want := range tests {
+		if got := anchor(command); got != want {
+			t.Errorf("anchor(%q) = %q, want %q", command, got, want)
+		}
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	commands := testenv.Catalog(t)
+	page := NewPage(commands, "en")
+
+	if page.Count != len(commands) || len(page.Sections) == 0 {
+		t.Fatalf("page has %d commands in %d sections", page.Count, len(page.Sections))
+	}
+	ids := make(map[string]bool)
+	total := 0
+	for _, s := range page.Sections {
+		for _, e := range s.Entries {
+			if e.Category != strings.TrimPrefix(s.ID, "category-") {
+				t.Errorf("%s is in section %s", e.Command.Command, s.ID)
+			}
+			ids[e.ID] = true
+			total++
+		}
+	}
+	if total != page.Count {
+		t.Errorf("sections hold %d entries, want %d", total, page.Count)
+	}
+
+	related := 0
+	for _, s := range page.Sections {
+		for _, e := range s.Entries {
+			for _, link := range e.Related {
+				related++
+				if !ids[link.ID] {
+					t.Errorf("%s links to %s, which is not on the page", e.Command.Command, link.Command)
+				}
+			}
+		}
+	}
+	if related == 0 {
+		t.Error("no relations resolved")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	commands := testenv.Catalog(t)
+	dir := t.TempDir()
+	if err := Write(dir, commands); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := ioutil.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range Langs {
+		if !strings.Contains(string(index), `href="`+lang+`/index.html"`) {
+			t.Errorf("index does not link to %s", lang)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, lang, "index.html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		html := string(data)
+		if !strings.Contains(html, `<html lang="`+lang+`">`) || !strings.Contains(html, label("search", lang)) {
+			t.Errorf("%s page is not localized", lang)
+		}
+		if n := strings.Count(html, `class="cmd"`); n != len(commands) {
+			t.Errorf("%s page has %d commands, want %d", lang, n, len(commands))
+		}
+		if strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+			t.Errorf("%s page loads something from the network", lang)
+		}
+	}
+
+	// Opened from file:// there is no directory index, so every link must name a file
+	pages := map[string]string{filepath.Join(dir, "index.html"): string(index)}
+	for _, lang := range Langs {
+		page := filepath.Join(dir, lang, "index.html")
+		data, _ := ioutil.ReadFile(page)
+		pages[page] = string(data)
+	}
+	for page, html := range pages {
+		for _, m := range hrefPattern.FindAllStringSubmatch(html, -1) {
+			if strings.HasPrefix(m[1], "#") {
+				continue
+			}
+			if info, err := os.Stat(filepath.Join(filepath.Dir(page), filepath.FromSlash(m[1]))); err != nil || info.IsDir() {
+				t.Errorf("%s links to %s, which is not a file", page, m[1])
+			}
+		}
+	}
+}
+
+var hrefPattern = regexp.MustCompile(`href="([^"]*)"`)
+
+func TestWritePageEscapes(t *testing.T) {
+	commands := []catalog.Command{{
+		Keyword:     "redo",
+		Command:     "<C-r>",
+		Description: "되돌린 변경을 <다시> 적용합니다 & more",
+		Category:    "undo",
+	}}
+	var out strings.Builder
+	if err := WritePage(&out, NewPage(commands, "ko")); err != nil {
+		t.Fatal(err)
+	}
+	html := out.String()
+	if !strings.Contains(html, `<code class="name">&lt;C-r&gt;</code>`) || !strings.Contains(html, "&lt;다시&gt; 적용합니다 &amp; more") {
+		t.Errorf("command text is not escaped:\n%s", html)
+	}
+	if !strings.Contains(html, `id="cmd-.3cC-r.3e"`) {
+		t.Error("anchor of <C-r> not found")
+	}
+}
@@ -0,0 +1,27 @@
+// Package style adds optional ANSI highlighting to formatted output.
+package style
+
+// Enabled turns highlighting on; it is set once at startup from the color setting
+var Enabled = false
+
+const (
+	bold  = "\033[1m"
+	cyan  = "\033[36m"
+	reset = "\033[0m"
+)
+
+// Command highlights a vi command
+func Command(s string) string {
+	if !Enabled {
+		return s
+	}
+	return bold + cyan + s + reset
+}
+
+// Heading highlights a heading line
+func Heading(s string) string {
+	if !Enabled {
+		return s
+	}
+	return bold + s + reset
+}
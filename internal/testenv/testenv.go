@@ -0,0 +1,50 @@
+// Package testenv points tests at the data files shipped in the repository
+// so package tests share one setup instead of copying it.
+package testenv
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/options"
+)
+
+// DataPath returns the path of a file in the repository's data directory
+func DataPath(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "data", name)
+}
+
+// Catalog reads the bundled catalog without touching the catalog settings
+func Catalog(t testing.TB) []catalog.Command {
+	t.Helper()
+	commands, err := catalog.LoadFile(DataPath("commands.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commands
+}
+
+// UseBundledData makes catalog.Load and options.Load read the bundled files
+// without packs until the test ends, for code that loads them itself
+func UseBundledData(t testing.TB) {
+	t.Helper()
+	catalog.SetSources([]string{DataPath("commands.json")})
+	catalog.SetPackDir("")
+	options.SetSource(DataPath("options.json"))
+	t.Cleanup(func() {
+		catalog.SetSources(nil)
+		options.SetSource("")
+	})
+}
+
+// Favorites returns a favorites opener backed by a temporary directory
+func Favorites(t testing.TB) func() (*favorites.FavoritesManager, error) {
+	dir := t.TempDir()
+	return func() (*favorites.FavoritesManager, error) {
+		return favorites.NewFavoritesManagerAt(filepath.Join(dir, "favorites.json"), filepath.Join(dir, "state"))
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import "bufio"
+
+// KeyCode identifies a key that is not a plain character
+type KeyCode int
+
+// Key codes reported by ReadKey
+const (
+	KeyRune KeyCode = iota // a printable character in Key.Rune
+	KeyCtrl                // Ctrl with the letter in Key.Rune, e.g. Ctrl+d
+	KeyEnter
+	KeyEsc
+	KeyTab
+	KeyBackspace
+	KeyDelete
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyUnknown // an escape sequence we do not handle
+)
+
+// Key is one key press
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+var keyNames = map[KeyCode]string{
+	KeyEnter:     "Enter",
+	KeyEsc:       "Esc",
+	KeyTab:       "Tab",
+	KeyBackspace: "Backspace",
+	KeyDelete:    "Delete",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyLeft:      "Left",
+	KeyRight:     "Right",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyPageUp:    "PageUp",
+	KeyPageDown:  "PageDown",
+	KeyUnknown:   "Unknown",
+}
+
+// String names the key the way the catalog does, e.g. "j", "Ctrl+d", "Esc"
+func (k Key) String() string {
+	switch k.Code {
+	case KeyRune:
+		return string(k.Rune)
+	case KeyCtrl:
+		return "Ctrl+" + string(k.Rune)
+	}
+	return keyNames[k.Code]
+}
+
+// Is reports whether k is the plain character r
+func (k Key) Is(r rune) bool {
+	return k.Code == KeyRune && k.Rune == r
+}
+
+// IsCtrl reports whether k is Ctrl with the letter r
+func (k Key) IsCtrl(r rune) bool {
+	return k.Code == KeyCtrl && k.Rune == r
+}
+
+// csiKeys maps the final byte of "ESC [ x" and "ESC O x" sequences
+var csiKeys = map[byte]KeyCode{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+}
+
+// maxCSIParams is longer than any parameter list of the keys we decode
+const maxCSIParams = 16
+
+// tildeKeys maps the number of "ESC [ n ~" sequences
+var tildeKeys = map[string]KeyCode{
+	"1": KeyHome,
+	"7": KeyHome,
+	"3": KeyDelete,
+	"4": KeyEnd,
+	"8": KeyEnd,
+	"5": KeyPageUp,
+	"6": KeyPageDown,
+}
+
+// ReadKey reads one key from a terminal in raw mode.
+// A lone ESC is the Esc key; ESC followed by "[" or "O" in the same read is
+// decoded as a cursor or editing key, so typing Esc then "O" still opens a line in vi.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch c {
+	case '\r', '\n':
+		return Key{Code: KeyEnter}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 0x7f, 0x08:
+		return Key{Code: KeyBackspace}, nil
+	case 0x1b:
+		return readEscape(r)
+	}
+	if c >= 0x01 && c <= 0x1a {
+		return Key{Code: KeyCtrl, Rune: 'a' + c - 1}, nil
+	}
+	if c < 0x20 {
+		return Key{Code: KeyUnknown}, nil
+	}
+	return Key{Code: KeyRune, Rune: c}, nil
+}
+
+// readEscape decodes what follows ESC
+func readEscape(r *bufio.Reader) (Key, error) {
+	if r.Buffered() < 2 {
+		return Key{Code: KeyEsc}, nil
+	}
+	next, _ := r.Peek(2)
+	switch next[0] {
+	case 'O':
+		code, ok := csiKeys[next[1]]
+		if !ok {
+			return Key{Code: KeyEsc}, nil
+		}
+		r.Discard(2)
+		return Key{Code: code}, nil
+	case '[':
+		r.Discard(1)
+		return readCSI(r)
+	}
+	return Key{Code: KeyEsc}, nil
+}
+
+// readCSI reads the parameters and final byte of "ESC [ ...".
+// Only bytes from the same read belong to the sequence: one that is cut off,
+// too long or unknown is reported as KeyUnknown so the caller can ignore it.
+func readCSI(r *bufio.Reader) (Key, error) {
+	var params []byte
+	for r.Buffered() > 0 {
+		b, _ := r.ReadByte()
+		if b >= 0x40 && b <= 0x7e {
+			if len(params) > maxCSIParams {
+				return Key{Code: KeyUnknown}, nil
+			}
+			if b == '~' {
+				if code, ok := tildeKeys[string(params)]; ok {
+					return Key{Code: code}, nil
+				}
+				return Key{Code: KeyUnknown}, nil
+			}
+			if code, ok := csiKeys[b]; ok {
+				return Key{Code: code}, nil
+			}
+			return Key{Code: KeyUnknown}, nil
+		}
+		params = append(params, b)
+	}
+	return Key{Code: KeyUnknown}, nil
+}
@@ -0,0 +1,348 @@
+// Package ui is the full-screen catalog browser behind the "ui" command.
+// The browser state lives in Model, which turns key presses into state changes
+// and renders frames as plain lines with ANSI attributes, so it can be driven
+// by a real terminal (Run) or by a scripted one in tests.
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/search"
+)
+
+// pane identifies one of the three columns
+type pane int
+
+const (
+	paneSidebar pane = iota
+	paneList
+	paneDetail
+)
+
+// Options configures a browser session
+type Options struct {
+	Commands   []catalog.Command
+	Categories []string                    // sidebar entries, usually from search.GetCategories
+	Favorites  *favorites.FavoritesManager // nil disables the favorite toggle
+	Lang       string
+	Query      string // initial search
+}
+
+// Model is the browser state
+type Model struct {
+	lang       string
+	commands   []catalog.Command
+	graph      *catalog.Graph
+	categories []string // index 0 is "all"
+	favs       *favorites.FavoritesManager
+	favorite   map[string]bool
+
+	focus      pane
+	searching  bool   // typing a search after "/"
+	query      string // current search, with search command filters
+	savedQuery string // restored when the search is cancelled with Esc
+	category   int    // selected sidebar entry
+	results    []catalog.Command
+	cursor     int
+	help       bool // show key help in the detail pane
+	pendingG   bool // first "g" of "gg"
+	status     string
+	statusErr  bool
+
+	// scroll offsets, adjusted while rendering
+	sideTop, listTop, detailTop int
+	// size of the last rendered frame, used for page movements
+	width, height int
+}
+
+// New creates a browser showing every command
+func New(opts Options) *Model {
+	m := &Model{
+		lang:       opts.Lang,
+		commands:   opts.Commands,
+		graph:      catalog.NewGraph(opts.Commands),
+		categories: append([]string{""}, opts.Categories...),
+		favs:       opts.Favorites,
+		favorite:   make(map[string]bool),
+		focus:      paneList,
+		query:      opts.Query,
+		height:     24,
+	}
+	if m.favs != nil {
+		list, err := m.favs.List()
+		if err != nil {
+			m.setError(err)
+		}
+		for _, fav := range list {
+			m.favorite[fav.Command] = true
+		}
+	}
+	m.refresh()
+	return m
+}
+
+func pick(en bool, ko, english string) string {
+	if en {
+		return english
+	}
+	return ko
+}
+
+func (m *Model) en() bool {
+	return m.lang == "en"
+}
+
+// Selected returns the command under the cursor
+func (m *Model) Selected() (catalog.Command, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.results) {
+		return catalog.Command{}, false
+	}
+	return m.results[m.cursor], true
+}
+
+// Query returns the current search
+func (m *Model) Query() string {
+	return m.query
+}
+
+// Category returns the selected sidebar category, "" for all commands
+func (m *Model) Category() string {
+	return m.categories[m.category]
+}
+
+// Results returns the commands shown in the list
+func (m *Model) Results() []catalog.Command {
+	return m.results
+}
+
+// IsFavorite reports whether a command is in favorites
+func (m *Model) IsFavorite(command string) bool {
+	return m.favorite[command]
+}
+
+// refresh recomputes the list from the search and the selected category.
+// An invalid filter keeps the previous list and reports the error.
+func (m *Model) refresh() {
+	query, err := search.ParseQuery(m.query)
+	if err != nil {
+		m.setError(err)
+		return
+	}
+	if m.statusErr {
+		m.status, m.statusErr = "", false
+	}
+
+	category := m.Category()
+	var results []catalog.Command
+	for _, cmd := range m.commands {
+		if category != "" && !strings.EqualFold(cmd.Category, category) {
+			continue
+		}
+		if query.Matches(cmd) {
+			results = append(results, cmd)
+		}
+	}
+	m.results = results
+	m.cursor, m.listTop, m.detailTop = 0, 0, 0
+}
+
+func (m *Model) setStatus(format string, args ...interface{}) {
+	m.status, m.statusErr = fmt.Sprintf(format, args...), false
+}
+
+func (m *Model) setError(err error) {
+	m.status, m.statusErr = err.Error(), true
+}
+
+// HandleKey applies one key press and reports whether the browser should quit
+func (m *Model) HandleKey(k Key) bool {
+	if m.searching {
+		m.handleSearchKey(k)
+		return false
+	}
+
+	pendingG := m.pendingG
+	m.pendingG = false
+
+	switch {
+	case k.Is('q'), k.IsCtrl('c'):
+		return true
+	case k.Is('/'):
+		m.searching, m.savedQuery = true, m.query
+		m.focus = paneList
+	case k.Is('?'):
+		m.help = !m.help
+		m.detailTop = 0
+	case k.Is('f'):
+		m.toggleFavorite()
+	case k.Code == KeyTab:
+		m.focus = (m.focus + 1) % 3
+	case k.Is('h'), k.Code == KeyLeft:
+		if m.focus > paneSidebar {
+			m.focus--
+		}
+	case k.Is('l'), k.Code == KeyRight:
+		if m.focus < paneDetail {
+			m.focus++
+		}
+	case k.Code == KeyEnter:
+		if m.focus < paneDetail {
+			m.focus++
+		}
+	case k.Code == KeyEsc:
+		switch {
+		case m.help:
+			m.help = false
+		case m.focus == paneDetail:
+			m.focus = paneList
+		case m.query != "":
+			m.query = ""
+			m.refresh()
+		}
+	case k.Is('j'), k.Code == KeyDown, k.IsCtrl('n'):
+		m.move(1)
+	case k.Is('k'), k.Code == KeyUp, k.IsCtrl('p'):
+		m.move(-1)
+	case k.IsCtrl('d'):
+		m.move(m.pageSize() / 2)
+	case k.IsCtrl('u'):
+		m.move(-m.pageSize() / 2)
+	case k.IsCtrl('f'), k.Code == KeyPageDown:
+		m.move(m.pageSize())
+	case k.IsCtrl('b'), k.Code == KeyPageUp:
+		m.move(-m.pageSize())
+	case k.Is('g'):
+		if pendingG {
+			m.move(-m.itemCount())
+		} else {
+			m.pendingG = true
+		}
+	case k.Code == KeyHome:
+		m.move(-m.itemCount())
+	case k.Is('G'), k.Code == KeyEnd:
+		m.move(m.itemCount())
+	}
+	return false
+}
+
+// handleSearchKey edits the search; the list follows every change
+func (m *Model) handleSearchKey(k Key) {
+	switch {
+	case k.Code == KeyEsc, k.IsCtrl('c'):
+		m.searching = false
+		m.query = m.savedQuery
+		m.refresh()
+	case k.Code == KeyEnter:
+		m.searching = false
+	case k.Code == KeyBackspace:
+		if m.query == "" {
+			m.searching = false
+			return
+		}
+		runes := []rune(m.query)
+		m.query = string(runes[:len(runes)-1])
+		m.refresh()
+	case k.IsCtrl('u'):
+		m.query = ""
+		m.refresh()
+	case k.IsCtrl('w'):
+		trimmed := strings.TrimRight(m.query, " ")
+		m.query = trimmed[:strings.LastIndex(trimmed, " ")+1]
+		m.refresh()
+	case k.Code == KeyDown, k.IsCtrl('n'):
+		m.moveCursor(1)
+	case k.Code == KeyUp, k.IsCtrl('p'):
+		m.moveCursor(-1)
+	case k.Code == KeyRune:
+		m.query += string(k.Rune)
+		m.refresh()
+	}
+}
+
+// pageSize is the number of list rows visible in the last frame
+func (m *Model) pageSize() int {
+	if n := m.height - 4; n > 1 {
+		return n
+	}
+	return 1
+}
+
+// itemCount is the number of entries in the focused pane
+func (m *Model) itemCount() int {
+	switch m.focus {
+	case paneSidebar:
+		return len(m.categories)
+	case paneDetail:
+		return len(m.detailLines(m.detailWidth()))
+	}
+	return len(m.results)
+}
+
+// move moves the selection of the focused pane by n entries
+func (m *Model) move(n int) {
+	switch m.focus {
+	case paneSidebar:
+		selected := clamp(m.category+n, 0, len(m.categories)-1)
+		if selected != m.category {
+			m.category = selected
+			m.refresh()
+		}
+	case paneList:
+		m.moveCursor(n)
+	case paneDetail:
+		m.detailTop = clamp(m.detailTop+n, 0, len(m.detailLines(m.detailWidth()))-1)
+	}
+}
+
+func (m *Model) moveCursor(n int) {
+	m.cursor = clamp(m.cursor+n, 0, len(m.results)-1)
+	m.detailTop = 0
+}
+
+// toggleFavorite adds the selected command to favorites or removes it
+func (m *Model) toggleFavorite() {
+	cmd, ok := m.Selected()
+	if !ok {
+		return
+	}
+	if m.favs == nil {
+		m.setError(errors.New(pick(m.en(), "즐겨찾기를 사용할 수 없습니다", "favorites are not available")))
+		return
+	}
+
+	if m.favorite[cmd.Command] {
+		if err := m.favs.Remove(cmd.Command); err != nil {
+			m.setError(err)
+			return
+		}
+		delete(m.favorite, cmd.Command)
+		m.setStatus(pick(m.en(), "즐겨찾기에서 제거했습니다: %s", "Removed from favorites: %s"), cmd.Command)
+		return
+	}
+
+	err := m.favs.Add(favorites.Favorite{
+		Command:     cmd.Command,
+		Description: cmd.Description,
+		Category:    cmd.Category,
+	})
+	if err != nil {
+		m.setError(err)
+		return
+	}
+	m.favorite[cmd.Command] = true
+	m.setStatus(pick(m.en(), "즐겨찾기에 추가했습니다: %s", "Added to favorites: %s"), cmd.Command)
+}
+
+func clamp(n, low, high int) int {
+	if n > high {
+		n = high
+	}
+	if n < low {
+		n = low
+	}
+	return n
+}
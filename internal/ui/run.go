@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Terminal is where the browser reads keys and draws frames.
+// Input must be unbuffered raw keystrokes: one Read per key press or escape sequence.
+type Terminal interface {
+	io.ReadWriter
+	Size() (width, height int, err error)
+}
+
+// resizer is a Terminal that reports when its size changes.
+// The browser redraws on every value received from Resized.
+type resizer interface {
+	Resized() <-chan os.Signal
+}
+
+// keyEvent is a key press or the error that ended the input
+type keyEvent struct {
+	key Key
+	err error
+}
+
+// Escape sequences written around a session
+const (
+	enterScreen = "\033[?1049h\033[?25l" // alternate screen, hidden cursor
+	leaveScreen = "\033[?25h\033[?1049l"
+	clearScreen = "\033[2J"
+	showCursor  = "\033[?25h"
+	hideCursor  = "\033[?25l"
+)
+
+// Run shows the browser until q, Ctrl+C or the end of input.
+// The terminal's screen is restored before Run returns.
+func Run(t Terminal, m *Model) (err error) {
+	if _, err := io.WriteString(t, enterScreen+clearScreen); err != nil {
+		return err
+	}
+	defer func() {
+		if _, restoreErr := io.WriteString(t, leaveScreen); err == nil {
+			err = restoreErr
+		}
+	}()
+
+	var resized <-chan os.Signal
+	if r, ok := t.(resizer); ok {
+		resized = r.Resized()
+	}
+	next := make(chan struct{}, 1)
+	defer close(next)
+	keys := readKeys(bufio.NewReader(t), next)
+
+	for {
+		width, height, err := t.Size()
+		if err != nil {
+			return fmt.Errorf("터미널 크기를 알 수 없습니다: %v", err)
+		}
+		if err := draw(t, m.Render(width, height)); err != nil {
+			return err
+		}
+
+		var ev keyEvent
+		select {
+		case <-resized:
+			io.WriteString(t, clearScreen)
+			continue
+		case ev = <-keys:
+		}
+		if ev.err == io.EOF {
+			return nil
+		}
+		if ev.err != nil {
+			return ev.err
+		}
+		if ev.key.IsCtrl('l') {
+			io.WriteString(t, clearScreen)
+		} else if m.HandleKey(ev.key) {
+			return nil
+		}
+		next <- struct{}{}
+	}
+}
+
+// readKeys reads keys in the background so Run can also wait for resizes.
+// A key is read only after a value on next, so nothing typed after the
+// browser quits is taken from the terminal.
+func readKeys(r *bufio.Reader, next chan struct{}) <-chan keyEvent {
+	keys := make(chan keyEvent)
+	next <- struct{}{}
+	go func() {
+		for range next {
+			key, err := ReadKey(r)
+			keys <- keyEvent{key, err}
+		}
+	}()
+	return keys
+}
+
+// draw writes a whole frame in one write to avoid flicker
+func draw(w io.Writer, frame Frame) error {
+	var buf bytes.Buffer
+	buf.WriteString(hideCursor)
+	for i, line := range frame.Lines {
+		fmt.Fprintf(&buf, "\033[%d;1H%s", i+1, line)
+	}
+	if frame.ShowCursor {
+		fmt.Fprintf(&buf, "\033[%d;%dH%s", frame.CursorRow+1, frame.CursorCol+1, showCursor)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,31 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package ui
+
+import (
+	"errors"
+	"os"
+)
+
+// TTY is not supported on this platform
+type TTY struct{}
+
+// IsTerminal reports false, since no terminal can be opened here
+func IsTerminal(f *os.File) bool { return false }
+
+// OpenTTY reports that the browser is not available on this platform
+func OpenTTY() (*TTY, error) {
+	return nil, errors.New("이 플랫폼에서는 ui 명령어를 지원하지 않습니다")
+}
+
+func (t *TTY) Read(p []byte) (int, error)  { return 0, nil }
+func (t *TTY) Write(p []byte) (int, error) { return len(p), nil }
+
+// Size is never called because OpenTTY fails
+func (t *TTY) Size() (int, int, error) { return 0, 0, nil }
+
+// Raw does nothing
+func (t *TTY) Raw() error { return nil }
+
+// Restore does nothing
+func (t *TTY) Restore() error { return nil }
@@ -0,0 +1,88 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package ui
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+)
+
+// TTY is the process's controlling terminal in raw mode
+type TTY struct {
+	in, out *os.File
+	saved   unix.Termios
+	raw     unix.Termios
+	resized chan os.Signal
+}
+
+// IsTerminal reports whether f is a terminal
+func IsTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), ioctlGetTermios)
+	return err == nil
+}
+
+// OpenTTY switches standard input to raw mode for the browser.
+// Call Restore to return the terminal to its previous mode and Raw to enter raw mode again.
+func OpenTTY() (*TTY, error) {
+	fd := int(os.Stdin.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, errors.New("터미널에서만 실행할 수 있습니다")
+	}
+	t := &TTY{in: os.Stdin, out: os.Stdout, saved: *termios}
+
+	raw := *termios
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	t.raw = raw
+	t.resized = make(chan os.Signal, 1)
+	if err := t.Raw(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Raw switches the terminal to raw mode again after Restore
+func (t *TTY) Raw() error {
+	if err := unix.IoctlSetTermios(int(t.in.Fd()), ioctlSetTermios, &t.raw); err != nil {
+		return err
+	}
+	signal.Notify(t.resized, unix.SIGWINCH)
+	return nil
+}
+
+func (t *TTY) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+func (t *TTY) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+// Size returns the terminal size in cells
+func (t *TTY) Size() (int, int, error) {
+	ws, err := unix.IoctlGetWinsize(int(t.out.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// Resized receives a value whenever the terminal window changes size
+func (t *TTY) Resized() <-chan os.Signal {
+	return t.resized
+}
+
+// Restore returns the terminal to the mode it had before OpenTTY
+func (t *TTY) Restore() error {
+	signal.Stop(t.resized)
+	return unix.IoctlSetTermios(int(t.in.Fd()), ioctlSetTermios, &t.saved)
+}
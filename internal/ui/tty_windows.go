@@ -0,0 +1,80 @@
+//go:build windows
+
+package ui
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// TTY is the console in raw mode with virtual terminal sequences enabled
+type TTY struct {
+	in, out         *os.File
+	inMode, outMode uint32
+	rawIn, rawOut   uint32
+}
+
+// IsTerminal reports whether f is a console
+func IsTerminal(f *os.File) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(f.Fd()), &mode) == nil
+}
+
+// OpenTTY switches the console to raw mode for the browser.
+// Call Restore to return the console to its previous mode and Raw to enter raw mode again.
+func OpenTTY() (*TTY, error) {
+	t := &TTY{in: os.Stdin, out: os.Stdout}
+	in, out := windows.Handle(t.in.Fd()), windows.Handle(t.out.Fd())
+	if err := windows.GetConsoleMode(in, &t.inMode); err != nil {
+		return nil, errors.New("터미널에서만 실행할 수 있습니다")
+	}
+	if err := windows.GetConsoleMode(out, &t.outMode); err != nil {
+		return nil, errors.New("터미널에서만 실행할 수 있습니다")
+	}
+
+	t.rawIn = t.inMode&^(windows.ENABLE_ECHO_INPUT|windows.ENABLE_PROCESSED_INPUT|windows.ENABLE_LINE_INPUT) |
+		windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+	t.rawOut = t.outMode | windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	if err := t.Raw(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Raw switches the console to raw mode again after Restore
+func (t *TTY) Raw() error {
+	in, out := windows.Handle(t.in.Fd()), windows.Handle(t.out.Fd())
+	if err := windows.SetConsoleMode(in, t.rawIn); err != nil {
+		return err
+	}
+	if err := windows.SetConsoleMode(out, t.rawOut); err != nil {
+		windows.SetConsoleMode(in, t.inMode)
+		return err
+	}
+	return nil
+}
+
+func (t *TTY) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+func (t *TTY) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+// Size returns the size of the visible console window in cells
+func (t *TTY) Size() (int, int, error) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(t.out.Fd()), &info); err != nil {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// Restore returns the console to the modes it had before OpenTTY
+func (t *TTY) Restore() error {
+	windows.SetConsoleMode(windows.Handle(t.out.Fd()), t.outMode)
+	return windows.SetConsoleMode(windows.Handle(t.in.Fd()), t.inMode)
+}
@@ -0,0 +1,335 @@
+package ui
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/favorites"
+	"vi-assistant/internal/search"
+	"vi-assistant/internal/testenv"
+)
+
+// newTestModel opens a browser over the bundled catalog with favorites in a temporary directory
+func newTestModel(t *testing.T, lang string) (*Model, *favorites.FavoritesManager) {
+	t.Helper()
+	testenv.UseBundledData(t)
+
+	commands, err := catalog.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	categories, err := search.GetCategories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(categories)
+
+	fm, err := testenv.Favorites(t)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(Options{Commands: commands, Categories: categories, Favorites: fm, Lang: lang}), fm
+}
+
+// run plays script on a 100x30 virtual terminal and returns the final screen
+func run(t *testing.T, m *Model, script string) *virtualTerminal {
+	t.Helper()
+	vt := newVirtualTerminal(100, 30, script)
+	if err := Run(vt, m); err != nil {
+		t.Fatal(err)
+	}
+	return vt
+}
+
+func TestMovement(t *testing.T) {
+	m, _ := newTestModel(t, "ko")
+	results := m.Results()
+
+	vt := run(t, m, "jjj")
+	if got := vt.selection(); !strings.HasPrefix(got, results[3].Command) {
+		t.Errorf("selection after jjj = %q, want %s", got, results[3].Command)
+	}
+	if !strings.Contains(vt.screen(), results[3].Description) {
+		t.Errorf("detail pane does not show %s:\n%s", results[3].Command, vt.screen())
+	}
+
+	run(t, m, "G")
+	if cmd, _ := m.Selected(); cmd.Command != results[len(results)-1].Command {
+		t.Errorf("G selected %s", cmd.Command)
+	}
+	run(t, m, "kgg")
+	if cmd, _ := m.Selected(); cmd.Command != results[0].Command {
+		t.Errorf("gg selected %s", cmd.Command)
+	}
+	run(t, m, "<C-d>")
+	if cmd, _ := m.Selected(); cmd.Command != results[m.pageSize()/2].Command {
+		t.Errorf("Ctrl+d selected %s", cmd.Command)
+	}
+}
+
+func TestIncrementalSearch(t *testing.T) {
+	m, _ := newTestModel(t, "ko")
+	total := len(m.Results())
+
+	// The list follows the search before Enter is pressed
+	vt := run(t, m, "/yy")
+	if !vt.cursorVisible || vt.line(28) != "/yy" {
+		t.Errorf("search prompt = %q (cursor visible %v)", vt.line(28), vt.cursorVisible)
+	}
+	if len(m.Results()) == 0 || len(m.Results()) == total {
+		t.Fatalf("live search found %d of %d", len(m.Results()), total)
+	}
+	if !strings.HasPrefix(vt.selection(), "yy") {
+		t.Errorf("selection = %q", vt.selection())
+	}
+	run(t, m, "<Enter>")
+
+	// Esc restores the previous search, Enter keeps the new one
+	run(t, m, "/dd<Esc>")
+	if m.Query() != "yy" {
+		t.Errorf("query after Esc = %q", m.Query())
+	}
+	run(t, m, "/<C-u>delete mode:visual<Enter>")
+	for _, cmd := range m.Results() {
+		if !cmd.HasMode("visual") {
+			t.Errorf("%s does not match mode:visual", cmd.Command)
+		}
+	}
+	run(t, m, "/<C-w><BS><BS><BS><BS><Enter>")
+	if m.Query() != "del" {
+		t.Errorf("query after Ctrl+w and Backspace = %q", m.Query())
+	}
+
+	// An unknown filter value keeps the list and shows the error while typing
+	vt = run(t, m, "/<C-u>mode:bogus")
+	if !m.statusErr || !strings.Contains(vt.line(28), "/mode:bogus  알 수 없는 모드입니다: bogus") {
+		t.Errorf("filter error not shown: %q", vt.line(28))
+	}
+
+	run(t, m, "<Enter><Esc><Esc>")
+	if m.Query() != "" || len(m.Results()) != total {
+		t.Errorf("Esc did not clear the search: %q", m.Query())
+	}
+}
+
+func TestCategorySidebar(t *testing.T) {
+	m, _ := newTestModel(t, "en")
+
+	vt := run(t, m, "hjj")
+	category := m.Category()
+	if category == "" || vt.selection() != category {
+		t.Fatalf("sidebar selection = %q, category = %q", vt.selection(), category)
+	}
+	if !strings.Contains(vt.line(0), "category: "+category) {
+		t.Errorf("title = %q", vt.line(0))
+	}
+	for _, cmd := range m.Results() {
+		if cmd.Category != category {
+			t.Errorf("%s is in %s, not %s", cmd.Command, cmd.Category, category)
+		}
+	}
+
+	// Search and category filter together; the sidebar keeps its selection
+	run(t, m, "l/line<Enter>")
+	if m.Category() != category {
+		t.Errorf("category changed to %q", m.Category())
+	}
+	run(t, m, "hgg")
+	if m.Category() != "" {
+		t.Errorf("gg in sidebar selected %q", m.Category())
+	}
+}
+
+func TestFavoriteToggle(t *testing.T) {
+	m, fm := newTestModel(t, "ko")
+
+	vt := run(t, m, "/dd<Enter>f")
+	cmd, _ := m.Selected()
+	list, _ := fm.List()
+	if len(list) != 1 || list[0].Command != cmd.Command || list[0].Category != cmd.Category {
+		t.Fatalf("favorites after f = %+v", list)
+	}
+	if !strings.HasPrefix(vt.selection(), "* "+cmd.Command) {
+		t.Errorf("list row not marked: %q", vt.selection())
+	}
+	if !strings.Contains(vt.screen(), "즐겨찾기에 추가했습니다: "+cmd.Command) {
+		t.Errorf("no status message:\n%s", vt.screen())
+	}
+
+	// A new session sees the saved favorite, and f removes it
+	m2 := New(Options{Commands: m.commands, Favorites: fm, Lang: "ko", Query: "dd"})
+	if !m2.IsFavorite(cmd.Command) {
+		t.Fatal("favorite not loaded")
+	}
+	run(t, m2, "f")
+	if list, _ := fm.List(); len(list) != 0 {
+		t.Errorf("favorites after second f = %+v", list)
+	}
+}
+
+func TestDetailPane(t *testing.T) {
+	m, _ := newTestModel(t, "en")
+
+	vt := run(t, m, "/dd<Enter>")
+	screen := vt.screen()
+	for _, want := range []string{"Command: dd", "See also:"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("detail pane missing %q:\n%s", want, screen)
+		}
+	}
+
+	vt = run(t, m, "?")
+	if !strings.Contains(vt.screen(), "search as you type") {
+		t.Errorf("? did not show help")
+	}
+	run(t, m, "<Esc>ll")
+	if m.help || m.focus != paneDetail {
+		t.Errorf("help %v, focus %d", m.help, m.focus)
+	}
+	run(t, m, "j")
+	if m.detailTop != 1 {
+		t.Errorf("j in detail pane scrolled to %d", m.detailTop)
+	}
+}
+
+func TestScreenRestored(t *testing.T) {
+	m, _ := newTestModel(t, "ko")
+	vt := run(t, m, "jq")
+	if vt.altScreen || !vt.cursorVisible {
+		t.Errorf("after q: alternate screen %v, cursor visible %v", vt.altScreen, vt.cursorVisible)
+	}
+	if len(vt.script) != 0 {
+		t.Errorf("keys left after q: %q", vt.script)
+	}
+
+	vt = newVirtualTerminal(30, 5, "")
+	if err := Run(vt, m); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(vt.line(0), "터미널이 너무") {
+		t.Errorf("small terminal = %q", vt.line(0))
+	}
+}
+
+// resizingTerminal grows to width x height when its script reaches "\x00"
+type resizingTerminal struct {
+	*virtualTerminal
+	width, height int
+	resized       chan os.Signal
+	pending       chan struct{}
+	applied       chan struct{}
+}
+
+func (rt *resizingTerminal) Resized() <-chan os.Signal {
+	return rt.resized
+}
+
+func (rt *resizingTerminal) Read(p []byte) (int, error) {
+	if len(rt.script) > 0 && rt.script[0] == "\x00" {
+		rt.script = rt.script[1:]
+		rt.pending <- struct{}{}
+		rt.resized <- os.Interrupt
+		<-rt.applied
+	}
+	return rt.virtualTerminal.Read(p)
+}
+
+// Size applies a pending resize, so the next key is read only after the redraw started
+func (rt *resizingTerminal) Size() (int, int, error) {
+	select {
+	case <-rt.pending:
+		rt.virtualTerminal.width, rt.virtualTerminal.height = rt.width, rt.height
+		rt.clear()
+		rt.applied <- struct{}{}
+	default:
+	}
+	return rt.virtualTerminal.Size()
+}
+
+func TestResize(t *testing.T) {
+	m, _ := newTestModel(t, "ko")
+	vt := newVirtualTerminal(30, 5, "")
+	vt.script = []string{"\x00"}
+	rt := &resizingTerminal{virtualTerminal: vt, width: 100, height: 30, resized: make(chan os.Signal),
+		pending: make(chan struct{}, 1), applied: make(chan struct{})}
+	if err := Run(rt, m); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(vt.screen(), "터미널이 너무") || !strings.Contains(vt.screen(), m.Results()[0].Description) {
+		t.Errorf("screen after growing the terminal:\n%s", vt.screen())
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"\x1b[A\x1b[B", []string{"Up", "Down"}},
+		{"\x1bOC", []string{"Right"}},
+		{"\x1b[5~\x1b[6~\x1b[3~", []string{"PageUp", "PageDown", "Delete"}},
+		{"\x1b[1;5D", []string{"Left"}},
+		{"\x04\x15\r\t\x7f", []string{"Ctrl+d", "Ctrl+u", "Enter", "Tab", "Backspace"}},
+		{"\x1bOo", []string{"Esc", "O", "o"}},
+		{"\x1b", []string{"Esc"}},
+		{"jㅗ", []string{"j", "ㅗ"}},
+		{"\x1b[12;34;56;78;90;12;34A", []string{"Unknown"}},
+		{"\x1b[1;5", []string{"Unknown"}},
+		{"\x1b[?2004h\x1b[M", []string{"Unknown", "Unknown"}},
+	}
+	for _, tt := range tests {
+		r := bufio.NewReader(strings.NewReader(tt.input))
+		var got []string
+		for {
+			key, err := ReadKey(r)
+			if err != nil {
+				break
+			}
+			got = append(got, key.String())
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("ReadKey(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	// A sequence cut off at the end of a read does not swallow the next key
+	vt := newVirtualTerminal(10, 1, "")
+	vt.script = []string{"\x1b[1;", "j"}
+	r := bufio.NewReader(vt)
+	for _, want := range []string{"Unknown", "j"} {
+		if key, err := ReadKey(r); err != nil || key.String() != want {
+			t.Errorf("split sequence: got %v, %v; want %s", key, err, want)
+		}
+	}
+}
+
+// An unknown escape sequence is ignored instead of ending the browser
+func TestUnknownEscapeIgnored(t *testing.T) {
+	m, _ := newTestModel(t, "ko")
+	vt := newVirtualTerminal(100, 30, "j")
+	vt.script = append(vt.script, "\x1b[999999999999999999999999z", "\x1b[200~", "j")
+	if err := Run(vt, m); err != nil {
+		t.Fatal(err)
+	}
+	if cmd, _ := m.Selected(); cmd.Command != m.Results()[2].Command {
+		t.Errorf("selection = %s, want %s", cmd.Command, m.Results()[2].Command)
+	}
+}
+
+func TestFitAndWrap(t *testing.T) {
+	if got := fit("현재 줄", 6); got != "현재 …" || TextWidth(got) != 6 {
+		t.Errorf("fit = %q (%d cells)", got, TextWidth(got))
+	}
+	if got := fit("dd", 4); got != "dd  " {
+		t.Errorf("fit pad = %q", got)
+	}
+	for _, line := range wrap("  현재 줄을 삭제합니다 and more words here", 12) {
+		if TextWidth(line) > 12 || !strings.HasPrefix(line, "  ") {
+			t.Errorf("wrapped line %q", line)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"vi-assistant/internal/explain"
+)
+
+// ANSI attributes used by the browser
+const (
+	attrReset   = "\033[0m"
+	attrBold    = "\033[1m"
+	attrDim     = "\033[2m"
+	attrReverse = "\033[7m"
+	attrCyan    = "\033[36m"
+	attrRed     = "\033[31m"
+)
+
+// Minimum terminal size for the three-pane layout
+const (
+	minWidth  = 50
+	minHeight = 8
+)
+
+// Frame is one rendered screen: exactly Height lines, each exactly Width cells wide
+type Frame struct {
+	Lines []string
+	// Cursor is shown at (CursorRow, CursorCol), zero-based, while a search is typed
+	ShowCursor           bool
+	CursorRow, CursorCol int
+}
+
+// layout holds the column widths of the three panes
+type layout struct {
+	side, list, detail int
+}
+
+func (m *Model) layout() layout {
+	side := TextWidth(pick(m.en(), "카테고리", "Categories")) + 2
+	for _, c := range m.categories {
+		if w := TextWidth(c) + 2; w > side {
+			side = w
+		}
+	}
+	if side > 20 {
+		side = 20
+	}
+	rest := m.width - side - 2 // two separators
+	list := rest * 2 / 5
+	if list < 24 {
+		list = 24
+	}
+	return layout{side: side, list: list, detail: rest - list}
+}
+
+func (m *Model) detailWidth() int {
+	return m.layout().detail - 1 // one cell of left padding
+}
+
+// Render draws the browser for a terminal of the given size
+func (m *Model) Render(width, height int) Frame {
+	m.width, m.height = width, height
+	frame := Frame{Lines: make([]string, height)}
+
+	if width < minWidth || height < minHeight {
+		msg := pick(m.en(), fmt.Sprintf("터미널이 너무 작습니다 (최소 %dx%d)", minWidth, minHeight),
+			fmt.Sprintf("Terminal too small (need %dx%d)", minWidth, minHeight))
+		for i := range frame.Lines {
+			frame.Lines[i] = strings.Repeat(" ", width)
+		}
+		frame.Lines[0] = fit(msg, width)
+		return frame
+	}
+
+	l := m.layout()
+	bodyHeight := height - 3 // title, status and key help rows
+	rows := bodyHeight - 1   // below the pane headers
+
+	side := m.sidebarLines(l.side, rows)
+	list := m.listLines(l.list, rows)
+	detail := m.detailPane(l.detail, rows)
+
+	frame.Lines[0] = attrReverse + fit(m.title(), width) + attrReset
+	frame.Lines[1] = m.header(pick(m.en(), "카테고리", "Categories"), l.side, paneSidebar) + "│" +
+		m.header(pick(m.en(), "명령어", "Commands"), l.list, paneList) + "│" +
+		m.header(pick(m.en(), "설명", "Details"), l.detail, paneDetail)
+	for i := 0; i < rows; i++ {
+		frame.Lines[2+i] = side[i] + attrDim + "│" + attrReset + list[i] + attrDim + "│" + attrReset + detail[i]
+	}
+
+	status := m.status
+	switch {
+	case m.searching:
+		status = "/" + m.query
+		frame.ShowCursor = true
+		frame.CursorRow, frame.CursorCol = height-2, TextWidth(status)
+		if m.statusErr && TextWidth(status)+2 < width {
+			// Keep filter errors visible next to the prompt while typing
+			frame.Lines[height-2] = status + "  " + attrRed + fit(m.status, width-TextWidth(status)-2) + attrReset
+		} else {
+			frame.Lines[height-2] = fit(status, width)
+		}
+	case m.statusErr:
+		frame.Lines[height-2] = attrRed + fit(status, width) + attrReset
+	default:
+		frame.Lines[height-2] = fit(status, width)
+	}
+	frame.Lines[height-1] = attrDim + fit(m.keyHelp(), width) + attrReset
+	return frame
+}
+
+// title shows the current search, category and result count
+func (m *Model) title() string {
+	parts := []string{" vi-assistant"}
+	if m.query != "" {
+		parts = append(parts, "/"+m.query)
+	}
+	if c := m.Category(); c != "" {
+		parts = append(parts, pick(m.en(), "카테고리: ", "category: ")+c)
+	}
+	parts = append(parts, fmt.Sprintf(pick(m.en(), "%d개", "%d commands"), len(m.results)))
+	return strings.Join(parts, "  │  ")
+}
+
+func (m *Model) keyHelp() string {
+	return pick(m.en(),
+		" j/k 이동  h/l 창 전환  gg/G 처음/끝  / 검색  f 즐겨찾기  ? 도움말  q 종료",
+		" j/k move  h/l switch pane  gg/G top/bottom  / search  f favorite  ? help  q quit")
+}
+
+// header renders a pane title, bold when the pane has focus
+func (m *Model) header(title string, w int, p pane) string {
+	text := fit(" "+title, w)
+	if m.focus == p {
+		return attrBold + attrCyan + text + attrReset
+	}
+	return attrDim + text + attrReset
+}
+
+// item renders one selectable row: reversed in the focused pane, bold elsewhere
+func (m *Model) item(text string, w int, selected bool, p pane) string {
+	text = fit(text, w)
+	switch {
+	case selected && m.focus == p:
+		return attrReverse + text + attrReset
+	case selected:
+		return attrBold + text + attrReset
+	}
+	return text
+}
+
+// scrollTo adjusts top so that index is within the rows visible from top
+func scrollTo(top *int, index, rows int) {
+	if index < *top {
+		*top = index
+	}
+	if index >= *top+rows {
+		*top = index - rows + 1
+	}
+	if *top < 0 {
+		*top = 0
+	}
+}
+
+func blank(lines []string, w int) []string {
+	for i := range lines {
+		if lines[i] == "" {
+			lines[i] = strings.Repeat(" ", w)
+		}
+	}
+	return lines
+}
+
+func (m *Model) sidebarLines(w, rows int) []string {
+	lines := make([]string, rows)
+	scrollTo(&m.sideTop, m.category, rows)
+	for i := 0; i < rows && m.sideTop+i < len(m.categories); i++ {
+		index := m.sideTop + i
+		name := m.categories[index]
+		if name == "" {
+			name = pick(m.en(), "전체", "all")
+		}
+		lines[i] = m.item(" "+name, w, index == m.category, paneSidebar)
+	}
+	return blank(lines, w)
+}
+
+func (m *Model) listLines(w, rows int) []string {
+	lines := make([]string, rows)
+	if len(m.results) == 0 {
+		lines[0] = fit(pick(m.en(), " 검색 결과가 없습니다", " No matching commands"), w)
+		return blank(lines, w)
+	}
+
+	commandWidth := 4
+	for _, cmd := range m.results {
+		if n := TextWidth(cmd.Command); n > commandWidth {
+			commandWidth = n
+		}
+	}
+	if commandWidth > w/2 {
+		commandWidth = w / 2
+	}
+
+	scrollTo(&m.listTop, m.cursor, rows)
+	for i := 0; i < rows && m.listTop+i < len(m.results); i++ {
+		index := m.listTop + i
+		cmd := m.results[index]
+		mark := " "
+		if m.favorite[cmd.Command] {
+			mark = "*"
+		}
+		text := fmt.Sprintf("%s %s %s", mark, fit(cmd.Command, commandWidth), cmd.Description)
+		lines[i] = m.item(text, w, index == m.cursor, paneList)
+	}
+	return blank(lines, w)
+}
+
+// detailLines is the wrapped text of the detail pane: key help, or the
+// explanation of the selected command with its related commands
+func (m *Model) detailLines(w int) []string {
+	var text string
+	if m.help {
+		text = m.helpText()
+	} else if cmd, ok := m.Selected(); ok {
+		result := &explain.ExplainResult{
+			Command: cmd,
+			Found:   true,
+			Related: m.graph.Walk(cmd.Command, 1),
+		}
+		text = explain.FormatExplanation(result, m.lang)
+		if m.favorite[cmd.Command] {
+			text += "\n" + pick(m.en(), "* 즐겨찾기에 있습니다 (f로 제거)", "* In favorites (f to remove)") + "\n"
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if line == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, wrap(line, w)...)
+	}
+	return lines
+}
+
+func (m *Model) detailPane(w, rows int) []string {
+	lines := make([]string, rows)
+	text := m.detailLines(w - 1)
+	m.detailTop = clamp(m.detailTop, 0, len(text)-1)
+	for i := 0; i < rows && m.detailTop+i < len(text); i++ {
+		lines[i] = " " + fit(text[m.detailTop+i], w-1)
+	}
+	return blank(lines, w)
+}
+
+func (m *Model) helpText() string {
+	if m.en() {
+		return `Keys
+  j k, Down Up   move in the focused pane
+  h l, Tab       focus the previous / next pane
+  Enter          focus the next pane
+  gg G           first / last entry
+  Ctrl+d Ctrl+u  half a page down / up
+  Ctrl+f Ctrl+b  a page down / up
+  /              search as you type (filters like mode:visual work)
+  Esc            cancel the search, leave the detail pane or clear the search
+  f              add or remove the selected command from favorites
+  ?              show or hide this help
+  q Ctrl+c       quit`
+	}
+	return `키
+  j k, 아래 위     현재 창에서 이동
+  h l, Tab       이전 / 다음 창으로
+  Enter          다음 창으로
+  gg G           처음 / 마지막 항목
+  Ctrl+d Ctrl+u  반 페이지 아래 / 위
+  Ctrl+f Ctrl+b  한 페이지 아래 / 위
+  /              입력하는 대로 검색 (mode:visual 같은 필터 사용 가능)
+  Esc            검색 취소, 설명 창에서 나가기, 검색어 지우기
+  f              선택한 명령어를 즐겨찾기에 추가하거나 제거
+  ?              도움말 보이기 / 숨기기
+  q Ctrl+c       종료`
+}
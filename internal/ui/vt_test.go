@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// virtualTerminal is a scripted Terminal for tests. Every script step is
+// delivered by its own Read, like separate key presses on a real terminal,
+// and output is interpreted into a grid of cells so tests can inspect the screen.
+type virtualTerminal struct {
+	width, height int
+	script        []string
+
+	cells         [][]vtCell
+	row, col      int
+	reverse       bool
+	cursorVisible bool
+	altScreen     bool
+}
+
+type vtCell struct {
+	r        rune
+	reverse  bool
+	trailing bool // second cell of a wide character
+}
+
+// vtKeys maps the <Name> notation of test scripts to the bytes a terminal sends
+var vtKeys = map[string]string{
+	"Enter":  "\r",
+	"Esc":    "\x1b",
+	"Tab":    "\t",
+	"BS":     "\x7f",
+	"Up":     "\x1b[A",
+	"Down":   "\x1b[B",
+	"Right":  "\x1b[C",
+	"Left":   "\x1b[D",
+	"PageDn": "\x1b[6~",
+	"C-c":    "\x03",
+	"C-d":    "\x04",
+	"C-u":    "\x15",
+	"C-w":    "\x17",
+}
+
+// newVirtualTerminal creates a terminal that types script, e.g. "jj/yank<Enter>f".
+// Each character and each <Name> is one key press.
+func newVirtualTerminal(width, height int, script string) *virtualTerminal {
+	vt := &virtualTerminal{width: width, height: height}
+	for script != "" {
+		if strings.HasPrefix(script, "<") {
+			if end := strings.Index(script, ">"); end > 0 {
+				if seq, ok := vtKeys[script[1:end]]; ok {
+					vt.script = append(vt.script, seq)
+					script = script[end+1:]
+					continue
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(script)
+		vt.script = append(vt.script, script[:size])
+		script = script[size:]
+	}
+	vt.clear()
+	return vt
+}
+
+func (vt *virtualTerminal) Size() (int, int, error) {
+	return vt.width, vt.height, nil
+}
+
+func (vt *virtualTerminal) Read(p []byte) (int, error) {
+	if len(vt.script) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, vt.script[0])
+	vt.script = vt.script[1:]
+	return n, nil
+}
+
+func (vt *virtualTerminal) clear() {
+	vt.cells = make([][]vtCell, vt.height)
+	for i := range vt.cells {
+		vt.cells[i] = make([]vtCell, vt.width)
+		for j := range vt.cells[i] {
+			vt.cells[i][j].r = ' '
+		}
+	}
+}
+
+// Write interprets the escape sequences the browser uses and ignores the rest
+func (vt *virtualTerminal) Write(p []byte) (int, error) {
+	s := string(p)
+	for s != "" {
+		if strings.HasPrefix(s, "\x1b[") {
+			end := 2
+			for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+				end++
+			}
+			if end == len(s) {
+				break
+			}
+			vt.control(s[2:end], s[end])
+			s = s[end+1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		vt.put(r)
+	}
+	return len(p), nil
+}
+
+func (vt *virtualTerminal) control(params string, final byte) {
+	switch final {
+	case 'H':
+		vt.row, vt.col = 0, 0
+		if parts := strings.Split(params, ";"); len(parts) == 2 {
+			row, _ := strconv.Atoi(parts[0])
+			col, _ := strconv.Atoi(parts[1])
+			vt.row, vt.col = row-1, col-1
+		}
+	case 'J':
+		if params == "2" {
+			vt.clear()
+		}
+	case 'K':
+		for col := vt.col; col < vt.width; col++ {
+			vt.cells[vt.row][col] = vtCell{r: ' '}
+		}
+	case 'm':
+		for _, attr := range strings.Split(params, ";") {
+			switch attr {
+			case "", "0":
+				vt.reverse = false
+			case "7":
+				vt.reverse = true
+			}
+		}
+	case 'h', 'l':
+		switch params {
+		case "?25":
+			vt.cursorVisible = final == 'h'
+		case "?1049":
+			vt.altScreen = final == 'h'
+		}
+	}
+}
+
+func (vt *virtualTerminal) put(r rune) {
+	w := runeWidth(r)
+	if w == 0 || vt.row < 0 || vt.row >= vt.height || vt.col+w > vt.width {
+		return
+	}
+	vt.cells[vt.row][vt.col] = vtCell{r: r, reverse: vt.reverse}
+	if w == 2 {
+		vt.cells[vt.row][vt.col+1] = vtCell{reverse: vt.reverse, trailing: true}
+	}
+	vt.col += w
+}
+
+// line returns the text of a screen row without trailing spaces
+func (vt *virtualTerminal) line(row int) string {
+	var b strings.Builder
+	for _, c := range vt.cells[row] {
+		if !c.trailing {
+			b.WriteRune(c.r)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// screen returns every row, one per line
+func (vt *virtualTerminal) screen() string {
+	lines := make([]string, vt.height)
+	for i := range lines {
+		lines[i] = vt.line(i)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reversed returns the reverse-video text of a row, which marks the selection
+func (vt *virtualTerminal) reversed(row int) string {
+	var b strings.Builder
+	for _, c := range vt.cells[row] {
+		if c.reverse && !c.trailing {
+			b.WriteRune(c.r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// selection returns the reverse-video text below the title row
+func (vt *virtualTerminal) selection() string {
+	for row := 1; row < vt.height; row++ {
+		if text := vt.reversed(row); text != "" {
+			return text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// runeWidth returns the number of terminal cells r occupies.
+// Hangul and other East Asian wide characters take two cells.
+func runeWidth(r rune) int {
+	if r < 0x20 || unicode.Is(unicode.Mn, r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// TextWidth returns the number of terminal cells s occupies
+func TextWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// fit truncates or pads s with spaces to exactly w cells.
+// A wide character that would straddle the edge is replaced by a space;
+// truncated text ends with "…".
+func fit(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	if TextWidth(s) <= w {
+		return s + strings.Repeat(" ", w-TextWidth(s))
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if used+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	b.WriteString("…")
+	used++
+	b.WriteString(strings.Repeat(" ", w-used))
+	return b.String()
+}
+
+// wrap breaks s into lines of at most w cells, preferring to break at spaces.
+// Leading spaces of the original line are kept as indentation on continuation lines.
+func wrap(s string, w int) []string {
+	if w <= 0 {
+		return nil
+	}
+	if TextWidth(s) <= w {
+		return []string{s}
+	}
+
+	indent := s[:len(s)-len(strings.TrimLeft(s, " "))]
+	if TextWidth(indent) >= w/2 {
+		indent = ""
+	}
+
+	var lines []string
+	line, lineWidth := "", 0
+	for _, word := range strings.Fields(s) {
+		ww := TextWidth(word)
+		switch {
+		case lineWidth == 0:
+			line, lineWidth = indent+word, TextWidth(indent)+ww
+		case lineWidth+1+ww <= w:
+			line += " " + word
+			lineWidth += 1 + ww
+		default:
+			lines = append(lines, line)
+			line, lineWidth = indent+word, TextWidth(indent)+ww
+		}
+		// Words longer than the pane (long patterns, URLs) are split by cells
+		for lineWidth > w {
+			head, rest := splitAt(line, w)
+			lines = append(lines, head)
+			line, lineWidth = indent+rest, TextWidth(indent)+TextWidth(rest)
+			if TextWidth(rest) == 0 {
+				line, lineWidth = "", 0
+			}
+		}
+	}
+	if lineWidth > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitAt splits s after at most w cells
+func splitAt(s string, w int) (string, string) {
+	used := 0
+	for i, r := range s {
+		rw := runeWidth(r)
+		if used+rw > w {
+			return s[:i], s[i:]
+		}
+		used += rw
+	}
+	return s, ""
+}
@@ -0,0 +1,351 @@
+package vimhelp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/width"
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/cheatsheet"
+)
+
+// HelpFileName is the name of the generated help file; its tags start with "vi-assistant-"
+const HelpFileName = "vi-assistant.txt"
+
+const (
+	tagPrefix = "vi-assistant-"
+	textWidth = 78 // Vim help files are formatted for 'textwidth' 78
+	indent    = "\t\t"
+	modeline  = " vim:tw=78:ts=8:noet:ft=help:norl:"
+)
+
+// Favorite is a favorite command listed in the generated help file
+type Favorite struct {
+	Command     string
+	Description string
+	Tags        []string
+	Note        string
+}
+
+// HelpOptions controls WriteHelpFile
+type HelpOptions struct {
+	Lang      string
+	Packs     []catalog.Pack // packs the commands were loaded from
+	Favorites []Favorite
+	Date      time.Time // shown as "Last change"; left out when zero
+}
+
+// helpLabels holds the localized words of the help file
+var helpLabels = map[string][2]string{
+	"title":     {"vi 명령어 카탈로그", "vi command catalog"},
+	"intro":     {"vi-assistant가 만든 명령어 카탈로그입니다. 팀의 명령어 팩과 즐겨찾기를 포함합니다.", "Command catalog generated by vi-assistant, including the team's command packs and favorites."},
+	"install":   {"~/.vim/doc 같은 doc 디렉토리에 넣고 :helptags로 태그를 만드세요.", "Put this file in a doc directory such as ~/.vim/doc and run :helptags there."},
+	"contents":  {"목차", "CONTENTS"},
+	"favorites": {"즐겨찾기", "Favorites"},
+	"packs":     {"명령어 팩", "Command packs"},
+	"example":   {"예제", "Example"},
+	"modes":     {"모드", "Modes"},
+	"available": {"지원", "Available"},
+	"source":    {"출처", "Source"},
+	"aliases":   {"별칭", "Aliases"},
+	"vimhelp":   {"Vim 도움말", "Vim help"},
+	"tags":      {"태그", "Tags"},
+	"note":      {"메모", "Note"},
+	"commands":  {"명령어 %d개", "%d command(s)"},
+}
+
+func label(key, lang string) string {
+	if lang == "en" {
+		return helpLabels[key][1]
+	}
+	return helpLabels[key][0]
+}
+
+// HelpTag returns the help tag of a catalog command, e.g. "vi-assistant-dd".
+// Characters Vim does not allow in tags are spelled out as in Vim's own
+// tags (:bar, star), and spaces become underscores.
+func HelpTag(command string) string {
+	replacer := strings.NewReplacer(" ", "_", "\t", "_", "|", "bar", "*", "star")
+	return tagPrefix + replacer.Replace(command)
+}
+
+// helpSection is a numbered section of the help file
+type helpSection struct {
+	title string
+	tag   string
+	write func(*helpWriter)
+}
+
+// WriteHelpFile writes the catalog as a Vim help file: a contents list,
+// one section per category with a tag for every command and its aliases,
+// links to related commands and Vim's own help, then favorites and packs.
+func WriteHelpFile(w io.Writer, commands []catalog.Command, opts HelpOptions) error {
+	lang := opts.Lang
+	hw := &helpWriter{w: bufio.NewWriter(w), tags: make(map[string]bool), lang: lang}
+
+	byCommand := make(map[string]catalog.Command)
+	entries := make([]cheatsheet.Entry, 0, len(commands))
+	for _, cmd := range commands {
+		if _, exists := byCommand[cmd.Command]; exists {
+			continue
+		}
+		byCommand[cmd.Command] = cmd
+		entries = append(entries, cheatsheet.Entry{Command: cmd.Command, Category: cmd.Category})
+	}
+	hw.linked = byCommand
+	graph := catalog.NewGraph(commands)
+
+	// Categories come in the cheat sheet order, with plain-text titles
+	var sections []helpSection
+	for _, section := range cheatsheet.Group("", entries, lang).Sections {
+		category := section.Entries[0].Category
+		var group []catalog.Command
+		for _, entry := range section.Entries {
+			group = append(group, byCommand[entry.Command])
+		}
+		sections = append(sections, helpSection{
+			title: strings.TrimLeftFunc(section.Title, func(r rune) bool { return !unicode.IsLetter(r) }),
+			tag:   tagPrefix + "category-" + category,
+			write: func(hw *helpWriter) {
+				for _, cmd := range group {
+					hw.command(cmd, graph)
+				}
+			},
+		})
+	}
+	if len(opts.Favorites) > 0 {
+		sections = append(sections, helpSection{
+			title: label("favorites", lang),
+			tag:   tagPrefix + "favorites",
+			write: func(hw *helpWriter) { hw.favorites(opts.Favorites) },
+		})
+	}
+	if len(opts.Packs) > 0 {
+		sections = append(sections, helpSection{
+			title: label("packs", lang),
+			tag:   tagPrefix + "packs",
+			write: func(hw *helpWriter) { hw.packs(opts.Packs, commands) },
+		})
+	}
+
+	// Header: the file tag, title and date, as on the first line of Vim's help files
+	header := "*" + HelpFileName + "*\t" + label("title", lang)
+	if !opts.Date.IsZero() {
+		header += "\t\tLast change: " + opts.Date.Format("2006 Jan 02")
+	}
+	hw.line(header)
+	hw.line("")
+	hw.paragraph(label("intro", lang))
+	hw.paragraph(label("install", lang))
+	hw.line("")
+
+	hw.rule()
+	hw.heading(label("contents", lang), tagPrefix+"contents")
+	hw.line("")
+	for i, section := range sections {
+		name := fmt.Sprintf("%d. %s ", i+1, section.title)
+		link := " |" + section.tag + "|"
+		dots := textWidth - textWidthOf(name) - textWidthOf(link)
+		hw.line(name + strings.Repeat(".", max(dots, 1)) + link)
+	}
+	hw.line("")
+
+	for i, section := range sections {
+		hw.rule()
+		hw.heading(fmt.Sprintf("%d. %s", i+1, section.title), section.tag)
+		hw.line("")
+		section.write(hw)
+	}
+
+	hw.rule()
+	hw.line(modeline)
+	return hw.w.Flush()
+}
+
+// helpWriter writes help file lines and remembers the tags defined so far
+type helpWriter struct {
+	w      *bufio.Writer
+	lang   string
+	tags   map[string]bool
+	linked map[string]catalog.Command // commands that have a tag to link to
+}
+
+func (hw *helpWriter) line(s string) {
+	hw.w.WriteString(strings.TrimRight(s, " ") + "\n")
+}
+
+func (hw *helpWriter) rule() {
+	hw.line(strings.Repeat("=", textWidth))
+}
+
+// heading writes text with tags aligned to the right margin.
+// Tags that do not fit next to the text get a line of their own above it.
+func (hw *helpWriter) heading(text string, tags ...string) {
+	var defined []string
+	for _, tag := range tags {
+		if !hw.tags[tag] {
+			hw.tags[tag] = true
+			defined = append(defined, "*"+tag+"*")
+		}
+	}
+	right := strings.Join(defined, " ")
+	if right == "" {
+		hw.line(text)
+		return
+	}
+	gap := textWidth - textWidthOf(text) - textWidthOf(right)
+	if gap < 2 {
+		hw.line(strings.Repeat(" ", max(textWidth-textWidthOf(right), 0)) + right)
+		hw.line(text)
+		return
+	}
+	hw.line(text + strings.Repeat(" ", gap) + right)
+}
+
+// paragraph writes wrapped text at the left margin
+func (hw *helpWriter) paragraph(s string) {
+	for _, line := range wrapText(s, textWidth) {
+		hw.line(line)
+	}
+}
+
+// text writes wrapped text indented by two tabs; a non-empty name is written before it as "name: "
+func (hw *helpWriter) text(name, s string) {
+	if name != "" {
+		s = name + ": " + s
+	}
+	for _, line := range wrapText(s, textWidth-textWidthOf(indent)) {
+		hw.line(indent + line)
+	}
+}
+
+// link returns a reference to a catalog command, or the command itself when it has no tag
+func (hw *helpWriter) link(command string) string {
+	if _, ok := hw.linked[command]; ok {
+		return "|" + HelpTag(command) + "|"
+	}
+	return command
+}
+
+func (hw *helpWriter) command(cmd catalog.Command, graph *catalog.Graph) {
+	tags := []string{HelpTag(cmd.Command)}
+	for _, alias := range cmd.Aliases {
+		tags = append(tags, HelpTag(alias))
+	}
+	hw.heading(cmd.Command, tags...)
+
+	lang := hw.lang
+	hw.text("", cmd.Description)
+	if cmd.Example != "" {
+		hw.text(label("example", lang), cmd.Example)
+	}
+	if len(cmd.Modes) > 0 {
+		hw.text(label("modes", lang), strings.Join(cmd.Modes, ", "))
+	}
+	if cmd.Availability != nil {
+		hw.text(label("available", lang), catalog.FormatAvailability(cmd.Availability, lang))
+	}
+	if cmd.Source != "" && cmd.Source != catalog.BuiltinSource {
+		hw.text(label("source", lang), cmd.Source+" |"+tagPrefix+"packs|")
+	}
+	if len(cmd.Aliases) > 0 {
+		hw.text(label("aliases", lang), strings.Join(cmd.Aliases, ", "))
+	}
+	if cmd.HelpTag != "" {
+		hw.text(label("vimhelp", lang), "|"+cmd.HelpTag+"|")
+	}
+
+	// Related commands, grouped by relation kind; same-category neighbors are the section itself
+	var kinds []string
+	related := make(map[string][]string)
+	for _, n := range graph.Walk(cmd.Command, 1) {
+		if n.Kind == catalog.RelationCategory {
+			continue
+		}
+		if _, seen := related[n.Kind]; !seen {
+			kinds = append(kinds, n.Kind)
+		}
+		related[n.Kind] = append(related[n.Kind], hw.link(n.Command.Command))
+	}
+	for _, kind := range kinds {
+		hw.text(catalog.RelationTitle(kind, lang), strings.Join(related[kind], " "))
+	}
+	hw.line("")
+}
+
+func (hw *helpWriter) favorites(favorites []Favorite) {
+	lang := hw.lang
+	for _, fav := range favorites {
+		hw.line(hw.link(fav.Command))
+		if fav.Description != "" {
+			hw.text("", fav.Description)
+		}
+		if len(fav.Tags) > 0 {
+			hw.text(label("tags", lang), strings.Join(fav.Tags, ", "))
+		}
+		if fav.Note != "" {
+			hw.text(label("note", lang), fav.Note)
+		}
+		hw.line("")
+	}
+}
+
+func (hw *helpWriter) packs(packs []catalog.Pack, commands []catalog.Command) {
+	sorted := append([]catalog.Pack(nil), packs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, pack := range sorted {
+		hw.heading(pack.Name+" ("+fmt.Sprintf(label("commands", hw.lang), pack.Commands)+")", tagPrefix+"pack-"+pack.Name)
+		var links []string
+		for _, cmd := range commands {
+			if cmd.Source == pack.Name {
+				links = append(links, hw.link(cmd.Command))
+			}
+		}
+		if len(links) > 0 {
+			hw.text("", strings.Join(links, " "))
+		}
+		hw.line("")
+	}
+}
+
+// textWidthOf returns the number of screen cells s takes; Hangul takes two
+func textWidthOf(s string) int {
+	n := 0
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			n += 8 - n%8
+		case width.LookupRune(r).Kind() == width.EastAsianWide, width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// wrapText breaks s into lines of at most w cells at spaces
+func wrapText(s string, w int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = word
+		case textWidthOf(line)+1+textWidthOf(word) <= w:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
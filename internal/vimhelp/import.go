@@ -0,0 +1,316 @@
+package vimhelp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"vi-assistant/internal/catalog"
+)
+
+// DefaultPackName names the pack produced by Import
+const DefaultPackName = "vimhelp"
+
+// fallbackCategory is used for imported tags whose help file has no matching catalog category
+const fallbackCategory = "vimhelp"
+
+// fileCategories maps Vim help files to catalog categories
+var fileCategories = map[string]string{
+	"motion.txt":   "navigation",
+	"scroll.txt":   "navigation",
+	"pattern.txt":  "search",
+	"insert.txt":   "mode",
+	"visual.txt":   "mode",
+	"change.txt":   "edit",
+	"undo.txt":     "edit",
+	"editing.txt":  "file",
+	"helphelp.txt": "help",
+	"various.txt":  "help",
+}
+
+// textCategories refine a category from the first word of the explanation
+var textCategories = map[string]string{
+	"delete": "delete",
+	"yank":   "copy",
+	"put":    "paste",
+}
+
+// ImportOptions selects what Import produces
+type ImportOptions struct {
+	Name  string   // pack name, DefaultPackName when empty
+	Tags  []string // tags to import as entries even when the catalog has no such command
+	Files []string // help files whose command tags are all imported (e.g. change.txt)
+}
+
+// ImportResult is the generated pack and what went into it
+type ImportResult struct {
+	Document  *catalog.Document
+	Augmented int      // catalog commands that got their help text
+	Created   int      // new entries for requested tags
+	Missing   []string // requested tags that could not be imported
+}
+
+// Import builds a command pack from the help directory.
+//
+// Every catalog command with a matching help tag is copied with HelpTag and
+// HelpText filled in, so installing the pack (with the default pack
+// precedence) augments the built-in entries. Requested tags and the command
+// tags of requested files that are not in the catalog become new entries.
+func Import(ix *Index, commands []catalog.Command, opts ImportOptions) (*ImportResult, error) {
+	name := opts.Name
+	if name == "" {
+		name = DefaultPackName
+	}
+
+	result := &ImportResult{Document: &catalog.Document{SchemaVersion: catalog.SchemaVersion, Name: name}}
+	doc := result.Document
+
+	known := make(map[string]bool)
+	defined := make(map[string]bool) // definitions already used, by definitionKey
+
+	for _, cmd := range commands {
+		known[cmd.Command] = true
+
+		tag := ""
+		for _, candidate := range TagsFor(cmd.Command, cmd.Modes...) {
+			if ix.Has(candidate) {
+				tag = candidate
+				break
+			}
+		}
+		if tag == "" {
+			continue
+		}
+
+		def, err := ix.Define(tag)
+		if err != nil || def.Text == "" {
+			continue
+		}
+		cmd.HelpTag = tag
+		cmd.HelpText = def.Text
+		cmd.Source = ""
+		doc.Commands = append(doc.Commands, cmd)
+		defined[definitionKey(def)] = true
+		result.Augmented++
+	}
+
+	// Requested tags are imported even when they do not look like commands
+	requested := append([]string(nil), opts.Tags...)
+	explicit := make(map[string]bool)
+	for _, tag := range requested {
+		explicit[tag] = true
+	}
+
+	files := make(map[string]bool)
+	for _, file := range opts.Files {
+		files[filepath.Base(file)] = true
+	}
+	if len(files) > 0 {
+		for _, tag := range ix.Tags() {
+			if files[filepath.Base(tag.File)] && IsCommandTag(tag.Name) && !explicit[tag.Name] {
+				requested = append(requested, tag.Name)
+			}
+		}
+	}
+
+	categories := make(map[string]bool)
+	for _, tag := range requested {
+		def, err := ix.Define(tag)
+		if err != nil || def.Text == "" {
+			if explicit[tag] {
+				result.Missing = append(result.Missing, tag)
+			}
+			continue
+		}
+		// A definition with several tags (x, dl, <Del>) becomes a single entry
+		if defined[definitionKey(def)] {
+			continue
+		}
+
+		entry := newEntry(def)
+		if known[entry.Command] {
+			continue
+		}
+		known[entry.Command] = true
+		defined[definitionKey(def)] = true
+
+		doc.Commands = append(doc.Commands, entry)
+		categories[entry.Category] = true
+		result.Created++
+	}
+
+	if categories[fallbackCategory] {
+		doc.Categories = []string{fallbackCategory}
+	}
+	if len(doc.Commands) == 0 {
+		return result, fmt.Errorf("%s에서 가져올 명령어를 찾지 못했습니다", ix.Dir())
+	}
+	return result, nil
+}
+
+// newEntry creates a catalog entry from a help definition
+func newEntry(def *Definition) catalog.Command {
+	command, mode := CommandFor(def.Tag, def.Headings)
+	category := categoryFor(def)
+
+	return catalog.Command{
+		Keyword:     category,
+		Command:     command,
+		Description: def.Summary(),
+		Example:     fmt.Sprintf("'%s' (:help %s)", command, def.Tag),
+		Category:    category,
+		Modes:       []string{mode},
+		HelpTag:     def.Tag,
+		HelpText:    def.Text,
+	}
+}
+
+// definitionKey identifies a help paragraph; tags chained with "or" (h, <Left>,
+// CTRL-H) sit on different lines but share the explanation
+func definitionKey(def *Definition) string {
+	return def.File + "\x00" + def.Text
+}
+
+// categoryFor picks a catalog category from the explanation's first word or the help file
+func categoryFor(def *Definition) string {
+	if fields := strings.Fields(def.Text); len(fields) > 0 {
+		if category, ok := textCategories[strings.ToLower(fields[0])]; ok {
+			return category
+		}
+	}
+	if category, ok := fileCategories[filepath.Base(def.File)]; ok {
+		return category
+	}
+	return fallbackCategory
+}
+
+var (
+	errorTag   = regexp.MustCompile(`^E\d+$`)
+	ctrlTag    = regexp.MustCompile(`^CTRL-(.+)$`)
+	modePrefix = regexp.MustCompile(`^([icv])_`)
+)
+
+// IsCommandTag reports whether a tag names a command rather than a topic.
+// Topics are help file names, error numbers, 'options' and hyphenated
+// phrases such as "undo-redo".
+func IsCommandTag(tag string) bool {
+	switch {
+	case tag == "", strings.HasSuffix(tag, ".txt"), errorTag.MatchString(tag):
+		return false
+	case strings.HasPrefix(tag, "'"):
+		return false
+	}
+
+	name := modePrefix.ReplaceAllString(tag, "")
+	if strings.HasPrefix(name, ":") || strings.HasPrefix(name, "<") || strings.HasPrefix(name, "CTRL-") {
+		return true
+	}
+	// Words joined by hyphens are topics; short keys like "g-" are commands
+	return !(strings.Contains(name, "-") && len(name) > 3)
+}
+
+// TagsFor returns the help tags that may document a catalog command, best first.
+// "Ctrl+r" becomes CTRL-R, "Esc" becomes <Esc>, and placeholders are
+// dropped: "d{motion}" -> d, "/pattern" -> /, ":%s/old/new/g" -> :s.
+//
+// The command tags come before the literal name, which is often a topic
+// ("/pattern" describes patterns, not the search command) or a row in the
+// key notation table (<Esc> in intro.txt). A key used only outside Normal
+// mode prefers its Insert mode tag (i_<Esc>).
+func TagsFor(command string, modes ...string) []string {
+	var tags []string
+	add := func(tag string) {
+		if tag == "" {
+			return
+		}
+		for _, t := range tags {
+			if t == tag {
+				return
+			}
+		}
+		tags = append(tags, tag)
+	}
+
+	stem := command
+	for _, marker := range []string{"{", "pattern", "old", "new"} {
+		if i := strings.Index(stem, marker); i >= 0 {
+			stem = stem[:i]
+		}
+	}
+	placeholder := stem != command
+	stem = strings.TrimSpace(stem)
+	if strings.HasPrefix(stem, ":") {
+		// Ranges and trailing separators are not part of the tag (:%s/ -> :s)
+		stem = ":" + strings.TrimLeft(stem[1:], "%.,$0123456789")
+		stem = strings.TrimRight(stem, "/!")
+	}
+	if placeholder {
+		add(stem)
+	}
+
+	if strings.HasPrefix(command, "Ctrl+") {
+		add("CTRL-" + strings.ToUpper(strings.TrimPrefix(command, "Ctrl+")))
+	}
+	if len(command) > 1 && !strings.ContainsAny(command, "<>:/?{+ ") && unicode.IsUpper(rune(command[0])) && command != strings.ToUpper(command) {
+		if insertKey(modes) {
+			add("i_<" + command + ">")
+		}
+		add("<" + command + ">")
+		add("i_<" + command + ">")
+	}
+
+	add(command)
+	add(stem)
+	return tags
+}
+
+// insertKey reports whether modes include Insert mode but not Normal mode
+func insertKey(modes []string) bool {
+	insert, normal := false, false
+	for _, mode := range modes {
+		switch catalog.NormalizeMode(mode) {
+		case catalog.ModeInsert:
+			insert = true
+		case catalog.ModeNormal:
+			normal = true
+		}
+	}
+	return insert && !normal
+}
+
+// CommandFor converts a help tag into the catalog's command notation and mode.
+// The headings are used to restore placeholders, so tag "d" with heading
+// "d{motion}" becomes the command "d{motion}".
+func CommandFor(tag string, headings []string) (command, mode string) {
+	mode = catalog.ModeNormal
+	name := tag
+	if m := modePrefix.FindStringSubmatch(tag); m != nil {
+		name = strings.TrimPrefix(tag, m[0])
+		mode = map[string]string{"i": catalog.ModeInsert, "v": catalog.ModeVisual, "c": catalog.ModeCommandLine}[m[1]]
+	}
+	if strings.HasPrefix(name, ":") {
+		mode = catalog.ModeCommandLine
+	}
+
+	if m := ctrlTag.FindStringSubmatch(name); m != nil {
+		key := m[1]
+		if len(key) == 1 {
+			key = strings.ToLower(key)
+		}
+		return "Ctrl+" + key, mode
+	}
+	if name == "<Esc>" {
+		return "Esc", mode
+	}
+
+	for _, heading := range headings {
+		if strings.HasPrefix(heading, name+"{") {
+			if end := strings.Index(heading, "}"); end > 0 {
+				return heading[:end+1], mode
+			}
+		}
+	}
+	return name, mode
+}
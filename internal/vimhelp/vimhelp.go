@@ -0,0 +1,247 @@
+// Package vimhelp reads a local Vim help directory: the "tags" file and the
+// *.txt help files it points into (e.g. /usr/share/vim/vim91/doc).
+// It works entirely offline and is used to quote Vim's own wording in catalog entries.
+// WriteHelpFile goes the other way and turns the catalog into a help file of its own.
+package vimhelp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Tag is a line of a Vim help tags file
+type Tag struct {
+	Name    string // 태그 이름 (예: dd, :s, CTRL-R)
+	File    string // 태그가 정의된 도움말 파일 (tags 파일 기준 상대 경로)
+	Pattern string // 검색 패턴 (예: /*dd*)
+}
+
+// ParseTags reads a tags file: "name<Tab>file<Tab>pattern" per line.
+// Header lines starting with "!_TAG_" are skipped.
+func ParseTags(r io.Reader) ([]Tag, error) {
+	var tags []Tag
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "!_TAG_") {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("tags %d번째 줄 형식이 잘못되었습니다: %q", lineNo, line)
+		}
+		tags = append(tags, Tag{Name: fields[0], File: fields[1], Pattern: fields[2]})
+	}
+	return tags, scanner.Err()
+}
+
+// Index gives access to the tags and help files of one doc directory
+type Index struct {
+	dir   string
+	tags  map[string]Tag
+	order []Tag
+	files map[string][]string // help file -> lines, read on demand
+}
+
+// Open reads the tags file of a Vim doc directory.
+// A Vim runtime directory containing "doc" (e.g. /usr/share/vim/vim91) is accepted too.
+func Open(dir string) (*Index, error) {
+	tagsPath := filepath.Join(dir, "tags")
+	if _, err := os.Stat(tagsPath); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(dir, "doc", "tags")); err == nil {
+			dir = filepath.Join(dir, "doc")
+			tagsPath = filepath.Join(dir, "tags")
+		}
+	}
+
+	f, err := os.Open(tagsPath)
+	if err != nil {
+		return nil, fmt.Errorf("도움말 tags 파일을 열 수 없습니다: %v", err)
+	}
+	defer f.Close()
+
+	tags, err := ParseTags(f)
+	if err != nil {
+		return nil, err
+	}
+
+	ix := &Index{dir: dir, tags: make(map[string]Tag), files: make(map[string][]string)}
+	for _, tag := range tags {
+		if _, exists := ix.tags[tag.Name]; !exists {
+			ix.tags[tag.Name] = tag
+			ix.order = append(ix.order, tag)
+		}
+	}
+	return ix, nil
+}
+
+// Dir returns the doc directory the index reads from
+func (ix *Index) Dir() string {
+	return ix.dir
+}
+
+// Has reports whether the tags file defines a tag
+func (ix *Index) Has(name string) bool {
+	_, ok := ix.tags[name]
+	return ok
+}
+
+// Tags returns every tag in tags file order
+func (ix *Index) Tags() []Tag {
+	return append([]Tag(nil), ix.order...)
+}
+
+// Definition is the help paragraph a tag points at
+type Definition struct {
+	Tag      string   `json:"tag"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`     // 1-based line of the tag in File
+	Headings []string `json:"headings"` // command forms, e.g. ["x]dd without the register prefix
+	Text     string   `json:"text"`     // the explanation joined into one line
+}
+
+// Summary returns the first sentence of the definition
+func (d *Definition) Summary() string {
+	if i := strings.Index(d.Text, ". "); i >= 0 {
+		return d.Text[:i+1]
+	}
+	return d.Text
+}
+
+var (
+	tagDefinition  = regexp.MustCompile(`(^|\s)\*[^*\s|]+\*(\s|$)`)
+	helpLink       = regexp.MustCompile(`\|([^|\s]+)\|`)
+	registerPrefix = regexp.MustCompile(`^\["x\]`)
+	spaces         = regexp.MustCompile(`\s+`)
+)
+
+// maxParagraphLines bounds a definition so a missing blank line cannot swallow a whole section
+const maxParagraphLines = 20
+
+// Define extracts the paragraph that defines a tag.
+//
+// Help files mark a definition with *tag* either on a line of its own above
+// the entry or at the end of the entry's first line. The entry is a heading
+// (the command form) followed by a tab and the explanation, continued on
+// indented lines until a blank line or the next definition. Several headings
+// may be chained with "or", as in "h  or / <Left>  or / <BS>  explanation".
+func (ix *Index) Define(name string) (*Definition, error) {
+	tag, ok := ix.tags[name]
+	if !ok {
+		return nil, fmt.Errorf("도움말 태그가 없습니다: %s", name)
+	}
+
+	lines, err := ix.lines(tag.File)
+	if err != nil {
+		return nil, err
+	}
+
+	marker := "*" + name + "*"
+	start := -1
+	for i, line := range lines {
+		if containsTag(line, marker) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("%s에서 태그 %s의 정의를 찾을 수 없습니다", tag.File, name)
+	}
+
+	def := &Definition{Tag: name, File: tag.File, Line: start + 1}
+	var text []string
+
+	for i := start; i < len(lines) && i < start+maxParagraphLines; i++ {
+		line := lines[i]
+		if i > start && strings.TrimSpace(line) == "" {
+			break
+		}
+		if i > start && len(text) > 0 && tagDefinition.MatchString(line) {
+			break
+		}
+		if strings.HasPrefix(line, "===") || strings.HasPrefix(line, "---") {
+			break
+		}
+
+		content := strings.TrimRight(stripTags(line), " \t")
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		// Indented lines continue the explanation
+		if content[0] == '\t' || content[0] == ' ' {
+			text = append(text, strings.TrimSpace(content))
+			continue
+		}
+
+		heading, rest := content, ""
+		if tab := strings.Index(content, "\t"); tab >= 0 {
+			heading, rest = content[:tab], strings.TrimSpace(content[tab:])
+		}
+		def.Headings = append(def.Headings, registerPrefix.ReplaceAllString(strings.TrimSpace(heading), ""))
+		if rest != "" && rest != "or" {
+			text = append(text, rest)
+		}
+	}
+
+	def.Text = cleanText(strings.Join(text, " "))
+	return def, nil
+}
+
+// containsTag reports whether line defines the tag marker (surrounded by spaces or line ends)
+func containsTag(line, marker string) bool {
+	for offset := 0; ; {
+		i := strings.Index(line[offset:], marker)
+		if i < 0 {
+			return false
+		}
+		i += offset
+		end := i + len(marker)
+		if (i == 0 || isSpace(line[i-1])) && (end == len(line) || isSpace(line[end])) {
+			return true
+		}
+		offset = i + 1
+	}
+}
+
+// stripTags removes every *tag* definition from a line.
+// Adjacent tags share the separating space, so one pass only removes every other one.
+func stripTags(line string) string {
+	for tagDefinition.MatchString(line) {
+		line = tagDefinition.ReplaceAllString(line, "$1$2")
+	}
+	return line
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+// cleanText turns |links| into plain words and collapses whitespace
+func cleanText(s string) string {
+	s = helpLink.ReplaceAllString(s, "$1")
+	return strings.TrimSpace(spaces.ReplaceAllString(s, " "))
+}
+
+// lines returns the lines of a help file, reading it once
+func (ix *Index) lines(file string) ([]string, error) {
+	if lines, ok := ix.files[file]; ok {
+		return lines, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(ix.dir, file))
+	if err != nil {
+		return nil, fmt.Errorf("도움말 파일을 읽을 수 없습니다: %v", err)
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	ix.files[file] = lines
+	return lines, nil
+}
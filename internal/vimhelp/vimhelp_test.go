@@ -0,0 +1,320 @@
+package vimhelp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+)
+
+var docDir = filepath.Join("testdata", "doc")
+
+func openFixture(t *testing.T) *Index {
+	t.Helper()
+	ix, err := Open(docDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ix
+}
+
+func TestParseTags(t *testing.T) {
+	tags, err := ParseTags(strings.NewReader("!_TAG_FILE_ENCODING\tutf-8\t//\ndd\tchange.txt\t/*dd*\n:s\tchange.txt\t/*:s*\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0] != (Tag{"dd", "change.txt", "/*dd*"}) || tags[1].Name != ":s" {
+		t.Errorf("ParseTags = %+v", tags)
+	}
+
+	if _, err := ParseTags(strings.NewReader("broken line\n")); err == nil {
+		t.Error("malformed tags line accepted")
+	}
+}
+
+func TestOpenRuntimeDir(t *testing.T) {
+	// A runtime directory holding doc/tags is accepted as well
+	ix, err := Open("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ix.Dir() != docDir || !ix.Has("dd") {
+		t.Errorf("Open(testdata) dir = %s", ix.Dir())
+	}
+}
+
+func TestDefine(t *testing.T) {
+	ix := openFixture(t)
+
+	tests := []struct {
+		tag      string
+		line     int
+		headings []string
+		text     string
+	}{
+		// Tag on a line of its own above the entry
+		{"dd", 23, []string{"dd"}, "Delete [count] lines [into register x] linewise."},
+		// Tag at the end of the entry's first line
+		{"CTRL-R", 16, []string{"CTRL-R"}, "Redo [count] changes which were undone."},
+		// Several tags on one line and headings chained with "or"
+		{"x", 10, []string{"<Del>", "x"}, "Delete [count] characters under and after the cursor [into register x] (not linewise). Does the same as \"dl\"."},
+		{"<Left>", 11, []string{"<Left>", "CTRL-H", "<BS>"}, "[count] characters to the left. exclusive motion. See the 'whichwrap' option for adjusting the behavior at start of line."},
+		// Heading without explanation on the same line
+		{":s", 56, []string{":[range]s[ubstitute]/{pattern}/{string}/[flags] [count]"}, "For each line in [range] replace a match of {pattern} with {string}."},
+	}
+
+	for _, tt := range tests {
+		def, err := ix.Define(tt.tag)
+		if err != nil {
+			t.Errorf("%s: %v", tt.tag, err)
+			continue
+		}
+		if def.Line != tt.line || def.Text != tt.text || strings.Join(def.Headings, "|") != strings.Join(tt.headings, "|") {
+			t.Errorf("Define(%s) = line %d %q %q\nwant line %d %q %q", tt.tag, def.Line, def.Headings, def.Text, tt.line, tt.headings, tt.text)
+		}
+	}
+
+	if _, err := ix.Define("no-such-tag"); err == nil {
+		t.Error("unknown tag defined")
+	}
+}
+
+func TestTagsFor(t *testing.T) {
+	tests := map[string]string{
+		"dd":              "dd",
+		"Ctrl+r":          "CTRL-R",
+		"Esc":             "<Esc>",
+		"d{motion}":       "d",
+		"/pattern":        "/",
+		":%s/old/new/g":   ":s",
+		":q!":             ":q",
+		":help {subject}": ":help",
+	}
+	for command, want := range tests {
+		found := false
+		for _, tag := range TagsFor(command) {
+			if tag == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("TagsFor(%q) = %q, missing %q", command, TagsFor(command), want)
+		}
+	}
+
+	// The command's own tag comes before topics and notation rows
+	first := []struct {
+		command string
+		modes   []string
+		want    string
+	}{
+		{"/pattern", nil, "/"},
+		{"?pattern", nil, "?"},
+		{"d{motion}", nil, "d"},
+		{":q!", nil, ":q!"},
+		{"Esc", nil, "<Esc>"},
+		{"Esc", []string{"insert", "visual"}, "i_<Esc>"},
+		{"Esc", []string{"normal", "insert"}, "<Esc>"},
+	}
+	for _, tt := range first {
+		if got := TagsFor(tt.command, tt.modes...); got[0] != tt.want {
+			t.Errorf("TagsFor(%q, %v) = %q, want %q first", tt.command, tt.modes, got, tt.want)
+		}
+	}
+}
+
+// TestImportPicksCommandParagraphs uses excerpts of pattern.txt, intro.txt and
+// insert.txt, where the literal names are topics or key notation rows
+func TestImportPicksCommandParagraphs(t *testing.T) {
+	ix := openFixture(t)
+	commands := []catalog.Command{
+		{Keyword: "search", Command: "/pattern", Description: "앞으로 검색", Example: "'/hello'", Category: "search", Modes: []string{"normal", "visual"}},
+		{Keyword: "search", Command: "?pattern", Description: "뒤로 검색", Example: "'?hello'", Category: "search", Modes: []string{"normal", "visual"}},
+		{Keyword: "escape", Command: "Esc", Description: "명령 모드로", Example: "'Esc'", Category: "mode", Modes: []string{"insert", "visual", "command-line"}},
+	}
+	result, err := Import(ix, commands, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct{ tag, text string }{
+		"/pattern": {"/", "Search forward for the [count]'th occurrence of"},
+		"?pattern": {"?", "Search backward for the [count]'th previous"},
+		"Esc":      {"i_<Esc>", "End insert or Replace mode, go back to Normal mode."},
+	}
+	if len(result.Document.Commands) != len(want) {
+		t.Fatalf("imported %d commands, want %d", len(result.Document.Commands), len(want))
+	}
+	for _, cmd := range result.Document.Commands {
+		w := want[cmd.Command]
+		if cmd.HelpTag != w.tag || !strings.HasPrefix(cmd.HelpText, w.text) {
+			t.Errorf("%s: tag %q, text %q\nwant tag %q, text starting %q", cmd.Command, cmd.HelpTag, cmd.HelpText, w.tag, w.text)
+		}
+	}
+}
+
+func TestCommandFor(t *testing.T) {
+	tests := []struct {
+		tag      string
+		headings []string
+		command  string
+		mode     string
+	}{
+		{"CTRL-R", nil, "Ctrl+r", catalog.ModeNormal},
+		{"i_CTRL-W", nil, "Ctrl+w", catalog.ModeInsert},
+		{"d", []string{"d{motion}"}, "d{motion}", catalog.ModeNormal},
+		{":undo", nil, ":undo", catalog.ModeCommandLine},
+		{"i_<Esc>", nil, "Esc", catalog.ModeInsert},
+	}
+	for _, tt := range tests {
+		command, mode := CommandFor(tt.tag, tt.headings)
+		if command != tt.command || mode != tt.mode {
+			t.Errorf("CommandFor(%q) = %q %q, want %q %q", tt.tag, command, mode, tt.command, tt.mode)
+		}
+	}
+}
+
+func TestIsCommandTag(t *testing.T) {
+	for _, tag := range []string{"dd", ":s", "CTRL-R", "<Del>", "i_CTRL-W", "g-"} {
+		if !IsCommandTag(tag) {
+			t.Errorf("IsCommandTag(%q) = false", tag)
+		}
+	}
+	for _, tag := range []string{"change.txt", "E470", "undo-redo", "'tabstop'"} {
+		if IsCommandTag(tag) {
+			t.Errorf("IsCommandTag(%q) = true", tag)
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	ix := openFixture(t)
+	commands := []catalog.Command{
+		{Keyword: "delete", Command: "dd", Description: "줄 삭제", Example: "'dd'", Category: "delete", Source: catalog.BuiltinSource},
+		{Keyword: "edit", Command: "Ctrl+r", Description: "다시 실행", Example: "'Ctrl+r'", Category: "edit"},
+		{Keyword: "file", Command: ":w", Description: "저장", Example: "':w'", Category: "file"},
+	}
+
+	result, err := Import(ix, commands, ImportOptions{Tags: []string{"gg", "nope"}, Files: []string{"undo.txt"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Augmented != 2 || len(result.Missing) != 1 || result.Missing[0] != "nope" {
+		t.Errorf("augmented %d, missing %v", result.Augmented, result.Missing)
+	}
+
+	byCommand := make(map[string]catalog.Command)
+	for _, cmd := range result.Document.Commands {
+		byCommand[cmd.Command] = cmd
+	}
+
+	dd := byCommand["dd"]
+	if dd.HelpTag != "dd" || dd.Description != "줄 삭제" || dd.Source != "" {
+		t.Errorf("augmented dd = %+v", dd)
+	}
+	if byCommand["Ctrl+r"].HelpTag != "CTRL-R" {
+		t.Errorf("Ctrl+r help tag = %q", byCommand["Ctrl+r"].HelpTag)
+	}
+	if _, ok := byCommand[":w"]; ok {
+		t.Error(":w has no help tag in the fixture but was exported")
+	}
+
+	gg := byCommand["gg"]
+	if gg.Category != "navigation" || gg.HelpTag != "gg" || !strings.HasPrefix(gg.Description, "Goto line") {
+		t.Errorf("created gg = %+v", gg)
+	}
+
+	// undo.txt: u and CTRL-R are already covered, :undo/:un/:u share one paragraph
+	if _, ok := byCommand[":u"]; !ok {
+		t.Error(":u from undo.txt not created")
+	}
+	if _, ok := byCommand[":undo"]; ok {
+		t.Error(":undo duplicates the :u paragraph")
+	}
+	if _, ok := byCommand[":red"]; !ok {
+		t.Error(":red from undo.txt not created")
+	}
+
+	// The generated pack passes the catalog linter
+	issues := catalog.LintWith("vimhelp.json", mustJSON(t, result.Document), commands)
+	for _, issue := range issues {
+		t.Errorf("lint: %s", issue)
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestWriteHelpFile(t *testing.T) {
+	commands := []catalog.Command{
+		{Command: "dd", Description: "줄 삭제", Category: "delete", Source: catalog.BuiltinSource, HelpTag: "dd",
+			Relations: map[string][]string{catalog.RelationGeneralization: {"d{motion}"}}},
+		{Command: "d{motion}", Description: "범위 삭제", Category: "delete"},
+		{Command: ":q", Description: "종료", Category: "file", Aliases: []string{":quit"}, SeeAlso: []string{":wq", "ZZ"}},
+		{Command: ":wq", Description: "저장 후 종료", Category: "file"},
+		{Command: ":help {subject}", Description: "도움말", Category: "help"},
+		{Command: ":Git", Description: "git 실행", Category: "git", Source: "fugitive"},
+	}
+	opts := HelpOptions{
+		Lang:      "en",
+		Packs:     []catalog.Pack{{Name: "fugitive", Commands: 1}},
+		Favorites: []Favorite{{Command: "dd", Description: "줄 삭제", Tags: []string{"edit"}, Note: "often"}},
+	}
+
+	var buf strings.Builder
+	if err := WriteHelpFile(&buf, commands, opts); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	if !strings.HasPrefix(lines[0], "*"+HelpFileName+"*\t") {
+		t.Errorf("first line = %q", lines[0])
+	}
+	if !strings.HasSuffix(text, modeline+"\n") {
+		t.Error("missing modeline")
+	}
+
+	defined := make(map[string]int)
+	for _, m := range regexp.MustCompile(`\*(vi-assistant-[^*\s]+)\*`).FindAllStringSubmatch(text, -1) {
+		defined[m[1]]++
+	}
+	for _, tag := range []string{"vi-assistant-dd", "vi-assistant-:q", "vi-assistant-:quit", "vi-assistant-:help_{subject}",
+		"vi-assistant-category-delete", "vi-assistant-favorites", "vi-assistant-packs", "vi-assistant-pack-fugitive"} {
+		if defined[tag] != 1 {
+			t.Errorf("tag %s defined %d times", tag, defined[tag])
+		}
+	}
+
+	// Every link into the file has a tag; other links point into Vim's help
+	for _, m := range regexp.MustCompile(`\|([^|\s]+)\|`).FindAllStringSubmatch(text, -1) {
+		if strings.HasPrefix(m[1], "vi-assistant-") && defined[m[1]] == 0 {
+			t.Errorf("link to undefined tag %s", m[1])
+		}
+	}
+	for _, want := range []string{"Vim help: |dd|", "General form: |vi-assistant-d{motion}|", "Source: fugitive |vi-assistant-packs|", "Note: often"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("help file lacks %q", want)
+		}
+	}
+	// ZZ is not in the catalog, so it is mentioned without a link
+	if strings.Contains(text, "|vi-assistant-ZZ|") {
+		t.Error("linked a command outside the catalog")
+	}
+
+	for i, line := range lines {
+		if textWidthOf(line) > textWidth {
+			t.Errorf("line %d is %d cells wide: %q", i+1, textWidthOf(line), line)
+		}
+	}
+}
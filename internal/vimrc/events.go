@@ -0,0 +1,61 @@
+package vimrc
+
+import "strings"
+
+// events describes the autocommand events most often seen in vimrc files
+var events = map[string][2]string{
+	"bufnewfile":      {"새 파일을 편집하기 시작할 때", "starting to edit a new file"},
+	"bufread":         {"파일을 읽은 뒤", "after reading a file"},
+	"bufreadpost":     {"파일을 읽은 뒤", "after reading a file"},
+	"bufreadpre":      {"파일을 읽기 전", "before reading a file"},
+	"bufwrite":        {"파일을 저장하기 직전", "before writing a file"},
+	"bufwritepre":     {"파일을 저장하기 직전", "before writing a file"},
+	"bufwritepost":    {"파일을 저장한 뒤", "after writing a file"},
+	"bufenter":        {"버퍼에 들어갈 때", "entering a buffer"},
+	"bufleave":        {"버퍼를 떠날 때", "leaving a buffer"},
+	"bufwinenter":     {"버퍼가 창에 표시될 때", "a buffer is displayed in a window"},
+	"bufdelete":       {"버퍼를 목록에서 지울 때", "deleting a buffer from the list"},
+	"filetype":        {"파일 종류가 정해질 때", "the filetype is set"},
+	"syntax":          {"syntax 옵션이 정해질 때", "the syntax option is set"},
+	"vimenter":        {"Vim 시작이 끝난 뒤", "after Vim has started"},
+	"vimleave":        {"Vim을 끝내기 직전", "before exiting Vim"},
+	"vimleavepre":     {"Vim을 끝내기 직전 (viminfo 저장 전)", "before exiting Vim, before writing viminfo"},
+	"vimresized":      {"Vim 창 크기가 바뀐 뒤", "after the Vim window was resized"},
+	"insertenter":     {"삽입 모드에 들어갈 때", "entering Insert mode"},
+	"insertleave":     {"삽입 모드를 떠날 때", "leaving Insert mode"},
+	"cursorhold":      {"updatetime 동안 입력이 없을 때", "no key was pressed for updatetime"},
+	"cursorholdi":     {"삽입 모드에서 updatetime 동안 입력이 없을 때", "no key was pressed for updatetime in Insert mode"},
+	"cursormoved":     {"노멀 모드에서 커서가 움직인 뒤", "after the cursor moved in Normal mode"},
+	"cursormovedi":    {"삽입 모드에서 커서가 움직인 뒤", "after the cursor moved in Insert mode"},
+	"textchanged":     {"노멀 모드에서 내용이 바뀐 뒤", "after a change in Normal mode"},
+	"textchangedi":    {"삽입 모드에서 내용이 바뀐 뒤", "after a change in Insert mode"},
+	"winenter":        {"다른 창에 들어갈 때", "entering a window"},
+	"winleave":        {"창을 떠날 때", "leaving a window"},
+	"colorscheme":     {"색 구성을 불러온 뒤", "after loading a color scheme"},
+	"focusgained":     {"Vim 창이 포커스를 얻을 때", "Vim got input focus"},
+	"focuslost":       {"Vim 창이 포커스를 잃을 때", "Vim lost input focus"},
+	"termopen":        {"터미널 버퍼를 열 때 (Neovim)", "a terminal buffer is opened (Neovim)"},
+	"quickfixcmdpost": {"quickfix 명령(:make, :grep 등)을 실행한 뒤", "after a quickfix command such as :make or :grep"},
+	"filereadpost":    {"파일을 :read로 읽은 뒤", "after reading a file with :read"},
+	"bufnew":          {"새 버퍼를 만들 때", "creating a new buffer"},
+	"user":            {"플러그인이 보내는 사용자 이벤트", "a User event sent by a plugin"},
+}
+
+// lookupEvent returns the description of an autocommand event (case-insensitive)
+func lookupEvent(name string) ([2]string, bool) {
+	desc, ok := events[strings.ToLower(name)]
+	return desc, ok
+}
+
+// isEventList reports whether s is a comma separated list of known events or "*"
+func isEventList(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for _, name := range strings.Split(s, ",") {
+		if _, ok := lookupEvent(name); !ok {
+			return false
+		}
+	}
+	return true
+}
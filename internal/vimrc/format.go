@@ -0,0 +1,76 @@
+package vimrc
+
+import (
+	"fmt"
+	"strings"
+
+	"vi-assistant/internal/style"
+)
+
+// kindOrder is the order of the summary line
+var kindOrder = []string{KindSet, KindMap, KindUnmap, KindAutocmd, KindAugroup, KindLet, KindCommand, KindOther}
+
+// kindTitles are the localized names of line kinds
+var kindTitles = map[string][2]string{
+	KindSet:     {"옵션", "options"},
+	KindMap:     {"매핑", "mappings"},
+	KindUnmap:   {"매핑 제거", "unmaps"},
+	KindAutocmd: {"자동 명령", "autocommands"},
+	KindAugroup: {"자동 명령 그룹", "augroups"},
+	KindLet:     {"변수", "variables"},
+	KindCommand: {"사용자 명령", "user commands"},
+	KindOther:   {"기타", "other"},
+}
+
+// FormatReport renders the analysis line by line with a summary at the end
+func FormatReport(report *Report, lang string) string {
+	var output strings.Builder
+	en := lang == "en"
+
+	leader := report.Leader
+	if leader == " " {
+		leader = "<Space>"
+	}
+	if en {
+		output.WriteString(style.Heading(fmt.Sprintf("Analysis of %s (leader key: %s)", report.Path, leader)) + "\n")
+	} else {
+		output.WriteString(style.Heading(fmt.Sprintf("%s 분석 (리더 키: %s)", report.Path, leader)) + "\n")
+	}
+
+	if len(report.Findings) == 0 {
+		if en {
+			output.WriteString("\nNo commands to explain.\n")
+		} else {
+			output.WriteString("\n설명할 명령이 없습니다.\n")
+		}
+		return output.String()
+	}
+
+	for _, f := range report.Findings {
+		output.WriteString(fmt.Sprintf("\n%4d  %s\n", f.Line, style.Command(f.Text)))
+		for _, line := range f.Explanation {
+			output.WriteString(fmt.Sprintf("      %s\n", line))
+		}
+		for _, warning := range f.Warnings {
+			output.WriteString(fmt.Sprintf("      ⚠ %s\n", warning))
+		}
+	}
+
+	var counts []string
+	for _, kind := range kindOrder {
+		if n := report.Counts[kind]; n > 0 {
+			title := kindTitles[kind]
+			if en {
+				counts = append(counts, fmt.Sprintf("%d %s", n, title[1]))
+			} else {
+				counts = append(counts, fmt.Sprintf("%s %d개", title[0], n))
+			}
+		}
+	}
+	if en {
+		output.WriteString(fmt.Sprintf("\nSummary: %s, %d warning(s)\n", strings.Join(counts, ", "), report.Warnings))
+	} else {
+		output.WriteString(fmt.Sprintf("\n요약: %s, 경고 %d개\n", strings.Join(counts, ", "), report.Warnings))
+	}
+	return output.String()
+}
@@ -0,0 +1,705 @@
+// Package vimrc explains the lines of a vimrc: ":set" options, the ":map"
+// family, autocommands, ":let" and user commands. Options are explained with
+// the options database and mappings are checked against the command catalog,
+// so a mapping that hides a built-in command is flagged.
+package vimrc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/options"
+)
+
+// Line kinds
+const (
+	KindSet     = "set"
+	KindMap     = "map"
+	KindUnmap   = "unmap"
+	KindAutocmd = "autocmd"
+	KindAugroup = "augroup"
+	KindLet     = "let"
+	KindCommand = "command"
+	KindOther   = "other"
+)
+
+// Finding is the explanation of one (possibly continued) vimrc line
+type Finding struct {
+	Line        int      `json:"line"` // 1-based line where the command starts
+	Text        string   `json:"text"`
+	Kind        string   `json:"kind"`
+	Explanation []string `json:"explanation"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// Report is the analysis of a whole vimrc
+type Report struct {
+	Path     string         `json:"path"`
+	Leader   string         `json:"leader"` // mapleader at the end of the file
+	Findings []Finding      `json:"findings"`
+	Counts   map[string]int `json:"counts"` // findings per kind
+	Warnings int            `json:"warnings"`
+}
+
+// DefaultLeader is Vim's mapleader when none is set
+const DefaultLeader = `\`
+
+// Analyzer explains vimrc lines using the catalog and the options database
+type Analyzer struct {
+	Commands []catalog.Command
+	Options  *options.DB
+	Lang     string
+
+	leader      string
+	localLeader string
+}
+
+// commandSpec describes an Ex command name with its shortest abbreviation,
+// e.g. {"nnoremap", 2} accepts "nn", "nno", ... "nnoremap"
+type commandSpec struct {
+	name    string
+	minLen  int
+	kind    string
+	modes   []string // modes for mapping commands
+	noremap bool
+}
+
+// Map mode names used in explanations; operator-pending, select and
+// terminal have no catalog equivalent but are reported as written
+const (
+	mapOperator = "operator-pending"
+	mapSelect   = "select"
+	mapTerminal = "terminal"
+	mapLangArg  = "lang-arg"
+)
+
+var (
+	nvo    = []string{catalog.ModeNormal, catalog.ModeVisual, mapSelect, mapOperator}
+	normal = []string{catalog.ModeNormal}
+	vis    = []string{catalog.ModeVisual, mapSelect}
+	xvis   = []string{catalog.ModeVisual}
+	sel    = []string{mapSelect}
+	op     = []string{mapOperator}
+	ins    = []string{catalog.ModeInsert}
+	cmdl   = []string{catalog.ModeCommandLine}
+	term   = []string{mapTerminal}
+	lang   = []string{mapLangArg}
+)
+
+var commandSpecs = []commandSpec{
+	{"set", 2, KindSet, nil, false},
+	{"setlocal", 4, KindSet, nil, false},
+	{"setglobal", 4, KindSet, nil, false},
+	{"let", 3, KindLet, nil, false},
+	{"autocmd", 2, KindAutocmd, nil, false},
+	{"augroup", 3, KindAugroup, nil, false},
+	{"command", 3, KindCommand, nil, false},
+
+	{"map", 3, KindMap, nvo, false},
+	{"nmap", 2, KindMap, normal, false},
+	{"vmap", 2, KindMap, vis, false},
+	{"xmap", 2, KindMap, xvis, false},
+	{"smap", 4, KindMap, sel, false},
+	{"omap", 2, KindMap, op, false},
+	{"imap", 2, KindMap, ins, false},
+	{"cmap", 2, KindMap, cmdl, false},
+	{"tmap", 3, KindMap, term, false},
+	{"lmap", 2, KindMap, lang, false},
+	{"noremap", 2, KindMap, nvo, true},
+	{"nnoremap", 2, KindMap, normal, true},
+	{"vnoremap", 2, KindMap, vis, true},
+	{"xnoremap", 2, KindMap, xvis, true},
+	{"snoremap", 4, KindMap, sel, true},
+	{"onoremap", 3, KindMap, op, true},
+	{"inoremap", 3, KindMap, ins, true},
+	{"cnoremap", 3, KindMap, cmdl, true},
+	{"tnoremap", 3, KindMap, term, true},
+	{"lnoremap", 2, KindMap, lang, true},
+
+	{"unmap", 3, KindUnmap, nvo, false},
+	{"nunmap", 3, KindUnmap, normal, false},
+	{"vunmap", 2, KindUnmap, vis, false},
+	{"xunmap", 2, KindUnmap, xvis, false},
+	{"ounmap", 2, KindUnmap, op, false},
+	{"iunmap", 2, KindUnmap, ins, false},
+	{"cunmap", 2, KindUnmap, cmdl, false},
+}
+
+// lookupCommand finds the spec for a command word such as "nno" or "map!".
+// "map!" and "noremap!" apply to Insert and Command-line mode.
+func lookupCommand(word string) (commandSpec, bool) {
+	bang := strings.HasSuffix(word, "!")
+	word = strings.TrimSuffix(word, "!")
+
+	for _, spec := range commandSpecs {
+		if len(word) >= spec.minLen && strings.HasPrefix(spec.name, word) {
+			if bang && (spec.name == "map" || spec.name == "noremap") {
+				spec.modes = []string{catalog.ModeInsert, catalog.ModeCommandLine}
+			}
+			return spec, true
+		}
+	}
+	return commandSpec{}, false
+}
+
+// NewAnalyzer creates an analyzer; lang selects the explanation language
+func NewAnalyzer(commands []catalog.Command, db *options.DB, lang string) *Analyzer {
+	return &Analyzer{Commands: commands, Options: db, Lang: lang}
+}
+
+// logicalLine is a vimrc command after joining "\" continuation lines
+type logicalLine struct {
+	number int
+	text   string
+}
+
+// readLines joins continuation lines and drops blank lines and comments
+func readLines(r io.Reader) ([]logicalLine, error) {
+	var lines []logicalLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(scanner.Text())
+
+		// A line starting with a backslash continues the previous command
+		if strings.HasPrefix(text, `\`) && len(lines) > 0 {
+			lines[len(lines)-1].text += strings.TrimPrefix(text, `\`)
+			continue
+		}
+		if text == "" || strings.HasPrefix(text, `"`) {
+			continue
+		}
+		lines = append(lines, logicalLine{number: number, text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// Analyze explains every command line of a vimrc
+func (an *Analyzer) Analyze(path string, r io.Reader) (*Report, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	an.leader, an.localLeader = DefaultLeader, DefaultLeader
+	report := &Report{Path: path, Counts: make(map[string]int)}
+	inFunction := false
+
+	for _, line := range lines {
+		text := strings.TrimLeft(line.text, ": \t")
+		word := commandWord(text)
+
+		// Function bodies run later, not when the vimrc is read
+		if inFunction {
+			if strings.HasPrefix(word, "endf") {
+				inFunction = false
+			}
+			continue
+		}
+		if word == "fu" || strings.HasPrefix(word, "fun") {
+			inFunction = true
+		}
+
+		finding := Finding{Line: line.number, Text: line.text}
+		args := strings.TrimSpace(strings.TrimPrefix(text, word))
+
+		spec, ok := lookupCommand(word)
+		if !ok {
+			finding.Kind = KindOther
+			finding.Explanation = []string{an.explainOther(word, args)}
+		} else {
+			finding.Kind = spec.kind
+			switch spec.kind {
+			case KindSet:
+				an.explainSet(&finding, spec, args)
+			case KindMap:
+				an.explainMap(&finding, spec, args)
+			case KindUnmap:
+				an.explainUnmap(&finding, spec, args)
+			case KindAutocmd:
+				an.explainAutocmd(&finding, word, args)
+			case KindAugroup:
+				an.explainAugroup(&finding, args)
+			case KindLet:
+				an.explainLet(&finding, args)
+			case KindCommand:
+				an.explainUserCommand(&finding, word, args)
+			}
+		}
+
+		report.Counts[finding.Kind]++
+		report.Warnings += len(finding.Warnings)
+		report.Findings = append(report.Findings, finding)
+	}
+
+	report.Leader = an.leader
+	return report, nil
+}
+
+var wordPattern = regexp.MustCompile(`^[A-Za-z]+!?`)
+
+// commandWord returns the Ex command name at the start of a line
+func commandWord(text string) string {
+	return wordPattern.FindString(text)
+}
+
+func (an *Analyzer) en() bool {
+	return an.Lang == "en"
+}
+
+func (an *Analyzer) pick(ko, en string) string {
+	if an.en() {
+		return en
+	}
+	return ko
+}
+
+// explainSet explains each argument of :set, :setlocal and :setglobal
+func (an *Analyzer) explainSet(f *Finding, spec commandSpec, args string) {
+	switch spec.name {
+	case "setlocal":
+		f.Explanation = append(f.Explanation, an.pick("현재 버퍼/창에만 적용합니다", "Applies to the current buffer/window only"))
+	case "setglobal":
+		f.Explanation = append(f.Explanation, an.pick("전역 값만 바꿉니다 (현재 버퍼/창에는 적용 안 됨)", "Changes only the global value, not the current buffer/window"))
+	}
+
+	list := options.SplitArgs(args)
+	if len(list) == 0 {
+		f.Explanation = append(f.Explanation, an.pick("기본값과 다른 옵션을 모두 보여줍니다", "Shows all options that differ from their default"))
+		return
+	}
+
+	for _, arg := range list {
+		a := an.Options.Parse(arg)
+		if a.Option == nil {
+			f.Explanation = append(f.Explanation, fmt.Sprintf("%s: %s", arg, an.pick("데이터베이스에 없는 옵션", "option not in the database")))
+			if a.Name == "" {
+				f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("옵션 이름을 읽을 수 없습니다: %s", "cannot read an option name from %s"), arg))
+			} else {
+				f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("알 수 없는 옵션입니다: %s (오타이거나 플러그인 옵션일 수 있음)", "unknown option %s (a typo or a plugin option)"), a.Name))
+			}
+			continue
+		}
+
+		name := a.Option.Name
+		if a.Option.Abbreviation != "" {
+			name = fmt.Sprintf("%s (%s)", a.Option.Name, a.Option.Abbreviation)
+		}
+		f.Explanation = append(f.Explanation, fmt.Sprintf("%s → %s: %s", name, a.Explain(an.Lang), a.Option.Describe(an.Lang)))
+
+		if (a.Operator == options.OpOff || a.Operator == options.OpToggle) && a.Option.Type != options.TypeBoolean {
+			f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("%s는 켬/끔 옵션이 아닙니다", "%s is not a boolean option"), a.Option.Name))
+		}
+		if a.Operator == options.OpAssign && a.Option.Type == options.TypeBoolean {
+			f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("%s는 켬/끔 옵션이라 값을 지정할 수 없습니다 (set %s 또는 set no%s)", "%s is a boolean option and takes no value (set %s or set no%s)"), a.Option.Name, a.Option.Name, a.Option.Name))
+		}
+		if a.Error != "" {
+			f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("%s는 숫자 옵션이라 정수 값이 필요합니다 (%s)", "%s is a number option and takes an integer (%s)"), a.Option.Name, a.Error))
+		}
+	}
+}
+
+// mapArguments are the <...> flags that may precede a mapping's left-hand side
+var mapArguments = map[string]string{
+	"<buffer>":  "현재 버퍼에만 적용|only in the current buffer",
+	"<silent>":  "실행할 때 명령줄에 표시하지 않음|not echoed on the command line",
+	"<expr>":    "오른쪽을 식으로 계산해 그 결과를 실행|the right-hand side is an expression",
+	"<nowait>":  "더 긴 매핑을 기다리지 않음|does not wait for longer mappings",
+	"<unique>":  "이미 매핑이 있으면 실패|fails when the mapping already exists",
+	"<script>":  "스크립트 안의 매핑만 재귀 적용|only remaps script-local mappings",
+	"<special>": "특수 키 표기를 항상 해석|always interpret special key notation",
+}
+
+// explainMap explains a :map family command and checks it against the catalog
+func (an *Analyzer) explainMap(f *Finding, spec commandSpec, args string) {
+	var flags []string
+	for {
+		lower := strings.ToLower(args)
+		found := false
+		for arg := range mapArguments {
+			if strings.HasPrefix(lower, arg) {
+				flags = append(flags, arg)
+				args = strings.TrimSpace(args[len(arg):])
+				found = true
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	lhs, rhs := splitFirst(args)
+	if lhs == "" {
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("%s 모드의 매핑을 모두 보여줍니다", "Lists all mappings for %s mode"), strings.Join(spec.modes, "/")))
+		return
+	}
+	if rhs == "" {
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("%s 매핑을 보여줍니다", "Shows the mapping for %s"), lhs))
+		return
+	}
+
+	keys := an.normalizeKeys(lhs)
+	recursion := an.pick("재귀 매핑 (오른쪽의 다른 매핑도 적용됨)", "recursive (mappings in the right-hand side apply)")
+	if spec.noremap {
+		recursion = an.pick("재귀 없음", "non-recursive")
+	}
+
+	f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("%s 모드 매핑, %s: %s → %s", "%s mode mapping, %s: %s → %s"),
+		strings.Join(spec.modes, "/"), recursion, keys, rhs))
+
+	sort.Strings(flags)
+	for _, flag := range flags {
+		parts := strings.SplitN(mapArguments[flag], "|", 2)
+		f.Explanation = append(f.Explanation, fmt.Sprintf("%s: %s", flag, an.pick(parts[0], parts[1])))
+	}
+
+	if explained := an.explainRHS(rhs); explained != "" {
+		f.Explanation = append(f.Explanation, explained)
+	}
+
+	if !spec.noremap && !strings.Contains(strings.ToLower(rhs), "<plug>") {
+		f.Warnings = append(f.Warnings, an.pick(
+			"재귀 매핑입니다 - 오른쪽이 다른 매핑에 영향을 받지 않도록 noremap 계열을 쓰는 것이 안전합니다",
+			"recursive mapping - prefer the noremap variant so the right-hand side is not remapped"))
+	}
+
+	f.Warnings = append(f.Warnings, an.shadowWarnings(keys, spec.modes)...)
+}
+
+// explainUnmap explains removing a mapping
+func (an *Analyzer) explainUnmap(f *Finding, spec commandSpec, args string) {
+	lhs, _ := splitFirst(args)
+	f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("%s 모드의 %s 매핑을 제거합니다", "Removes the %s mode mapping for %s"),
+		strings.Join(spec.modes, "/"), an.normalizeKeys(lhs)))
+}
+
+// explainRHS describes the right-hand side when it runs a catalog command
+func (an *Analyzer) explainRHS(rhs string) string {
+	lower := strings.ToLower(rhs)
+	if strings.HasPrefix(lower, "<plug>") {
+		return an.pick("플러그인이 제공하는 <Plug> 매핑을 실행합니다", "Runs a <Plug> mapping provided by a plugin")
+	}
+	if lower == "<nop>" {
+		return an.pick("아무 동작도 하지 않게 만듭니다 (키 비활성화)", "Does nothing (disables the keys)")
+	}
+
+	// ":w<CR>" and "<Cmd>w<CR>" run an Ex command
+	ex := ""
+	switch {
+	case strings.HasPrefix(rhs, ":"):
+		ex = rhs
+	case strings.HasPrefix(lower, "<cmd>"):
+		ex = ":" + rhs[len("<cmd>"):]
+	}
+	if ex != "" {
+		if end := strings.Index(strings.ToLower(ex), "<cr>"); end >= 0 {
+			ex = ex[:end]
+		}
+		if cmd, ok := an.findCommand(strings.TrimSpace(ex)); ok {
+			return fmt.Sprintf("%s: %s", cmd.Command, cmd.Description)
+		}
+		return fmt.Sprintf(an.pick("Ex 명령 %s를 실행합니다", "Runs the Ex command %s"), ex)
+	}
+
+	if cmd, ok := an.findCommand(an.normalizeKeys(rhs)); ok {
+		return fmt.Sprintf("%s: %s", cmd.Command, cmd.Description)
+	}
+	return ""
+}
+
+// findCommand looks a command up by name or alias
+func (an *Analyzer) findCommand(name string) (catalog.Command, bool) {
+	for _, cmd := range an.Commands {
+		if cmd.Command == name || cmd.MatchesAlias(name) {
+			return cmd, true
+		}
+	}
+	return catalog.Command{}, false
+}
+
+// shadowWarnings reports catalog commands hidden or delayed by a mapping of keys
+func (an *Analyzer) shadowWarnings(keys string, modes []string) []string {
+	var warnings []string
+	var delayed []string
+
+	for _, cmd := range an.Commands {
+		if !sharesMode(cmd, modes) {
+			continue
+		}
+		stem := literalStem(cmd.Command)
+		switch {
+		case cmd.Command == keys || cmd.MatchesAlias(keys):
+			warnings = append(warnings, fmt.Sprintf(an.pick("내장 명령어 %s(%s)를 덮어씁니다", "shadows the built-in command %s (%s)"), cmd.Command, cmd.Description))
+		case stem != cmd.Command && stem == keys:
+			warnings = append(warnings, fmt.Sprintf(an.pick("%s로 시작하는 내장 명령어 %s(%s)를 덮어씁니다", "shadows the built-in %s… command %s (%s)"), keys, cmd.Command, cmd.Description))
+		case len(keys) < len(cmd.Command) && strings.HasPrefix(cmd.Command, keys) && !strings.HasPrefix(cmd.Command, ":"):
+			delayed = append(delayed, cmd.Command)
+		}
+	}
+
+	if len(delayed) > 0 {
+		warnings = append(warnings, fmt.Sprintf(an.pick("%s를 입력하면 이 매핑 때문에 timeoutlen만큼 기다리게 됩니다", "typing %s now waits for timeoutlen because of this mapping"), strings.Join(delayed, ", ")))
+	}
+	return warnings
+}
+
+// sharesMode reports whether a catalog command applies in one of the mapping's modes.
+// Commands without mode data are assumed to be Normal mode commands.
+func sharesMode(cmd catalog.Command, modes []string) bool {
+	cmdModes := cmd.Modes
+	if len(cmdModes) == 0 {
+		cmdModes = []string{catalog.ModeNormal}
+	}
+	for _, m := range modes {
+		for _, c := range cmdModes {
+			if m == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// literalStem returns the part of a catalog command before its first {placeholder}
+func literalStem(command string) string {
+	if i := strings.Index(command, "{"); i > 0 {
+		return command[:i]
+	}
+	return command
+}
+
+var keyNotation = regexp.MustCompile(`<[^<>\s]+>`)
+
+// normalizeKeys converts Vim key notation into the catalog's notation:
+// <C-r> becomes Ctrl+r, <Esc> becomes Esc and <leader> the current leader key
+func (an *Analyzer) normalizeKeys(keys string) string {
+	return keyNotation.ReplaceAllStringFunc(keys, func(key string) string {
+		name := strings.ToLower(key[1 : len(key)-1])
+		switch {
+		case name == "leader":
+			return an.leader
+		case name == "localleader":
+			return an.localLeader
+		case name == "esc":
+			return "Esc"
+		case name == "space":
+			return " "
+		case name == "bar":
+			return "|"
+		case name == "bslash":
+			return `\`
+		case name == "lt":
+			return "<"
+		case strings.HasPrefix(name, "c-") && len(name) == 3:
+			return "Ctrl+" + name[2:]
+		}
+		return key
+	})
+}
+
+// explainAutocmd explains ":autocmd [group] {events} {pattern} {command}"
+func (an *Analyzer) explainAutocmd(f *Finding, word, args string) {
+	fields := strings.Fields(args)
+	if strings.HasSuffix(word, "!") {
+		if len(fields) == 0 {
+			f.Explanation = append(f.Explanation, an.pick("현재 그룹의 자동 명령을 모두 지웁니다 (다시 읽을 때 중복 방지)", "Removes all autocommands of the current group (avoids duplicates when re-sourcing)"))
+			return
+		}
+		f.Explanation = append(f.Explanation, an.pick("기존 자동 명령을 지우고 새로 정의합니다", "Removes the existing autocommands before defining this one"))
+	}
+	if len(fields) == 0 {
+		f.Explanation = append(f.Explanation, an.pick("자동 명령을 모두 보여줍니다", "Lists all autocommands"))
+		return
+	}
+
+	// An optional group name comes before the events
+	if !isEventList(fields[0]) && len(fields) > 1 && isEventList(fields[1]) {
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("그룹: %s", "Group: %s"), fields[0]))
+		fields = fields[1:]
+	}
+
+	var events []string
+	for _, name := range strings.Split(fields[0], ",") {
+		if desc, ok := lookupEvent(name); ok {
+			events = append(events, fmt.Sprintf("%s (%s)", name, an.pick(desc[0], desc[1])))
+		} else {
+			events = append(events, name)
+			f.Warnings = append(f.Warnings, fmt.Sprintf(an.pick("알 수 없는 이벤트입니다: %s", "unknown event %s"), name))
+		}
+	}
+	f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("이벤트: %s", "Events: %s"), strings.Join(events, ", ")))
+
+	if len(fields) > 1 {
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("대상 파일: %s", "Files: %s"), fields[1]))
+	}
+	if len(fields) > 2 {
+		rest := fields[2:]
+		for len(rest) > 0 && strings.HasPrefix(rest[0], "++") {
+			rest = rest[1:]
+		}
+		command := strings.Join(rest, " ")
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("실행: %s", "Runs: %s"), command))
+		if opt := an.setInCommand(command); opt != "" {
+			f.Explanation = append(f.Explanation, opt)
+		}
+	}
+}
+
+// setInCommand explains the options of a ":setlocal ..." run by an autocommand
+func (an *Analyzer) setInCommand(command string) string {
+	text := strings.TrimLeft(command, ": ")
+	word := commandWord(text)
+	spec, ok := lookupCommand(word)
+	if !ok || spec.kind != KindSet {
+		return ""
+	}
+	var sub Finding
+	an.explainSet(&sub, spec, strings.TrimSpace(strings.TrimPrefix(text, word)))
+	return strings.Join(sub.Explanation, "; ")
+}
+
+// explainAugroup explains the start and end of an autocommand group
+func (an *Analyzer) explainAugroup(f *Finding, args string) {
+	name := strings.TrimSpace(args)
+	if strings.EqualFold(name, "END") {
+		f.Explanation = append(f.Explanation, an.pick("자동 명령 그룹을 끝내고 기본 그룹으로 돌아갑니다", "Ends the autocommand group and returns to the default group"))
+		return
+	}
+	f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("자동 명령 그룹 %s를 시작합니다 - 이후 autocmd는 이 그룹에 속합니다", "Starts the autocommand group %s; following autocmds belong to it"), name))
+}
+
+// explainLet explains variable assignments, including mapleader and &options
+func (an *Analyzer) explainLet(f *Finding, args string) {
+	name, value := args, ""
+	if i := strings.Index(args, "="); i >= 0 {
+		name, value = strings.TrimSpace(args[:i]), strings.TrimSpace(args[i+1:])
+		name = strings.TrimRight(name, ".+-*/ ")
+	}
+	unquoted := strings.Trim(value, `"'`)
+
+	switch {
+	case name == "mapleader" || name == "g:mapleader":
+		an.leader = unquoted
+		shown := unquoted
+		if shown == " " {
+			shown = "<Space>"
+		}
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("리더 키를 %s로 정합니다 - 이후 매핑의 <leader>가 %s가 됩니다", "Sets the leader key to %s; <leader> in later mappings means %s"), shown, shown))
+	case name == "maplocalleader" || name == "g:maplocalleader":
+		an.localLeader = unquoted
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("로컬 리더 키를 %s로 정합니다 (<localleader>)", "Sets the local leader key to %s (<localleader>)"), unquoted))
+	case strings.HasPrefix(name, "&"):
+		optName := strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(name, "&"), "l:"), "g:")
+		if opt, ok := an.Options.Lookup(optName); ok {
+			f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("옵션 %s를 %s(으)로 설정합니다: %s", "Sets the option %s to %s: %s"), opt.Name, value, opt.Describe(an.Lang)))
+		} else {
+			f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("옵션 %s를 %s(으)로 설정합니다", "Sets the option %s to %s"), optName, value))
+		}
+	case strings.HasPrefix(name, "g:"):
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("전역 변수 %s = %s (대개 플러그인 설정)", "Global variable %s = %s (usually a plugin setting)"), name, value))
+	case strings.HasPrefix(name, "$"):
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("환경 변수 %s = %s", "Environment variable %s = %s"), name, value))
+	default:
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("변수 %s = %s", "Variable %s = %s"), name, value))
+	}
+}
+
+// explainUserCommand explains ":command[!] [attributes] {Name} {replacement}"
+func (an *Analyzer) explainUserCommand(f *Finding, word, args string) {
+	fields := strings.Fields(args)
+	var attrs []string
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "-") {
+		attrs = append(attrs, fields[0])
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		f.Explanation = append(f.Explanation, an.pick("사용자 정의 명령을 모두 보여줍니다", "Lists all user-defined commands"))
+		return
+	}
+
+	name := fields[0]
+	replacement := strings.Join(fields[1:], " ")
+	f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("사용자 명령 :%s를 정의합니다 → %s", "Defines the user command :%s → %s"), name, replacement))
+	if len(attrs) > 0 {
+		f.Explanation = append(f.Explanation, fmt.Sprintf(an.pick("속성: %s", "Attributes: %s"), strings.Join(attrs, " ")))
+	}
+	if !strings.HasSuffix(word, "!") {
+		f.Warnings = append(f.Warnings, an.pick("command! 를 쓰지 않으면 vimrc를 다시 읽을 때 E174 오류가 납니다", "without command! re-sourcing the vimrc fails with E174"))
+	}
+	if name != "" && !(name[0] >= 'A' && name[0] <= 'Z') {
+		f.Warnings = append(f.Warnings, an.pick("사용자 명령 이름은 대문자로 시작해야 합니다 (E183)", "user command names must start with an uppercase letter (E183)"))
+	}
+}
+
+// otherCommands explains common vimrc commands outside the main families
+var otherCommands = map[string][2]string{
+	"syntax":      {"문법 강조를 설정합니다", "Configures syntax highlighting"},
+	"filetype":    {"파일 종류 감지와 파일 종류별 플러그인/들여쓰기를 설정합니다", "Configures filetype detection, plugins and indenting"},
+	"colorscheme": {"색 구성을 바꿉니다", "Changes the color scheme"},
+	"source":      {"다른 Vim 스크립트 파일을 읽어 실행합니다", "Reads and runs another Vim script"},
+	"runtime":     {"runtimepath에서 스크립트를 찾아 실행합니다", "Runs a script found in runtimepath"},
+	"call":        {"함수를 호출합니다", "Calls a function"},
+	"function":    {"함수를 정의합니다 (본문은 호출될 때 실행)", "Defines a function (the body runs when called)"},
+	"if":          {"조건문 - 조건이 참일 때만 다음 줄들을 실행합니다", "Conditional: the following lines run only when true"},
+	"endif":       {"조건문 끝", "End of the conditional"},
+	"else":        {"조건이 거짓일 때 실행할 부분", "Part that runs when the condition is false"},
+	"highlight":   {"강조 그룹의 색을 정합니다", "Sets the colors of a highlight group"},
+	"packadd":     {"선택적 패키지(플러그인)를 불러옵니다", "Loads an optional package (plugin)"},
+	"plug":        {"vim-plug 플러그인을 등록합니다", "Registers a plugin with vim-plug"},
+	"execute":     {"문자열을 Ex 명령으로 실행합니다", "Runs a string as an Ex command"},
+}
+
+// otherAbbreviations maps accepted abbreviations to otherCommands keys
+var otherAbbreviations = map[string]string{
+	"sy": "syntax", "syn": "syntax", "filet": "filetype", "colo": "colorscheme", "so": "source",
+	"ru": "runtime", "cal": "call", "fu": "function", "fun": "function", "en": "endif",
+	"endi": "endif", "el": "else", "hi": "highlight", "pa": "packadd", "exe": "execute",
+}
+
+// explainOther explains a command outside the set/map/autocmd/let/command families
+func (an *Analyzer) explainOther(word, args string) string {
+	key := strings.ToLower(strings.TrimSuffix(word, "!"))
+	if full, ok := otherAbbreviations[key]; ok {
+		key = full
+	}
+	desc, ok := otherCommands[key]
+	if !ok {
+		return fmt.Sprintf(an.pick("%s 명령 (자세한 설명 없음 - :help :%s 참고)", "%s command (no details - see :help :%s)"), word, strings.TrimSuffix(word, "!"))
+	}
+	if args != "" {
+		return fmt.Sprintf("%s: %s", an.pick(desc[0], desc[1]), args)
+	}
+	return an.pick(desc[0], desc[1])
+}
+
+// splitFirst splits off the first whitespace-separated field
+func splitFirst(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, " \t")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i+1:])
+}
+
+// Candidates returns the usual vimrc locations in the order Vim and Neovim
+// read them: ~/.vimrc, ~/.vim/vimrc, then $XDG_CONFIG_HOME/nvim/init.vim
+func Candidates(home, configHome string) []string {
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	return []string{
+		filepath.Join(home, ".vimrc"),
+		filepath.Join(home, ".vim", "vimrc"),
+		filepath.Join(configHome, "nvim", "init.vim"),
+	}
+}
@@ -0,0 +1,180 @@
+package vimrc
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"vi-assistant/internal/catalog"
+	"vi-assistant/internal/options"
+)
+
+func analyzeFixture(t *testing.T) *Report {
+	t.Helper()
+
+	commands := []catalog.Command{
+		{Command: "x", Description: "delete a character", Modes: []string{catalog.ModeNormal}},
+		{Command: "dd", Description: "delete a line", Modes: []string{catalog.ModeNormal}},
+		{Command: "gg", Description: "go to the first line", Modes: []string{catalog.ModeNormal}},
+		{Command: "Ctrl+r", Description: "redo", Modes: []string{catalog.ModeNormal}},
+		{Command: "Esc", Description: "back to Normal mode", Modes: []string{catalog.ModeInsert}},
+		{Command: ":w", Description: "write the file", Modes: []string{catalog.ModeCommandLine}},
+	}
+	db := options.NewDB([]options.Option{
+		{Name: "compatible", Abbreviation: "cp", Type: options.TypeBoolean},
+		{Name: "number", Abbreviation: "nu", Type: options.TypeBoolean},
+		{Name: "relativenumber", Abbreviation: "rnu", Type: options.TypeBoolean},
+		{Name: "expandtab", Abbreviation: "et", Type: options.TypeBoolean},
+		{Name: "tabstop", Abbreviation: "ts", Type: options.TypeNumber},
+		{Name: "shiftwidth", Abbreviation: "sw", Type: options.TypeNumber},
+		{Name: "hlsearch", Abbreviation: "hls", Type: options.TypeBoolean},
+		{Name: "textwidth", Abbreviation: "tw", Type: options.TypeNumber},
+	})
+
+	f, err := os.Open("testdata/vimrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	report, err := NewAnalyzer(commands, db, "en").Analyze("testdata/vimrc", f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func findingAt(t *testing.T, report *Report, line int) Finding {
+	t.Helper()
+	for _, f := range report.Findings {
+		if f.Line == line {
+			return f
+		}
+	}
+	t.Fatalf("no finding for line %d", line)
+	return Finding{}
+}
+
+func contains(lines []string, part string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAnalyzeOptions(t *testing.T) {
+	report := analyzeFixture(t)
+
+	f := findingAt(t, report, 2)
+	if f.Kind != KindSet || !contains(f.Explanation, "compatible (cp) → off") {
+		t.Errorf("line 2 = %+v", f)
+	}
+	f = findingAt(t, report, 4)
+	if !contains(f.Explanation, "tabstop (ts) → set to 4") || !contains(f.Explanation, "shiftwidth (sw) → add 2") {
+		t.Errorf("line 4 = %+v", f)
+	}
+	if f = findingAt(t, report, 5); !contains(f.Explanation, "hlsearch (hls) → toggle") {
+		t.Errorf("line 5 = %+v", f)
+	}
+	if f = findingAt(t, report, 6); len(f.Warnings) != 2 || !contains(f.Warnings, "notaoption") {
+		t.Errorf("line 6 warnings = %q", f.Warnings)
+	}
+}
+
+func TestAnalyzeMappings(t *testing.T) {
+	report := analyzeFixture(t)
+
+	if report.Leader != "," {
+		t.Errorf("leader = %q", report.Leader)
+	}
+
+	f := findingAt(t, report, 12)
+	if f.Kind != KindMap || !contains(f.Explanation, ",w → :w<CR>") || !contains(f.Explanation, "write the file") || len(f.Warnings) != 0 {
+		t.Errorf("line 12 = %+v", f)
+	}
+
+	// Mappings that hide built-in commands are flagged
+	if f = findingAt(t, report, 13); !contains(f.Warnings, "shadows the built-in command x") {
+		t.Errorf("line 13 warnings = %q", f.Warnings)
+	}
+	if f = findingAt(t, report, 17); !contains(f.Warnings, "Ctrl+r") {
+		t.Errorf("line 17 warnings = %q", f.Warnings)
+	}
+	if f = findingAt(t, report, 16); !contains(f.Warnings, "gg") {
+		t.Errorf("line 16 warnings = %q", f.Warnings)
+	}
+
+	// <Plug> mappings are meant to be recursive
+	f = findingAt(t, report, 14)
+	if len(f.Warnings) != 0 || !contains(f.Explanation, "<silent>") {
+		t.Errorf("line 14 = %+v", f)
+	}
+
+	// An insert mode mapping to <Esc> does not shadow anything
+	if f = findingAt(t, report, 15); len(f.Warnings) != 0 || !contains(f.Explanation, "back to Normal mode") {
+		t.Errorf("line 15 = %+v", f)
+	}
+
+	// Mappings inside function bodies are not analyzed
+	for _, f := range report.Findings {
+		if f.Line == 30 {
+			t.Errorf("function body analyzed: %+v", f)
+		}
+	}
+}
+
+func TestAnalyzeAutocmdAndCommands(t *testing.T) {
+	report := analyzeFixture(t)
+
+	// The continuation line is joined to the autocmd
+	f := findingAt(t, report, 21)
+	if f.Kind != KindAutocmd || !contains(f.Explanation, "BufWritePre (before writing a file)") || !contains(f.Explanation, `:%s/\s\+$//e`) {
+		t.Errorf("line 21 = %+v", f)
+	}
+	if f = findingAt(t, report, 23); !contains(f.Explanation, "shiftwidth (sw) → set to 4") {
+		t.Errorf("line 23 = %+v", f)
+	}
+
+	if f = findingAt(t, report, 26); f.Kind != KindCommand || len(f.Warnings) != 0 {
+		t.Errorf("line 26 = %+v", f)
+	}
+	if f = findingAt(t, report, 27); len(f.Warnings) != 2 {
+		t.Errorf("line 27 warnings = %q", f.Warnings)
+	}
+
+	if report.Counts[KindMap] != 6 || report.Counts[KindAutocmd] != 3 {
+		t.Errorf("counts = %v", report.Counts)
+	}
+}
+
+func TestLookupCommand(t *testing.T) {
+	tests := []struct {
+		word    string
+		name    string
+		noremap bool
+	}{
+		{"nn", "nnoremap", true},
+		{"nno", "nnoremap", true},
+		{"nmap", "nmap", false},
+		{"ino", "inoremap", true},
+		{"au", "autocmd", false},
+		{"se", "set", false},
+		{"setl", "setlocal", false},
+	}
+	for _, tt := range tests {
+		spec, ok := lookupCommand(tt.word)
+		if !ok || spec.name != tt.name || spec.noremap != tt.noremap {
+			t.Errorf("lookupCommand(%q) = %+v, %v", tt.word, spec, ok)
+		}
+	}
+
+	spec, _ := lookupCommand("map!")
+	if len(spec.modes) != 2 || spec.modes[0] != catalog.ModeInsert {
+		t.Errorf("map! modes = %v", spec.modes)
+	}
+	if _, ok := lookupCommand("s"); ok {
+		t.Error("lookupCommand(s) matched")
+	}
+}